@@ -3,17 +3,32 @@ package cmd
 import (
 	"net/url"
 
+	"github.com/knadh/koanf/v2"
+
 	"github.com/theopenlane/openlane-cloud/internal/client"
 )
 
-// SetupClient will setup the openlane cloud client
+// SetupClient will setup the openlane cloud client; the credentials, headers, TLS files, timeout, and retry policy are
+// read from the client section of the config file and the OPENLANECLOUD_CLIENT_ environment variables
 func SetupClient(host string) (client.Client, error) {
 	config := client.NewDefaultConfig()
+	config.UserAgent = appName + "-cli"
 
-	baseURL, err := url.Parse(host)
-	if err != nil {
+	if err := Config.UnmarshalWithConf("client", &config, koanf.UnmarshalConf{Tag: "koanf"}); err != nil {
 		return nil, err
 	}
 
-	return client.New(config, client.WithBaseURL(baseURL))
+	opts := []client.Option{}
+
+	// the host flag takes precedence over the default base url
+	if host != "" {
+		baseURL, err := url.Parse(host)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, client.WithBaseURL(baseURL))
+	}
+
+	return client.New(config, opts...)
 }
@@ -3,6 +3,7 @@ package organization
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd"
 	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd/prompts"
+	"github.com/theopenlane/openlane-cloud/internal/client"
 	"github.com/theopenlane/openlane-cloud/internal/v1/models"
 )
 
@@ -29,7 +31,20 @@ func init() {
 	organizationCreateCmd.Flags().StringP("name", "n", "", "name of the organization")
 	organizationCreateCmd.Flags().StringP("description", "d", "", "description of the organization")
 	organizationCreateCmd.Flags().StringSlice("domains", []string{}, "domains associated with the organization")
+	organizationCreateCmd.Flags().StringP("template", "t", "", "name of the blueprint used to create the hierarchy, optionally with @version")
+	organizationCreateCmd.Flags().String("billing-contact", "", "name of the billing contact for the organization")
+	organizationCreateCmd.Flags().String("billing-email", "", "email address of the billing contact for the organization")
+	organizationCreateCmd.Flags().String("billing-phone", "", "phone number of the billing contact for the organization")
+	organizationCreateCmd.Flags().StringSlice("allowed-email-domains", []string{}, "email domains of users that can be added to the organization")
+	organizationCreateCmd.Flags().String("avatar-url", "", "url of the avatar shown for the organization")
+	organizationCreateCmd.Flags().String("geo-location", "", "region of the organization: AMER, EMEA, or APAC")
+	organizationCreateCmd.Flags().String("tax-identifier", "", "government-issued tax or business id of the organization")
+	organizationCreateCmd.Flags().StringSlice("settings-tags", []string{}, "tags added to the organization settings")
+	organizationCreateCmd.Flags().StringSlice("inherit", []string{}, "settings also applied to the environments and buckets, one of: "+strings.Join(models.InheritableSettings, ", "))
+	organizationCreateCmd.Flags().StringSlice("invites", []string{}, "email addresses of users to invite to the organization, optionally with the role as email=ADMIN")
+	organizationCreateCmd.Flags().StringSlice("groups", []string{}, "names of groups to create in every environment")
 	organizationCreateCmd.Flags().BoolP("interactive", "i", true, "interactive prompt, set to false to disable")
+	organizationCreateCmd.Flags().Bool("dry-run", false, "validate the request and print the organizations that would be created without creating them")
 }
 
 func createOrganization(ctx context.Context) error {
@@ -61,19 +76,33 @@ func createOrganization(ctx context.Context) error {
 		}
 	}
 
+	// the environments only apply to the default hierarchy
+	template := cmd.Config.String("template")
+
 	environments := cmd.Config.Strings("environments")
-	if len(environments) == 0 && interactive {
+	if len(environments) == 0 && template == "" && interactive {
 		environments, err = prompts.Environments()
 		cobra.CheckErr(err)
 
 		fmt.Println("Environments: ", environments)
 	}
 
-	input := models.OrganizationRequest{
+	settings, err := organizationSettings(interactive)
+	cobra.CheckErr(err)
+
+	input := client.OrganizationRequest{
 		Name:         name,
 		Description:  description,
 		Domains:      domains,
+		Template:     template,
 		Environments: environments,
+		Settings:     settings,
+		Invites:      organizationInvites(flagList("invites")),
+		Groups:       organizationGroups(flagList("groups")),
+	}
+
+	if cmd.Config.Bool("dry-run") {
+		return planOrganization(ctx, c, &input)
 	}
 
 	// add an empty line
@@ -98,7 +127,7 @@ func createOrganization(ctx context.Context) error {
 
 	var (
 		wg sync.WaitGroup
-		ws *models.OrganizationReply
+		ws *client.OrganizationReply
 	)
 
 	// create a wait channel
@@ -133,6 +162,10 @@ func createOrganization(ctx context.Context) error {
 		fmt.Println("Domains: ", strings.Join(ws.Domains, ","))
 	}
 
+	if ws.Template != "" {
+		fmt.Println("Template: ", ws.Template)
+	}
+
 	for _, env := range ws.Environments {
 		// add an empty line
 		fmt.Println()
@@ -151,9 +184,155 @@ func createOrganization(ctx context.Context) error {
 		fmt.Println()
 	}
 
+	if len(ws.Children) > 0 {
+		// add an empty line
+		fmt.Println()
+
+		printChildren(ws.Children, 1)
+	}
+
+	for _, invite := range ws.Invites {
+		fmt.Printf("Invited: %s (%s) %s\n", invite.Email, invite.Role, invite.ID)
+	}
+
+	for _, group := range ws.Groups {
+		fmt.Printf("Group: %s in %s %s\n", group.Name, group.OrganizationID, group.ID)
+	}
+
+	return nil
+}
+
+// organizationSettings returns the settings block for the request from the flags, prompting for the billing contact
+// when interactive; nil is returned when no settings are set
+func organizationSettings(interactive bool) (*client.OrganizationSettings, error) {
+	var err error
+
+	billingContact := cmd.Config.String("billing-contact")
+	if billingContact == "" && interactive {
+		billingContact, err = prompts.BillingContact()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	billingEmail := cmd.Config.String("billing-email")
+	if billingEmail == "" && interactive {
+		billingEmail, err = prompts.BillingEmail()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	settings := client.OrganizationSettings{
+		AllowedEmailDomains: flagList("allowed-email-domains"),
+		AvatarURL:           cmd.Config.String("avatar-url"),
+		BillingContact:      billingContact,
+		BillingEmail:        billingEmail,
+		BillingPhone:        cmd.Config.String("billing-phone"),
+		GeoLocation:         cmd.Config.String("geo-location"),
+		TaxIdentifier:       cmd.Config.String("tax-identifier"),
+		Tags:                flagList("settings-tags"),
+		Inherit:             flagList("inherit"),
+	}
+
+	if reflect.ValueOf(settings).IsZero() {
+		return nil, nil
+	}
+
+	return &settings, nil
+}
+
+// organizationInvites returns the invites from the values of the invites flag, each an email address optionally
+// followed by =ROLE
+func organizationInvites(values []string) []client.OrganizationInvite {
+	var out []client.OrganizationInvite
+
+	for _, v := range values {
+		email, role, _ := strings.Cut(v, "=")
+
+		out = append(out, client.OrganizationInvite{Email: email, Role: role})
+	}
+
+	return out
+}
+
+// organizationGroups returns the groups created in every environment from the values of the groups flag
+func organizationGroups(names []string) []client.OrganizationGroup {
+	var out []client.OrganizationGroup
+
+	for _, name := range names {
+		out = append(out, client.OrganizationGroup{Name: name})
+	}
+
+	return out
+}
+
+// flagList returns the values of a list flag, nil when the flag is not set
+func flagList(name string) []string {
+	if values := cmd.Config.Strings(name); len(values) > 0 {
+		return values
+	}
+
+	return nil
+}
+
+// planOrganization prints the organizations that would be created for the request and any name collisions
+func planOrganization(ctx context.Context, c client.Client, input *client.OrganizationRequest) error {
+	plan, err := c.OrganizationPlan(ctx, input)
+	cobra.CheckErr(err)
+
+	fmt.Println("Template: ", plan.Template)
+	fmt.Println("Organizations that would be created: ", plan.Total)
+
+	// add an empty line
+	fmt.Println()
+
+	if plan.Organization != nil {
+		printPlanned([]client.PlannedOrg{*plan.Organization}, 0)
+	}
+
+	if len(plan.Collisions) > 0 {
+		// add an empty line
+		fmt.Println()
+
+		fmt.Println("Organizations that already exist:")
+
+		for _, org := range plan.Collisions {
+			fmt.Printf("--> %s (%s)\n", org.Name, org.ID)
+		}
+	}
+
 	return nil
 }
 
+// printPlanned prints the planned organizations with their unique name and tags, indented by their depth in the hierarchy
+func printPlanned(orgs []client.PlannedOrg, depth int) {
+	for _, org := range orgs {
+		line := fmt.Sprintf("%s> %s [%s]", strings.Repeat("---", depth), org.DisplayName, org.Name)
+
+		if len(org.Tags) > 0 {
+			line += " tags: " + strings.Join(org.Tags, ",")
+		}
+
+		if org.ExistingID != "" {
+			line += " (exists: " + org.ExistingID + ")"
+		}
+
+		fmt.Println(line)
+
+		printPlanned(org.Children, depth+1)
+	}
+}
+
+// printChildren prints the organizations created from a blueprint, indented by their depth in the hierarchy
+func printChildren(nodes []client.OrgNode, depth int) {
+	for _, node := range nodes {
+		fmt.Printf("%s> %s\n", strings.Repeat("---", depth), node.Name)
+
+		printChildren(node.Children, depth+1)
+	}
+}
+
 // wait will wait for the wait group to finish and update the progress bar
 func wait(waitCh chan struct{}, bar *progressbar.ProgressBar) {
 	for {
@@ -0,0 +1,52 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd"
+)
+
+var organizationDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an openlane org and every org underneath it",
+	RunE: func(command *cobra.Command, _ []string) error {
+		return deleteOrganization(command.Context())
+	},
+}
+
+func init() {
+	organizationCmd.AddCommand(organizationDeleteCmd)
+
+	organizationDeleteCmd.Flags().String("id", "", "id of the root organization to delete")
+	organizationDeleteCmd.Flags().Bool("dry-run", false, "list the organizations that would be deleted without deleting them")
+}
+
+func deleteOrganization(ctx context.Context) error {
+	id := cmd.Config.String("id")
+	if id == "" {
+		return cmd.NewRequiredFieldMissingError("id")
+	}
+
+	c, err := cmd.SetupClient(cmd.Config.String("host"))
+	cobra.CheckErr(err)
+
+	dryRun := cmd.Config.Bool("dry-run")
+
+	out, err := c.OrganizationDelete(ctx, id, dryRun)
+	cobra.CheckErr(err)
+
+	if dryRun {
+		fmt.Println("Organizations that would be deleted:")
+	} else {
+		fmt.Println("Deleted Organizations:")
+	}
+
+	for _, org := range out.Organizations {
+		fmt.Printf("--> %s (%s)\n", org.Name, org.ID)
+	}
+
+	return nil
+}
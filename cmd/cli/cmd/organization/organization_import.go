@@ -0,0 +1,117 @@
+package organization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd"
+	"github.com/theopenlane/openlane-cloud/internal/client"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// jobPollInterval is how often the bulk provisioning job is checked until it has finished
+const jobPollInterval = time.Second
+
+var organizationImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create openlane orgs for every organization in a csv or json file",
+	RunE: func(command *cobra.Command, _ []string) error {
+		return importOrganizations(command.Context())
+	},
+}
+
+func init() {
+	organizationCmd.AddCommand(organizationImportCmd)
+
+	organizationImportCmd.Flags().StringP("file", "f", "", "csv or json file containing the organizations to create")
+	organizationImportCmd.Flags().Bool("dry-run", false, "validate the organizations and print the results without creating them")
+}
+
+func importOrganizations(ctx context.Context) error {
+	file := cmd.Config.String("file")
+	if file == "" {
+		return cmd.NewRequiredFieldMissingError("file")
+	}
+
+	input, err := readOrganizations(file)
+	cobra.CheckErr(err)
+
+	if cmd.Config.Bool("dry-run") {
+		for i := range input {
+			input[i].DryRun = true
+		}
+	}
+
+	c, err := cmd.SetupClient(cmd.Config.String("host"))
+	cobra.CheckErr(err)
+
+	submitted, err := c.OrganizationBulkCreate(ctx, input)
+	cobra.CheckErr(err)
+
+	fmt.Printf("--> waiting for job %s to create %d organizations\n", submitted.Job.ID, submitted.Job.Total)
+
+	job, err := client.WaitForJob(ctx, c, submitted.Job.ID, jobPollInterval)
+	cobra.CheckErr(err)
+
+	// a finished bulk job always contains the result of every organization
+	out := job.Job.BulkResult
+
+	for _, r := range out.Results {
+		switch {
+		case !r.Success:
+			fmt.Printf("--> [%d] %s: failed (%d) %s\n", r.Index, r.Name, r.Status, r.Error)
+		case r.Plan != nil:
+			fmt.Printf("--> [%d] %s: %d organizations would be created from %s\n", r.Index, r.Name, r.Plan.Total, r.Plan.Template)
+		default:
+			fmt.Printf("--> [%d] %s: created (%s)\n", r.Index, r.Name, r.Organization.ID)
+		}
+	}
+
+	fmt.Printf("\nSucceeded: %d, Failed: %d\n", out.Succeeded, out.Failed)
+
+	return nil
+}
+
+// readOrganizations reads the organization requests from a json file containing an array of organizations, or from a
+// csv file with a header row
+func readOrganizations(file string) ([]client.OrganizationRequest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []client.OrganizationRequest
+
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		if err := json.NewDecoder(f).Decode(&out); err != nil {
+			return nil, err
+		}
+
+		return out, nil
+	}
+
+	rows, err := models.ParseOrganizationsCSV(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows are parsed into the request of the server, which has the same JSON as the request of the client
+	body, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
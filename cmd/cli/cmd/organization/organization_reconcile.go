@@ -0,0 +1,94 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd"
+	"github.com/theopenlane/openlane-cloud/internal/client"
+)
+
+var organizationReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Compare an existing openlane org with the expected hierarchy and optionally repair it",
+	RunE: func(command *cobra.Command, _ []string) error {
+		return reconcileOrganization(command.Context(), command)
+	},
+}
+
+func init() {
+	organizationCmd.AddCommand(organizationReconcileCmd)
+
+	organizationReconcileCmd.Flags().String("id", "", "id of the root organization to reconcile")
+	organizationReconcileCmd.Flags().StringP("template", "t", "", "name of the blueprint the hierarchy is expected to match, optionally with @version")
+	organizationReconcileCmd.Flags().StringSlice("environments", []string{}, "expected environments, instead of a template")
+	organizationReconcileCmd.Flags().StringSlice("buckets", []string{}, "expected buckets, instead of a template")
+	organizationReconcileCmd.Flags().StringSlice("relationships", []string{}, "expected relationships, instead of a template")
+	organizationReconcileCmd.Flags().Bool("repair", false, "create missing organizations and restore renamed organizations")
+	organizationReconcileCmd.Flags().Bool("prune", false, "delete organizations that are not in the expected hierarchy when repairing")
+}
+
+func reconcileOrganization(ctx context.Context, command *cobra.Command) error {
+	id := cmd.Config.String("id")
+	if id == "" {
+		return cmd.NewRequiredFieldMissingError("id")
+	}
+
+	c, err := cmd.SetupClient(cmd.Config.String("host"))
+	cobra.CheckErr(err)
+
+	input := client.OrganizationReconcileRequest{
+		Template: cmd.Config.String("template"),
+		Repair:   cmd.Config.Bool("repair"),
+		Prune:    cmd.Config.Bool("prune"),
+	}
+
+	// only send the hierarchy lists that were set so the server defaults the others
+	if command.Flags().Changed("environments") {
+		input.Environments = cmd.Config.Strings("environments")
+	}
+
+	if command.Flags().Changed("buckets") {
+		input.Buckets = cmd.Config.Strings("buckets")
+	}
+
+	if command.Flags().Changed("relationships") {
+		input.Relationships = cmd.Config.Strings("relationships")
+	}
+
+	out, err := c.OrganizationReconcile(ctx, id, &input)
+	cobra.CheckErr(err)
+
+	fmt.Println("Template: ", out.Template)
+
+	if out.InSync {
+		fmt.Println("Organization hierarchy is in sync")
+
+		return nil
+	}
+
+	for _, m := range out.Missing {
+		fmt.Printf("missing: %s (+%d)\n", m.Path, m.Descendants)
+	}
+
+	for _, e := range out.Extra {
+		fmt.Printf("extra:   %s (%s, +%d)\n", e.Path, e.ID, e.Descendants)
+	}
+
+	for _, r := range out.Renamed {
+		fmt.Printf("renamed: %s (%s) is named %s\n", r.Path, r.ID, r.Name)
+	}
+
+	if !out.Repaired {
+		return nil
+	}
+
+	// add an empty line
+	fmt.Println()
+
+	fmt.Printf("Repaired: %d renamed, %d created, %d deleted\n", len(out.Renamed), len(out.Created), len(out.Deleted))
+
+	return nil
+}
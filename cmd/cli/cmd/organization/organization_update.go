@@ -0,0 +1,61 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd"
+	"github.com/theopenlane/openlane-cloud/internal/client"
+)
+
+var organizationUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Add environments, buckets, or relationships to an existing openlane org",
+	RunE: func(command *cobra.Command, _ []string) error {
+		return updateOrganization(command.Context())
+	},
+}
+
+func init() {
+	organizationCmd.AddCommand(organizationUpdateCmd)
+
+	organizationUpdateCmd.Flags().String("id", "", "id of the root organization to update")
+	organizationUpdateCmd.Flags().StringSlice("environments", []string{}, "environments to add to the organization")
+	organizationUpdateCmd.Flags().StringSlice("buckets", []string{}, "buckets to add to every environment")
+	organizationUpdateCmd.Flags().StringSlice("relationships", []string{}, "relationships to add to every environment")
+}
+
+func updateOrganization(ctx context.Context) error {
+	id := cmd.Config.String("id")
+	if id == "" {
+		return cmd.NewRequiredFieldMissingError("id")
+	}
+
+	c, err := cmd.SetupClient(cmd.Config.String("host"))
+	cobra.CheckErr(err)
+
+	input := client.OrganizationUpdateRequest{
+		Environments:  cmd.Config.Strings("environments"),
+		Buckets:       cmd.Config.Strings("buckets"),
+		Relationships: cmd.Config.Strings("relationships"),
+	}
+
+	out, err := c.OrganizationUpdate(ctx, id, &input)
+	cobra.CheckErr(err)
+
+	if len(out.Created) == 0 {
+		fmt.Println("Organization is already up to date")
+
+		return nil
+	}
+
+	fmt.Println("Created Organizations:")
+
+	for _, org := range out.Created {
+		fmt.Printf("--> %s (%s)\n", org.Name, org.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package prompts
+
+import (
+	"github.com/manifoldco/promptui"
+)
+
+func BillingContact() (string, error) {
+	prompt := promptui.Prompt{
+		Label:     "Billing Contact (optional):",
+		Templates: templates,
+	}
+
+	return prompt.Run()
+}
+
+func BillingEmail() (string, error) {
+	prompt := promptui.Prompt{
+		Label:     "Billing Email (optional):",
+		Templates: templates,
+	}
+
+	return prompt.Run()
+}
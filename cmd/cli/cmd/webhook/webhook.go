@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd"
+)
+
+// webhookCmd represents the base webhook command when called without any subcommands
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "the subcommands for working with openlane-cloud webhooks",
+}
+
+func init() {
+	cmd.RootCmd.AddCommand(webhookCmd)
+}
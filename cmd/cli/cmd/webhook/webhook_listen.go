@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/theopenlane/openlane-cloud/cmd/cli/cmd"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
+)
+
+var webhookListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "start a local receiver that validates the signature of webhooks and prints them",
+	RunE: func(command *cobra.Command, _ []string) error {
+		return listen(command.Context())
+	},
+}
+
+func init() {
+	webhookCmd.AddCommand(webhookListenCmd)
+
+	webhookListenCmd.Flags().String("listen", ":17611", "address to receive webhooks on")
+	webhookListenCmd.Flags().String("secret", "", "secret used to sign the webhooks, server.webhooks.secret on the server")
+	webhookListenCmd.Flags().Duration("tolerance", webhook.DefaultTolerance, "maximum age of a webhook signature")
+}
+
+func listen(ctx context.Context) error {
+	secret := cmd.Config.String("secret")
+	if secret == "" {
+		return cmd.NewRequiredFieldMissingError("secret")
+	}
+
+	tolerance := cmd.Config.Duration("tolerance")
+
+	srv := &http.Server{
+		Addr:              cmd.Config.String("listen"),
+		ReadHeaderTimeout: 2 * time.Second, //nolint:mnd
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			if err := webhook.Verify(secret, r.Header, body, tolerance); err != nil {
+				fmt.Printf("rejected %s %s: %s\n", r.Header.Get(webhook.EventHeader), r.Header.Get(webhook.IDHeader), err)
+
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			fmt.Printf("received %s %s\n", r.Header.Get(webhook.EventHeader), r.Header.Get(webhook.IDHeader))
+
+			var out bytes.Buffer
+			if err := json.Indent(&out, body, "", "  "); err == nil {
+				fmt.Println(out.String())
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	fmt.Printf("listening for webhooks on %s\n", srv.Addr)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
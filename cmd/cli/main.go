@@ -5,6 +5,7 @@ import (
 
 	_ "github.com/theopenlane/openlane-cloud/cmd/cli/cmd/organization"
 	_ "github.com/theopenlane/openlane-cloud/cmd/cli/cmd/seed"
+	_ "github.com/theopenlane/openlane-cloud/cmd/cli/cmd/webhook"
 )
 
 func main() {
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -31,6 +34,11 @@ func serve(ctx context.Context) error {
 	serverOpts = append(serverOpts,
 		serveropts.WithConfigProvider(&config.ProviderWithRefresh{}),
 		serveropts.WithOpenlaneClient(),
+		serveropts.WithProvisioning(),
+		serveropts.WithIdempotency(),
+		serveropts.WithWebhooks(),
+		serveropts.WithAudit(),
+		serveropts.WithAuth(),
 		serveropts.WithHTTPS(),
 		serveropts.WithMiddleware(),
 		serveropts.WithRateLimiter(),
@@ -44,6 +52,10 @@ func serve(ctx context.Context) error {
 
 	srv := server.NewServer(so.Config)
 
+	// the server shuts down gracefully when it is interrupted or terminated
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	if err := srv.StartEchoServer(ctx); err != nil {
 		log.Error().Err(err).Msg("failed to run server")
 	}
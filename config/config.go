@@ -13,6 +13,12 @@ import (
 	"github.com/mcuadros/go-defaults"
 	"github.com/theopenlane/beacon/otelx"
 	"github.com/theopenlane/core/pkg/middleware/ratelimit"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/audit"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/validation"
+	"github.com/theopenlane/openlane-cloud/internal/idempotency"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
 )
 
 var (
@@ -29,6 +35,8 @@ type Config struct {
 	Tracer otelx.Config `json:"tracer" koanf:"tracer"`
 	// Ratelimit contains the configuration for the rate limiter
 	Ratelimit ratelimit.Config `json:"ratelimit" koanf:"ratelimit"`
+	// Auth contains the configuration for authenticating requests to the versioned API
+	Auth auth.Config `json:"auth" koanf:"auth"`
 }
 
 // Server settings for the echo server
@@ -39,7 +47,7 @@ type Server struct {
 	Dev bool `json:"dev" koanf:"dev" default:"false"`
 	// Listen sets the listen address to serve the echo server on
 	Listen string `json:"listen" koanf:"listen" jsonschema:"required" default:":17610"`
-	// ShutdownGracePeriod sets the grace period for in flight requests before shutting down
+	// ShutdownGracePeriod sets the grace period for in flight requests, provisioning jobs, and queued webhook deliveries before shutting down
 	ShutdownGracePeriod time.Duration `json:"shutdown_grace_period" koanf:"shutdown_grace_period" default:"10s"`
 	// ReadTimeout sets the maximum duration for reading the entire request including the body
 	ReadTimeout time.Duration `json:"read_timeout" koanf:"read_timeout" default:"15s"`
@@ -55,6 +63,16 @@ type Server struct {
 	CORS CORS `json:"cors" koanf:"cors"`
 	// Openlane contains the token for the openlane server
 	Openlane Openlane `json:"openlane" koanf:"openlane"`
+	// Provisioning contains the settings for provisioning organization hierarchies
+	Provisioning Provisioning `json:"provisioning" koanf:"provisioning"`
+	// Idempotency contains the settings for replaying requests made with an Idempotency-Key header
+	Idempotency idempotency.Config `json:"idempotency" koanf:"idempotency"`
+	// Webhooks contains the settings for notifying other systems when organization hierarchies change
+	Webhooks webhook.Config `json:"webhooks" koanf:"webhooks"`
+	// Audit contains the settings for the audit log of provisioning actions
+	Audit audit.Config `json:"audit" koanf:"audit"`
+	// Validation contains the settings for validating requests against the OpenAPI specification
+	Validation validation.Config `json:"validation" koanf:"validation"`
 }
 
 // CORS settings for the server to allow cross origin requests
@@ -72,6 +90,29 @@ type CORS struct {
 type Openlane struct {
 	// Token is the token used to authenticate with the openlane server
 	Token string `json:"token" koanf:"token"`
+	// ForwardCredentials uses the openlane token of the caller for every request made to the openlane server instead of
+	// the server token, so organizations are created by and owned by the caller
+	ForwardCredentials bool `json:"forward_credentials" koanf:"forward_credentials" default:"false"`
+	// ClientCacheSize is the maximum number of caller clients kept when credentials are forwarded
+	ClientCacheSize int `json:"client_cache_size" koanf:"client_cache_size" default:"100"`
+}
+
+// Provisioning settings for creating organization hierarchies
+type Provisioning struct {
+	// Workers is the number of background workers processing asynchronous provisioning jobs
+	Workers int `json:"workers" koanf:"workers" default:"5"`
+	// QueueSize is the maximum number of asynchronous provisioning jobs waiting for a worker
+	QueueSize int `json:"queue_size" koanf:"queue_size" default:"100"`
+	// JobRetention is how long finished asynchronous provisioning jobs can be queried before they are removed
+	JobRetention time.Duration `json:"job_retention" koanf:"job_retention" default:"1h"`
+	// Concurrency is the maximum number of child organizations created in parallel for a single hierarchy
+	Concurrency int `json:"concurrency" koanf:"concurrency" default:"5"`
+	// Blueprints is a directory containing additional yaml or json hierarchy blueprints that can be selected by template
+	Blueprints string `json:"blueprints" koanf:"blueprints"`
+	// BulkConcurrency is the maximum number of organization hierarchies created in parallel for a single bulk request
+	BulkConcurrency int `json:"bulk_concurrency" koanf:"bulk_concurrency" default:"3"`
+	// MaxBulkSize is the maximum number of organizations that can be created by a single bulk request
+	MaxBulkSize int `json:"max_bulk_size" koanf:"max_bulk_size" default:"100"`
 }
 
 // TLS settings for the server for secure connections
@@ -0,0 +1,192 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+)
+
+const (
+	testIssuer   = "https://auth.example.com"
+	testAudience = "openlane-cloud"
+)
+
+// newKeySet writes a JWKS file containing the public key of a new signing key and returns the private key
+func newKeySet(t *testing.T) (jwk.Key, string) {
+	t.Helper()
+
+	raw, err := rsa.GenerateKey(rand.Reader, 2048) //nolint:mnd
+	require.NoError(t, err)
+
+	key, err := jwk.FromRaw(raw)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, "test"))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, jwa.RS256))
+
+	pub, err := key.PublicKey()
+	require.NoError(t, err)
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(pub))
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0600)) //nolint:mnd
+
+	return key, path
+}
+
+// signToken returns a signed JWT for the subject
+func signToken(t *testing.T, key jwk.Key, subject, issuer string, expires time.Time) string {
+	t.Helper()
+
+	tok, err := jwt.NewBuilder().
+		Subject(subject).
+		Issuer(issuer).
+		Audience([]string{testAudience}).
+		IssuedAt(time.Now()).
+		Expiration(expires).
+		Build()
+	require.NoError(t, err)
+
+	signed, err := jwt.Sign(tok, jwt.WithKey(jwa.RS256, key))
+	require.NoError(t, err)
+
+	return string(signed)
+}
+
+func TestNew(t *testing.T) {
+	_, err := auth.New(auth.Config{Enabled: true})
+	require.ErrorIs(t, err, auth.ErrNoCredentialsConfigured)
+
+	_, err = auth.New(auth.Config{Enabled: true, JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+	require.Error(t, err)
+}
+
+func TestAuthenticate(t *testing.T) {
+	key, path := newKeySet(t)
+	other, _ := newKeySet(t)
+
+	a, err := auth.New(auth.Config{
+		Enabled:   true,
+		APITokens: map[string]string{"ci": "ci-token"},
+		JWKSFile:  path,
+		Issuer:    testIssuer,
+		Audience:  testAudience,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		token       string
+		wantSubject string
+		wantMethod  auth.Method
+		wantErr     error
+	}{
+		{
+			name:        "api token",
+			token:       "ci-token",
+			wantSubject: "ci",
+			wantMethod:  auth.MethodAPIToken,
+		},
+		{
+			name:        "jwt",
+			token:       signToken(t, key, "user@example.com", testIssuer, time.Now().Add(time.Hour)),
+			wantSubject: "user@example.com",
+			wantMethod:  auth.MethodJWT,
+		},
+		{
+			name:    "missing token",
+			wantErr: auth.ErrMissingToken,
+		},
+		{
+			name:    "unknown api token",
+			token:   "not-a-token",
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "expired jwt",
+			token:   signToken(t, key, "user@example.com", testIssuer, time.Now().Add(-time.Hour)),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "wrong issuer",
+			token:   signToken(t, key, "user@example.com", "https://other.example.com", time.Now().Add(time.Hour)),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "unknown signing key",
+			token:   signToken(t, other, "user@example.com", testIssuer, time.Now().Add(time.Hour)),
+			wantErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			caller, err := a.Authenticate(tc.token)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSubject, caller.Subject)
+			assert.Equal(t, tc.wantMethod, caller.Method)
+			assert.Equal(t, tc.token, caller.Token)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	a, err := auth.New(auth.Config{Enabled: true, APITokens: map[string]string{"ci": "ci-token"}})
+	require.NoError(t, err)
+
+	handler := auth.Middleware(a)(func(ctx echo.Context) error {
+		caller, ok := auth.CallerFromContext(ctx.Request().Context())
+		require.True(t, ok)
+
+		return ctx.String(http.StatusOK, caller.Subject)
+	})
+
+	// requests without a valid token are rejected
+	for _, header := range []string{"", "Bearer wrong", "Basic ci-token"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/organization/1", nil)
+		if header != "" {
+			req.Header.Set(echo.HeaderAuthorization, header)
+		}
+
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler(echo.New().NewContext(req, rec)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, header)
+		assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+	}
+
+	// authenticated requests have the caller in the request context
+	req := httptest.NewRequest(http.MethodGet, "/v1/organization/1", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer ci-token")
+
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, handler(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ci", rec.Body.String())
+}
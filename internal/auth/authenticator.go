@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"crypto/subtle"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// Authenticator validates bearer tokens and returns the caller they identify
+type Authenticator struct {
+	tokens   map[string]string
+	keys     jwk.Set
+	issuer   string
+	audience string
+}
+
+// New returns an authenticator for the API tokens and JWKS file in the config
+func New(c Config) (*Authenticator, error) {
+	if len(c.APITokens) == 0 && c.JWKSFile == "" {
+		return nil, ErrNoCredentialsConfigured
+	}
+
+	a := &Authenticator{
+		tokens:   c.APITokens,
+		issuer:   c.Issuer,
+		audience: c.Audience,
+	}
+
+	if c.JWKSFile != "" {
+		keys, err := jwk.ReadFile(c.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+
+		a.keys = keys
+	}
+
+	return a, nil
+}
+
+// Authenticate returns the caller identified by the bearer token, API tokens are checked before JWTs
+func (a *Authenticator) Authenticate(token string) (*Caller, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	for name, apiToken := range a.tokens {
+		if apiToken != "" && subtle.ConstantTimeCompare([]byte(apiToken), []byte(token)) == 1 {
+			return &Caller{
+				Subject: name,
+				Method:  MethodAPIToken,
+				Token:   token,
+			}, nil
+		}
+	}
+
+	if a.keys == nil {
+		return nil, ErrInvalidToken
+	}
+
+	opts := []jwt.ParseOption{
+		jwt.WithKeySet(a.keys, jws.WithInferAlgorithmFromKey(true)),
+		jwt.WithValidate(true),
+	}
+
+	if a.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.issuer))
+	}
+
+	if a.audience != "" {
+		opts = append(opts, jwt.WithAudience(a.audience))
+	}
+
+	parsed, err := jwt.ParseString(token, opts...)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if parsed.Subject() == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &Caller{
+		Subject: parsed.Subject(),
+		Method:  MethodJWT,
+		Token:   token,
+		Claims:  parsed.PrivateClaims(),
+	}, nil
+}
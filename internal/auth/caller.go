@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+)
+
+// Method is the way the caller was authenticated
+type Method string
+
+var (
+	// MethodAPIToken is used for callers authenticated with a configured API token
+	MethodAPIToken Method = "api_token"
+	// MethodJWT is used for callers authenticated with a JWT
+	MethodJWT Method = "jwt"
+)
+
+// Caller is the authenticated identity making the request
+type Caller struct {
+	// Subject is the name of the API token or the subject of the JWT
+	Subject string
+	// Method is the way the caller was authenticated
+	Method Method
+	// Token is the bearer token provided by the caller
+	Token string
+	// Claims contains the private claims of the JWT, it is empty for API tokens
+	Claims map[string]any
+}
+
+// callerKey is the context key for the authenticated caller
+type callerKey struct{}
+
+// WithCaller returns a copy of the context containing the caller
+func WithCaller(ctx context.Context, c *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// CallerFromContext returns the authenticated caller from the context
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	c, ok := ctx.Value(callerKey{}).(*Caller)
+
+	return c, ok && c != nil
+}
@@ -0,0 +1,15 @@
+package auth
+
+// Config is the configuration for authenticating requests to the API
+type Config struct {
+	// Enabled requires every request to the versioned API to be authenticated
+	Enabled bool `json:"enabled" koanf:"enabled" default:"true"`
+	// APITokens are the bearer tokens accepted by the API, keyed by the name used as the identity of the caller
+	APITokens map[string]string `json:"api_tokens" koanf:"api_tokens"`
+	// JWKSFile is the location of the JSON Web Key Set used to validate JWT bearer tokens
+	JWKSFile string `json:"jwks_file" koanf:"jwks_file"`
+	// Issuer is the expected issuer of JWT bearer tokens, it is also used to publish the OpenID Connect discovery url
+	Issuer string `json:"issuer" koanf:"issuer"`
+	// Audience is the expected audience of JWT bearer tokens
+	Audience string `json:"audience" koanf:"audience"`
+}
@@ -0,0 +1,2 @@
+// Package auth authenticates requests to the openlane-cloud API using bearer API tokens or JWTs
+package auth
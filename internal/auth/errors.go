@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"errors"
+)
+
+var (
+	// ErrMissingToken is returned when a request does not include a bearer token
+	ErrMissingToken = errors.New("missing bearer token in authorization header")
+
+	// ErrInvalidToken is returned when the bearer token is not a known API token or a valid JWT
+	ErrInvalidToken = errors.New("invalid bearer token")
+
+	// ErrNoCredentialsConfigured is returned when authentication is enabled without any API tokens or JWKS file
+	ErrNoCredentialsConfigured = errors.New("authentication is enabled but no api tokens or jwks file are configured")
+)
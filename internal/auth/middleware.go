@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+)
+
+// bearerPrefix is the scheme prefix of the authorization header
+const bearerPrefix = "Bearer "
+
+// Middleware returns middleware that rejects requests without a valid bearer token and adds the caller to the
+// request context
+func Middleware(a *Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			caller, err := a.Authenticate(BearerToken(ctx.Request()))
+			if err != nil {
+				ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+
+				return ctx.JSON(http.StatusUnauthorized, rout.ErrorResponse(err))
+			}
+
+			log.Debug().Str("subject", caller.Subject).Str("method", string(caller.Method)).Msg("authenticated request")
+
+			req := ctx.Request()
+			ctx.SetRequest(req.WithContext(WithCaller(req.Context(), caller)))
+
+			return next(ctx)
+		}
+	}
+}
+
+// BearerToken returns the bearer token from the authorization header of the request
+func BearerToken(r *http.Request) string {
+	header := r.Header.Get(echo.HeaderAuthorization)
+
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len(bearerPrefix):])
+}
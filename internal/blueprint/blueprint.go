@@ -0,0 +1,244 @@
+package blueprint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/theopenlane/core/pkg/models"
+)
+
+const (
+	// DefaultName is the name of the built-in blueprint used when a request does not select one
+	DefaultName = "default"
+	// RelationshipsBucket is the bucket that contains the relationships in the standard hierarchy
+	RelationshipsBucket = "relationships"
+)
+
+// Blueprint describes an organization hierarchy that is created underneath a new root organization
+type Blueprint struct {
+	// Name is used to select the blueprint on a provisioning request
+	Name string `json:"name"`
+	// Version of the blueprint, the highest version is used when a request does not ask for a specific version
+	Version int `json:"version"`
+	// Description of the hierarchy created by the blueprint
+	Description string `json:"description,omitempty"`
+	// Children are the organizations created directly underneath the root organization
+	Children []Node `json:"children,omitempty"`
+}
+
+// Node is a single child organization in the blueprint
+type Node struct {
+	// Name of the organization, the full organization name is the path from the root joined by '.'
+	Name string `json:"name"`
+	// Description of the organization
+	Description string `json:"description,omitempty"`
+	// Tags added to the organization in addition to the names of the node and its ancestors
+	Tags []string `json:"tags,omitempty"`
+	// Settings for the organization
+	Settings *Settings `json:"settings,omitempty"`
+	// Children are the organizations created underneath this organization
+	Children []Node `json:"children,omitempty"`
+}
+
+// Settings are the organization settings applied to a node
+type Settings struct {
+	// Domains associated with the organization
+	Domains []string `json:"domains,omitempty"`
+	// AllowedEmailDomains restricts the email domains of users that can be added to the organization
+	AllowedEmailDomains []string `json:"allowedEmailDomains,omitempty"`
+	// BillingContact is the name of the billing contact for the organization
+	BillingContact string `json:"billingContact,omitempty"`
+	// BillingEmail is the email address of the billing contact for the organization
+	BillingEmail string `json:"billingEmail,omitempty"`
+	// BillingPhone is the phone number of the billing contact for the organization
+	BillingPhone string `json:"billingPhone,omitempty"`
+	// BillingAddress is the address billing information is sent to
+	BillingAddress *models.Address `json:"billingAddress,omitempty"`
+	// BillingNotificationsEnabled turns email notifications related to billing on or off
+	BillingNotificationsEnabled *bool `json:"billingNotificationsEnabled,omitempty"`
+	// TaxIdentifier is the government-issued tax or business id of the organization
+	TaxIdentifier string `json:"taxIdentifier,omitempty"`
+	// GeoLocation is the region of the organization: AMER, EMEA, or APAC
+	GeoLocation string `json:"geoLocation,omitempty"`
+	// Tags added to the organization settings
+	Tags []string `json:"tags,omitempty"`
+	// AvatarURL is the url of the avatar shown for the organization, it is set on the organization rather than its settings
+	AvatarURL string `json:"avatarURL,omitempty"`
+}
+
+// Inherit returns the settings with every field that is not set filled in from the parent settings
+func (s *Settings) Inherit(parent Settings) *Settings {
+	out := parent
+	if s == nil {
+		return &out
+	}
+
+	out = *s
+
+	if out.Domains == nil {
+		out.Domains = parent.Domains
+	}
+
+	if out.AllowedEmailDomains == nil {
+		out.AllowedEmailDomains = parent.AllowedEmailDomains
+	}
+
+	if out.BillingContact == "" {
+		out.BillingContact = parent.BillingContact
+	}
+
+	if out.BillingEmail == "" {
+		out.BillingEmail = parent.BillingEmail
+	}
+
+	if out.BillingPhone == "" {
+		out.BillingPhone = parent.BillingPhone
+	}
+
+	if out.BillingAddress == nil {
+		out.BillingAddress = parent.BillingAddress
+	}
+
+	if out.BillingNotificationsEnabled == nil {
+		out.BillingNotificationsEnabled = parent.BillingNotificationsEnabled
+	}
+
+	if out.TaxIdentifier == "" {
+		out.TaxIdentifier = parent.TaxIdentifier
+	}
+
+	if out.GeoLocation == "" {
+		out.GeoLocation = parent.GeoLocation
+	}
+
+	if out.Tags == nil {
+		out.Tags = parent.Tags
+	}
+
+	if out.AvatarURL == "" {
+		out.AvatarURL = parent.AvatarURL
+	}
+
+	return &out
+}
+
+// ID returns the name and version used to reference the blueprint
+func (b Blueprint) ID() string {
+	return fmt.Sprintf("%s@%d", b.Name, b.Version)
+}
+
+// Count returns the number of organizations created by the blueprint, not including the root organization
+func (b Blueprint) Count() int {
+	return countNodes(b.Children)
+}
+
+// InheritSettings returns a copy of the blueprint where every node up to depth levels below the root inherits the
+// settings; settings already set on a node are kept. The blueprint itself is not modified
+func (b Blueprint) InheritSettings(s Settings, depth int) Blueprint {
+	b.Children = inheritSettings(b.Children, s, depth)
+
+	return b
+}
+
+// Validate ensures the blueprint has a name and version and that every node has a valid, unique name
+func (b Blueprint) Validate() error {
+	if b.Name == "" {
+		return ErrMissingBlueprintName
+	}
+
+	if b.Version <= 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidBlueprintVersion, b.Name)
+	}
+
+	if err := validateNodes(b.Children, b.Name); err != nil {
+		return fmt.Errorf("%s: %w", b.ID(), err)
+	}
+
+	return nil
+}
+
+// Standard returns the default hierarchy shape with the provided environments at the first level, the buckets underneath
+// each environment, and the relationships underneath the bucket named relationships, ignoring case
+func Standard(environments, buckets, relationships []string) Blueprint {
+	bp := Blueprint{
+		Name:    DefaultName,
+		Version: 1,
+	}
+
+	for _, env := range environments {
+		envNode := Node{Name: env}
+
+		for _, bucket := range buckets {
+			bucketNode := Node{Name: bucket}
+
+			if strings.EqualFold(bucket, RelationshipsBucket) {
+				for _, relationship := range relationships {
+					bucketNode.Children = append(bucketNode.Children, Node{Name: relationship})
+				}
+			}
+
+			envNode.Children = append(envNode.Children, bucketNode)
+		}
+
+		bp.Children = append(bp.Children, envNode)
+	}
+
+	return bp
+}
+
+// inheritSettings returns a copy of the nodes where the nodes up to depth levels deep inherit the settings
+func inheritSettings(nodes []Node, s Settings, depth int) []Node {
+	if depth <= 0 || len(nodes) == 0 {
+		return nodes
+	}
+
+	out := make([]Node, len(nodes))
+
+	for i, n := range nodes {
+		n.Settings = n.Settings.Inherit(s)
+		n.Children = inheritSettings(n.Children, s, depth-1)
+
+		out[i] = n
+	}
+
+	return out
+}
+
+// countNodes returns the number of nodes in the tree
+func countNodes(nodes []Node) int {
+	count := len(nodes)
+
+	for _, n := range nodes {
+		count += countNodes(n.Children)
+	}
+
+	return count
+}
+
+// validateNodes checks the nodes and all of their descendants
+func validateNodes(nodes []Node, path string) error {
+	seen := make(map[string]struct{}, len(nodes))
+
+	for _, n := range nodes {
+		if n.Name == "" {
+			return fmt.Errorf("%w: %s", ErrMissingNodeName, path)
+		}
+
+		if strings.Contains(n.Name, ".") {
+			return fmt.Errorf("%w: %s", ErrInvalidNodeName, n.Name)
+		}
+
+		key := strings.ToLower(n.Name)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: %s.%s", ErrDuplicateNode, path, n.Name)
+		}
+
+		seen[key] = struct{}{}
+
+		if err := validateNodes(n.Children, path+"."+n.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
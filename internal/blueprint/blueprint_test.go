@@ -0,0 +1,173 @@
+package blueprint_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mcuadros/go-defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+func TestEmbeddedDefault(t *testing.T) {
+	bp, err := blueprint.Embedded().Get(blueprint.DefaultName)
+	require.NoError(t, err)
+
+	// the built-in blueprint matches the default request so existing requests provision the same hierarchy
+	req := models.OrganizationRequest{}
+	defaults.SetDefaults(&req)
+
+	assert.Equal(t, blueprint.Standard(req.Environments, req.Buckets, req.Relationships).Children, bp.Children)
+	assert.Equal(t, 22, bp.Count())
+}
+
+func TestStandard(t *testing.T) {
+	bp := blueprint.Standard([]string{"production"}, []string{"sales", "Relationships"}, []string{"vendors"})
+
+	require.Len(t, bp.Children, 1)
+	require.Len(t, bp.Children[0].Children, 2)
+	assert.Empty(t, bp.Children[0].Children[0].Children)
+
+	// the relationships bucket is matched ignoring case
+	assert.Equal(t, []blueprint.Node{{Name: "vendors"}}, bp.Children[0].Children[1].Children)
+}
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "retail.yaml"), []byte(`
+name: retail
+version: 1
+children:
+  - name: stores
+    tags: [physical]
+    children:
+      - name: east
+      - name: west
+`), 0600))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "retail-v2.json"), []byte(`{
+  "name": "retail",
+  "version": 2,
+  "children": [{"name": "online", "description": "web store"}]
+}`), 0600))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0600))
+
+	r, err := blueprint.NewRegistry(dir)
+	require.NoError(t, err)
+
+	latest, err := r.Get("retail")
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest.Version)
+	assert.Equal(t, "web store", latest.Children[0].Description)
+
+	v1, err := r.Get("retail@1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v1.Count())
+	assert.Equal(t, []string{"physical"}, v1.Children[0].Tags)
+
+	_, err = r.Get("retail@3")
+	require.ErrorIs(t, err, blueprint.ErrBlueprintNotFound)
+
+	_, err = r.Get("missing")
+	require.ErrorIs(t, err, blueprint.ErrBlueprintNotFound)
+
+	ids := []string{}
+	for _, bp := range r.List() {
+		ids = append(ids, bp.ID())
+	}
+
+	assert.Equal(t, []string{"default@1", "retail@1", "retail@2"}, ids)
+}
+
+func TestRegistryInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contents string
+		err      error
+	}{
+		{
+			name:     "missing name",
+			contents: "version: 1",
+			err:      blueprint.ErrMissingBlueprintName,
+		},
+		{
+			name:     "missing version",
+			contents: "name: meow",
+			err:      blueprint.ErrInvalidBlueprintVersion,
+		},
+		{
+			name:     "duplicate version",
+			contents: "name: default\nversion: 1",
+			err:      blueprint.ErrDuplicateBlueprint,
+		},
+		{
+			name:     "missing node name",
+			contents: "name: meow\nversion: 1\nchildren:\n  - description: meow",
+			err:      blueprint.ErrMissingNodeName,
+		},
+		{
+			name:     "invalid node name",
+			contents: "name: meow\nversion: 1\nchildren:\n  - name: a.b",
+			err:      blueprint.ErrInvalidNodeName,
+		},
+		{
+			name:     "duplicate siblings",
+			contents: "name: meow\nversion: 1\nchildren:\n  - name: a\n    children:\n      - name: b\n      - name: B",
+			err:      blueprint.ErrDuplicateNode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "blueprint.yaml"), []byte(tc.contents), 0600))
+
+			_, err := blueprint.NewRegistry(dir)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestInheritSettings(t *testing.T) {
+	bp := blueprint.Blueprint{
+		Name:    "retail",
+		Version: 1,
+		Children: []blueprint.Node{
+			{
+				Name:     "stores",
+				Settings: &blueprint.Settings{BillingEmail: "stores@meow.com"},
+				Children: []blueprint.Node{
+					{
+						Name:     "east",
+						Children: []blueprint.Node{{Name: "downtown"}},
+					},
+				},
+			},
+			{Name: "online"},
+		},
+	}
+
+	inherited := bp.InheritSettings(blueprint.Settings{BillingEmail: "billing@meow.com", GeoLocation: "AMER"}, 2)
+
+	// settings on the node are kept and the rest are inherited
+	stores := inherited.Children[0]
+	assert.Equal(t, "stores@meow.com", stores.Settings.BillingEmail)
+	assert.Equal(t, "AMER", stores.Settings.GeoLocation)
+
+	assert.Equal(t, "billing@meow.com", stores.Children[0].Settings.BillingEmail)
+	assert.Equal(t, "billing@meow.com", inherited.Children[1].Settings.BillingEmail)
+
+	// nodes deeper than the depth do not inherit settings
+	assert.Nil(t, stores.Children[0].Children[0].Settings)
+
+	// the original blueprint is not modified
+	assert.Empty(t, bp.Children[0].Settings.GeoLocation)
+	assert.Nil(t, bp.Children[0].Children[0].Settings)
+	assert.Nil(t, bp.Children[1].Settings)
+}
@@ -0,0 +1,2 @@
+// Package blueprint contains the named, versioned hierarchy blueprints used to provision organization trees
+package blueprint
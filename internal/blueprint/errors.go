@@ -0,0 +1,28 @@
+package blueprint
+
+import (
+	"errors"
+)
+
+var (
+	// ErrBlueprintNotFound is returned when the requested blueprint does not exist
+	ErrBlueprintNotFound = errors.New("blueprint not found")
+
+	// ErrMissingBlueprintName is returned when a blueprint does not have a name
+	ErrMissingBlueprintName = errors.New("blueprint name is required")
+
+	// ErrInvalidBlueprintVersion is returned when a blueprint version is not a positive number
+	ErrInvalidBlueprintVersion = errors.New("blueprint version must be greater than zero")
+
+	// ErrDuplicateBlueprint is returned when more than one blueprint is loaded with the same name and version
+	ErrDuplicateBlueprint = errors.New("blueprint is defined more than once")
+
+	// ErrMissingNodeName is returned when a node in the blueprint does not have a name
+	ErrMissingNodeName = errors.New("blueprint node name is required")
+
+	// ErrInvalidNodeName is returned when a node name contains the separator used to build organization names
+	ErrInvalidNodeName = errors.New("blueprint node name cannot contain '.'")
+
+	// ErrDuplicateNode is returned when two sibling nodes have the same name
+	ErrDuplicateNode = errors.New("blueprint node name must be unique among its siblings")
+)
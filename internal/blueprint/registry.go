@@ -0,0 +1,173 @@
+package blueprint
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/invopop/yaml"
+)
+
+//go:embed blueprints/*
+var embedded embed.FS
+
+var (
+	embeddedOnce     sync.Once
+	embeddedRegistry *Registry
+)
+
+// Registry contains the blueprints available for provisioning
+type Registry struct {
+	blueprints map[string][]Blueprint
+}
+
+// NewRegistry returns a registry containing the built-in blueprints and any blueprints found in dir; blueprints in dir
+// can add new versions of the built-in blueprints but cannot redefine an existing version
+func NewRegistry(dir string) (*Registry, error) {
+	r := &Registry{
+		blueprints: map[string][]Blueprint{},
+	}
+
+	if err := r.load(embedded, "blueprints"); err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		if err := r.load(os.DirFS(dir), "."); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Embedded returns a registry containing only the built-in blueprints
+func Embedded() *Registry {
+	embeddedOnce.Do(func() {
+		r, err := NewRegistry("")
+		if err != nil {
+			// the built-in blueprints are validated by tests, so this can only happen during development
+			panic(err)
+		}
+
+		embeddedRegistry = r
+	})
+
+	return embeddedRegistry
+}
+
+// Get returns the blueprint referenced by name; a specific version can be selected with name@version, otherwise the
+// highest version is returned
+func (r *Registry) Get(ref string) (Blueprint, error) {
+	name, version, _ := strings.Cut(ref, "@")
+
+	versions, ok := r.blueprints[name]
+	if !ok {
+		return Blueprint{}, fmt.Errorf("%w: %s", ErrBlueprintNotFound, ref)
+	}
+
+	if version == "" {
+		return versions[len(versions)-1], nil
+	}
+
+	v, err := strconv.Atoi(version)
+	if err != nil {
+		return Blueprint{}, fmt.Errorf("%w: %s", ErrBlueprintNotFound, ref)
+	}
+
+	for _, bp := range versions {
+		if bp.Version == v {
+			return bp, nil
+		}
+	}
+
+	return Blueprint{}, fmt.Errorf("%w: %s", ErrBlueprintNotFound, ref)
+}
+
+// List returns every blueprint in the registry sorted by name and version
+func (r *Registry) List() []Blueprint {
+	out := []Blueprint{}
+
+	for _, versions := range r.blueprints {
+		out = append(out, versions...)
+	}
+
+	slices.SortFunc(out, compare)
+
+	return out
+}
+
+// Add validates the blueprint and adds it to the registry
+func (r *Registry) Add(bp Blueprint) error {
+	if err := bp.Validate(); err != nil {
+		return err
+	}
+
+	versions := r.blueprints[bp.Name]
+
+	for _, existing := range versions {
+		if existing.Version == bp.Version {
+			return fmt.Errorf("%w: %s", ErrDuplicateBlueprint, bp.ID())
+		}
+	}
+
+	versions = append(versions, bp)
+	slices.SortFunc(versions, compare)
+
+	r.blueprints[bp.Name] = versions
+
+	return nil
+}
+
+// load adds every yaml or json blueprint in the directory of the file system
+func (r *Registry) load(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		var bp Blueprint
+
+		// yaml is converted to json before unmarshalling, so json files are also supported
+		if err := yaml.Unmarshal(data, &bp); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		if err := r.Add(bp); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// compare orders blueprints by name and then version
+func compare(a, b Blueprint) int {
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+
+	return a.Version - b.Version
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/theopenlane/httpsling"
+)
+
+const (
+	// DefaultAPIKeyHeader is the header the API key is sent in when no other header is configured
+	DefaultAPIKeyHeader = "X-API-Key"
+	// OpenlaneTokenHeader is the header the openlane credential of the caller is sent in
+	OpenlaneTokenHeader = "X-Openlane-Token"
+	// DefaultUserAgent is the User-Agent sent when no other user agent is configured
+	DefaultUserAgent = "openlane-cloud-client"
+	// tokenExpiryLeeway is how long before it expires a cached token is refreshed, so it does not expire in flight
+	tokenExpiryLeeway = 30 * time.Second
+)
+
+// TokenSource returns the bearer token sent with a request; it is called before every attempt of every request so it
+// can return a new token when the previous one expires
+type TokenSource interface {
+	Token(context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts a function to the TokenSource interface
+type TokenSourceFunc func(context.Context) (string, error)
+
+// Token implements TokenSource
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// RefreshFunc returns a new token and the time it expires, a zero expiry means the token does not expire
+type RefreshFunc func(context.Context) (token string, expiry time.Time, err error)
+
+// RefreshingTokenSource is a TokenSource that caches the token returned by a RefreshFunc until shortly before it
+// expires; the token is also refreshed, and the request sent once more, when the server rejects it
+type RefreshingTokenSource struct {
+	mu      sync.Mutex
+	refresh RefreshFunc
+	token   string
+	expiry  time.Time
+}
+
+// NewRefreshingTokenSource returns a RefreshingTokenSource that gets its tokens from refresh
+func NewRefreshingTokenSource(refresh RefreshFunc) *RefreshingTokenSource {
+	return &RefreshingTokenSource{refresh: refresh}
+}
+
+// Token returns the cached token, or a new token when there is none or it is about to expire
+func (s *RefreshingTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Until(s.expiry) > tokenExpiryLeeway) {
+		return s.token, nil
+	}
+
+	token, expiry, err := s.refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token, s.expiry = token, expiry
+
+	return token, nil
+}
+
+// Invalidate discards the cached token so the next call to Token refreshes it
+func (s *RefreshingTokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = ""
+}
+
+// invalidator is implemented by token sources that cache their token, so a token rejected by the server can be replaced
+type invalidator interface {
+	Invalidate()
+}
+
+// tokenMiddleware returns the httpsling middleware that sets the bearer token from the token source on every attempt;
+// when the server rejects a cached token it is invalidated and the request is sent once more with a new token
+func tokenMiddleware(tokens TokenSource) httpsling.Middleware {
+	return func(next httpsling.Doer) httpsling.Doer {
+		return httpsling.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := sendWithToken(next, tokens, req)
+
+			inv, ok := tokens.(invalidator)
+			if err != nil || !ok || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+
+			retry, rerr := rewind(req)
+			if rerr != nil {
+				return resp, err
+			}
+
+			drain(resp.Body)
+			inv.Invalidate()
+
+			return sendWithToken(next, tokens, retry)
+		})
+	}
+}
+
+// sendWithToken sends a copy of the request with the bearer token from the token source
+func sendWithToken(next httpsling.Doer, tokens TokenSource, req *http.Request) (*http.Response, error) {
+	token, err := tokens.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenUnavailable, err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(httpsling.HeaderAuthorization, "Bearer "+token)
+
+	return next.Do(req)
+}
+
+// enabled returns true when any of the certificate files are set
+func (t TLSConfig) enabled() bool {
+	return t.CAFile != "" || t.CertFile != "" || t.KeyFile != ""
+}
+
+// Load reads the certificate files and returns the TLS configuration used to connect to the server
+func (t TLSConfig) Load() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if t.CAFile != "" {
+		bundle, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.RootCAs, err = certPool(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", t.CAFile, err)
+		}
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// certPool returns the system certificate pool with the certificates of the PEM bundle added
+func certPool(bundle []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, ErrInvalidCABundle
+	}
+
+	return pool, nil
+}
+
+// tlsClient returns an http client using the default transport settings with the TLS configuration
+func tlsClient(cfg *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.TLSClientConfig = cfg
+
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/utils/rout"
+)
+
+func TestCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("options", func(t *testing.T) {
+		s := &flakyServer{}
+
+		_, err := newFlakyClient(t, s,
+			WithBearerToken("api-token"),
+			WithAPIKey("", "api-key"),
+			WithOpenlaneToken("openlane-token"),
+			WithUserAgent("pipeline/1.0"),
+			WithHeader("X-Tenant", "mitb"),
+		).OrganizationGet(ctx, "org")
+		require.NoError(t, err)
+
+		header := s.requests[0].Header
+		assert.Equal(t, "Bearer api-token", header.Get("Authorization"))
+		assert.Equal(t, "api-key", header.Get(DefaultAPIKeyHeader))
+		assert.Equal(t, "openlane-token", header.Get(OpenlaneTokenHeader))
+		assert.Equal(t, "pipeline/1.0", header.Get("User-Agent"))
+		assert.Equal(t, "mitb", header.Get("X-Tenant"))
+	})
+
+	t.Run("config", func(t *testing.T) {
+		s := &flakyServer{}
+		srv := httptest.NewServer(s)
+		t.Cleanup(srv.Close)
+
+		config := NewDefaultConfig()
+		config.BaseURL, _ = url.Parse(srv.URL)
+		config.Token = "api-token"
+		config.APIKey = "api-key"
+		config.APIKeyHeader = "X-Gateway-Key"
+		config.Headers = map[string]string{"X-Tenant": "mitb"}
+
+		c, err := New(config)
+		require.NoError(t, err)
+
+		_, err = c.OrganizationGet(ctx, "org")
+		require.NoError(t, err)
+
+		header := s.requests[0].Header
+		assert.Equal(t, "Bearer api-token", header.Get("Authorization"))
+		assert.Equal(t, "api-key", header.Get("X-Gateway-Key"))
+		assert.Equal(t, DefaultUserAgent, header.Get("User-Agent"))
+		assert.Equal(t, "mitb", header.Get("X-Tenant"))
+	})
+}
+
+func TestRefreshingTokenSource(t *testing.T) {
+	ctx := context.Background()
+
+	// the server only accepts the latest token
+	var valid atomic.Value
+
+	valid.Store("token-2")
+
+	s := &flakyServer{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+valid.Load().(string) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(rout.ErrorResponse("invalid token"))
+
+			return
+		}
+
+		s.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	refreshes := 0
+	expiry := time.Time{}
+
+	tokens := NewRefreshingTokenSource(func(context.Context) (string, time.Time, error) {
+		refreshes++
+
+		return "token-" + strconv.Itoa(refreshes), expiry, nil
+	})
+
+	baseURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	c, err := New(NewDefaultConfig(), WithBaseURL(baseURL), WithTokenSource(tokens))
+	require.NoError(t, err)
+
+	// the first token is rejected, so it is refreshed and the request is sent again
+	_, err = c.OrganizationGet(ctx, "org")
+	require.NoError(t, err)
+	assert.Equal(t, 2, refreshes)
+
+	// the cached token is used until it is about to expire
+	_, err = c.OrganizationGet(ctx, "org")
+	require.NoError(t, err)
+	assert.Equal(t, 2, refreshes)
+
+	tokens.Invalidate()
+
+	expiry = time.Now().Add(time.Second)
+	valid.Store("token-4")
+
+	_, err = tokens.Token(ctx)
+	require.NoError(t, err)
+
+	// the token expires within the leeway so it is refreshed before the request
+	_, err = c.OrganizationGet(ctx, "org")
+	require.NoError(t, err)
+	assert.Equal(t, 4, refreshes)
+
+	// a token that cannot be refreshed is not retried
+	valid.Store("unknown")
+
+	_, err = c.OrganizationGet(ctx, "org")
+	require.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, 6, refreshes)
+
+	c, err = New(NewDefaultConfig(), WithBaseURL(baseURL), WithTokenSource(TokenSourceFunc(func(context.Context) (string, error) {
+		return "", assert.AnError
+	})))
+	require.NoError(t, err)
+
+	_, err = c.OrganizationGet(ctx, "org")
+	require.ErrorIs(t, err, ErrTokenUnavailable)
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestTLS(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewTLSServer(&flakyServer{})
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	// the server certificate is not signed by a trusted certificate authority, which is not retried
+	c, err := New(NewDefaultConfig(), WithBaseURL(baseURL))
+	require.NoError(t, err)
+
+	var certErr *tls.CertificateVerificationError
+
+	_, err = c.OrganizationGet(ctx, "org")
+	require.ErrorAs(t, err, &certErr)
+
+	c, err = New(NewDefaultConfig(), WithBaseURL(baseURL), WithCABundle(bundle))
+	require.NoError(t, err)
+
+	_, err = c.OrganizationGet(ctx, "org")
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, bundle, 0600))
+
+	config := NewDefaultConfig()
+	config.BaseURL = baseURL
+	config.TLS.CAFile = caFile
+
+	c, err = New(config)
+	require.NoError(t, err)
+
+	_, err = c.OrganizationGet(ctx, "org")
+	require.NoError(t, err)
+
+	_, err = New(NewDefaultConfig(), WithCABundle([]byte("not a certificate")))
+	require.ErrorIs(t, err, ErrInvalidCABundle)
+
+	config.TLS = TLSConfig{CertFile: filepath.Join(t.TempDir(), "missing.pem")}
+
+	_, err = New(config)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -2,16 +2,64 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/theopenlane/httpsling"
-
-	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+	"github.com/theopenlane/utils/rout"
 )
 
 // Client is the interface that wraps the openlane cloud API REST client methods
 type Client interface {
 	// OrganizationCreate creates an organizational hierarchy for a organization
-	OrganizationCreate(context.Context, *models.OrganizationRequest) (*models.OrganizationReply, error)
+	OrganizationCreate(context.Context, *OrganizationRequest) (*OrganizationReply, error)
+	// OrganizationPlan returns the organizational hierarchy that would be created for a organization without creating it
+	OrganizationPlan(context.Context, *OrganizationRequest) (*OrganizationPlanReply, error)
+	// OrganizationBulkCreate submits an organizational hierarchy for every organization to be created in the background and returns the job
+	OrganizationBulkCreate(context.Context, []OrganizationRequest) (*ProvisioningJobReply, error)
+	// OrganizationGet returns an existing organizational hierarchy
+	OrganizationGet(context.Context, string) (*OrganizationReply, error)
+	// OrganizationUpdate adds environments, buckets, or relationships to an existing organizational hierarchy
+	OrganizationUpdate(context.Context, string, *OrganizationUpdateRequest) (*OrganizationUpdateReply, error)
+	// OrganizationReconcile compares an existing organizational hierarchy with the expected hierarchy and optionally repairs it
+	OrganizationReconcile(context.Context, string, *OrganizationReconcileRequest) (*OrganizationReconcileReply, error)
+	// OrganizationDelete deletes an organizational hierarchy, or returns the organizations that would be deleted for a dry run
+	OrganizationDelete(context.Context, string, bool) (*OrganizationDeleteReply, error)
+	// OrganizationCreateAsync submits an organizational hierarchy to be created in the background and returns the job
+	OrganizationCreateAsync(context.Context, *OrganizationRequest) (*ProvisioningJobReply, error)
+	// OrganizationJob returns the status of an asynchronous provisioning job
+	OrganizationJob(context.Context, string) (*ProvisioningJobReply, error)
+	// OrganizationList returns a page of the root organizations visible to the caller
+	OrganizationList(context.Context, ListOptions) (*OrganizationListReply, error)
+	// Audit returns the entries in the audit log of provisioning actions matching the filter
+	Audit(context.Context, AuditOptions) (*AuditReply, error)
+	// Live returns an error when the server is not running
+	Live(context.Context) error
+	// Ready returns the status of every readiness check of the server
+	Ready(context.Context) (map[string]string, error)
+}
+
+// ListOptions selects the page of organizations returned by OrganizationList
+type ListOptions struct {
+	// Limit is the maximum number of organizations on the page, the server default is used when it is not set
+	Limit int
+	// Cursor is the NextCursor of the previous page, the first page is returned when it is not set
+	Cursor string
+}
+
+// AuditOptions selects the entries returned by Audit
+type AuditOptions struct {
+	// Since excludes entries before the time when set
+	Since time.Time
+	// Until excludes entries after the time when set
+	Until time.Time
+	// OrganizationID only includes entries for the hierarchy with the root organization or that created or deleted the
+	// organization when set
+	OrganizationID string
+	// Limit is the maximum number of entries returned, the most recent entries are kept
+	Limit int
 }
 
 // NewWithDefaults creates a new API v1 client with default configuration
@@ -35,6 +83,11 @@ func New(config Config, opts ...Option) (_ Client, err error) {
 		}
 	}
 
+	// the configuration is applied before the options so they can override it
+	if err := c.configure(); err != nil {
+		return nil, err
+	}
+
 	// apply the options to the client
 	for _, opt := range opts {
 		if opt == nil {
@@ -46,39 +99,223 @@ func New(config Config, opts ...Option) (_ Client, err error) {
 		}
 	}
 
+	// the deadline covers every attempt so it wraps the retries, and every attempt gets a token from the token source
+	middleware := []httpsling.Middleware{timeoutMiddleware(c.Config.Timeout), c.Config.Retry.middleware()}
+
+	if c.tokens != nil {
+		middleware = append(middleware, tokenMiddleware(c.tokens))
+	}
+
+	if err := c.Requester.Apply(httpsling.Use(middleware...)); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
+// configure applies the base url, credentials, headers, and TLS configuration of the client configuration to the requester
+func (c *APIv1) configure() error {
+	opts := []httpsling.Option{}
+
+	if c.Config.BaseURL != nil {
+		opts = append(opts, httpsling.URL(c.Config.BaseURL.String()))
+	}
+
+	if c.Config.UserAgent != "" {
+		opts = append(opts, httpsling.Header(httpsling.HeaderUserAgent, c.Config.UserAgent))
+	}
+
+	if c.Config.Token != "" {
+		opts = append(opts, httpsling.BearerAuth(c.Config.Token))
+	}
+
+	if c.Config.APIKey != "" {
+		header := c.Config.APIKeyHeader
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+
+		opts = append(opts, httpsling.Header(header, c.Config.APIKey))
+	}
+
+	if c.Config.OpenlaneToken != "" {
+		opts = append(opts, httpsling.Header(OpenlaneTokenHeader, c.Config.OpenlaneToken))
+	}
+
+	for key, value := range c.Config.Headers {
+		opts = append(opts, httpsling.Header(key, value))
+	}
+
+	if c.Config.TLS.enabled() {
+		cfg, err := c.Config.TLS.Load()
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, httpsling.WithDoer(tlsClient(cfg)))
+	}
+
+	return c.Requester.Apply(opts...)
+}
+
 // APIv1 implements the Client interface and provides methods to interact with the openlane cloud API
 type APIv1 struct {
 	// Config is the configuration for the APIv1 client
 	Config Config
 	// HTTPSlingClient is the HTTP client for the APIv1 client
 	Requester *httpsling.Requester
+	// tokens is the source of the bearer token of every request, if set
+	tokens TokenSource
 }
 
 // Ensure the APIv1 implements the Client interface
 var _ Client = &APIv1{}
 
-// OrganizationCreate creates an organizational hierarchy for a new organization based on the name, environment(s), bucket(s), and
-// relationship(s) provided in the request
-func (c *APIv1) OrganizationCreate(ctx context.Context, in *models.OrganizationRequest) (out *models.OrganizationReply, err error) {
-	resp, err := c.Requester.ReceiveWithContext(ctx, &out,
-		httpsling.Post(v1Path("organization")),
-		httpsling.Body(in))
+// OrganizationCreate creates an organizational hierarchy for a new organization based on the name and template, or the
+// environment(s), bucket(s), and relationship(s) provided in the request
+func (c *APIv1) OrganizationCreate(ctx context.Context, in *OrganizationRequest) (*OrganizationReply, error) {
+	return sendOrganization[OrganizationReply](ctx, c, organizationParams{}, in)
+}
+
+// OrganizationPlan runs the organization request as a dry run and returns the organizations that would be created, their
+// names, tags, and parents, along with any existing organizations that use the same names; nothing is created
+func (c *APIv1) OrganizationPlan(ctx context.Context, in *OrganizationRequest) (*OrganizationPlanReply, error) {
+	plan := *in
+	plan.DryRun = true
+
+	return sendOrganization[OrganizationPlanReply](ctx, c, organizationParams{}, &plan)
+}
+
+// OrganizationBulkCreate submits an organizational hierarchy for every organization in a single request and returns the
+// job, which can be polled with OrganizationJob or WaitForJob; organizations that fail do not affect the others, so the
+// result of every organization must be checked once the job has finished
+func (c *APIv1) OrganizationBulkCreate(ctx context.Context, in []OrganizationRequest) (*ProvisioningJobReply, error) {
+	body := BulkOrganizationRequest(in)
+
+	return sendBulkOrganization(ctx, c, bulkOrganizationParams{}, &body)
+}
+
+// OrganizationCreateAsync submits the organization request to be provisioned in the background and returns the job,
+// which can be polled with OrganizationJob until it succeeds or fails
+func (c *APIv1) OrganizationCreateAsync(ctx context.Context, in *OrganizationRequest) (*ProvisioningJobReply, error) {
+	return sendOrganization[ProvisioningJobReply](ctx, c, organizationParams{Async: true}, in)
+}
+
+// OrganizationJob returns the progress of an asynchronous provisioning job by id, along with the organization once the
+// job has succeeded
+func (c *APIv1) OrganizationJob(ctx context.Context, id string) (*ProvisioningJobReply, error) {
+	return sendOrganizationJob(ctx, c, organizationJobParams{ID: id})
+}
+
+// OrganizationList returns a page of the root organizations visible to the caller ordered by id; use the NextCursor of
+// the reply to request the next page, or OrganizationPages to iterate over every organization
+func (c *APIv1) OrganizationList(ctx context.Context, opts ListOptions) (*OrganizationListReply, error) {
+	return sendListOrganizations(ctx, c, listOrganizationsParams{Limit: max(opts.Limit, 0), Cursor: opts.Cursor})
+}
+
+// OrganizationGet returns the organizational hierarchy for an existing organization by the id of the root organization
+func (c *APIv1) OrganizationGet(ctx context.Context, id string) (*OrganizationReply, error) {
+	return sendGetOrganization(ctx, c, getOrganizationParams{ID: id})
+}
+
+// OrganizationUpdate adds the environment(s), bucket(s), and relationship(s) provided in the request to the existing
+// organizational hierarchy by the id of the root organization; only organizations that do not already exist are created
+func (c *APIv1) OrganizationUpdate(ctx context.Context, id string, in *OrganizationUpdateRequest) (*OrganizationUpdateReply, error) {
+	return sendUpdateOrganization(ctx, c, updateOrganizationParams{ID: id}, in)
+}
+
+// OrganizationReconcile compares the organizational hierarchy of the root organization with the hierarchy described by
+// the template, or environment(s), bucket(s), and relationship(s), in the request and returns the differences; the
+// differences are repaired when requested
+func (c *APIv1) OrganizationReconcile(ctx context.Context, id string, in *OrganizationReconcileRequest) (*OrganizationReconcileReply, error) {
+	return sendReconcileOrganization(ctx, c, reconcileOrganizationParams{ID: id}, in)
+}
+
+// OrganizationDelete deletes the organization and every organization underneath it by the id of the root organization;
+// when dryRun is set nothing is deleted and the organizations that would be deleted are returned
+func (c *APIv1) OrganizationDelete(ctx context.Context, id string, dryRun bool) (*OrganizationDeleteReply, error) {
+	return sendDeleteOrganization(ctx, c, deleteOrganizationParams{ID: id, DryRun: dryRun})
+}
+
+// Audit returns the entries in the audit log of provisioning actions matching the filter, oldest first
+func (c *APIv1) Audit(ctx context.Context, opts AuditOptions) (*AuditReply, error) {
+	return sendAudit(ctx, c, auditParams{
+		Since:        opts.Since,
+		Until:        opts.Until,
+		Organization: opts.OrganizationID,
+		Limit:        max(opts.Limit, 0),
+	})
+}
+
+// Live returns nil when the server is running
+func (c *APIv1) Live(ctx context.Context) error {
+	resp, err := c.Requester.ReceiveWithContext(ctx, nil,
+		httpsling.Get("/livez"))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if !httpsling.IsSuccess(resp) {
+		return newRequestError(resp, rout.Reply{})
+	}
+
+	return nil
+}
+
+// Ready returns the status of every readiness check keyed by name; when a check fails the statuses are returned along
+// with a RequestError matching ErrUnavailable, the status of a failed check is its error
+func (c *APIv1) Ready(ctx context.Context) (map[string]string, error) {
+	var body map[string]any
+
+	resp, err := c.Requester.ReceiveWithContext(ctx, &body,
+		httpsling.Get("/ready"))
 	if err != nil {
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
+	// the checks are nested under status when the server is ready and returned directly when it is not
+	checks := body
+	if nested, ok := body["status"].(map[string]any); ok && httpsling.IsSuccess(resp) {
+		checks = nested
+	}
+
+	status := make(map[string]string, len(checks))
+	for name, v := range checks {
+		status[name] = fmt.Sprint(v)
+	}
+
 	if !httpsling.IsSuccess(resp) {
-		return nil, newRequestError(resp.StatusCode, out.Error)
+		return status, newRequestError(resp, rout.Reply{})
 	}
 
-	return out, nil
+	return status, nil
 }
 
-func v1Path(path string) string {
-	return "/v1/" + path
+// send sends the request built by the generated client functions and decodes the JSON reply into out; a reply that is
+// not successful is returned as a RequestError, whether or not its body can be decoded
+func (c *APIv1) send(ctx context.Context, out any, opts ...httpsling.Option) error {
+	resp, err := c.Requester.SendWithContext(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if !httpsling.IsSuccess(resp) {
+		reply := rout.Reply{}
+		_ = json.Unmarshal(body, &reply)
+
+		return newRequestError(resp, reply)
+	}
+
+	return json.Unmarshal(body, out)
 }
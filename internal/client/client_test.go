@@ -0,0 +1,368 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+
+	"github.com/theopenlane/openlane-cloud/internal/client"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/audit"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/handlers"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/route"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/server"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/validation"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+var (
+	// errNotFound is returned by the fake openlane client for missing organizations
+	errNotFound = errors.New("organization not found")
+	// errUpstream is returned by the fake openlane client for organizations that fail to be created
+	errUpstream = errors.New("openlane is having a bad day")
+)
+
+// fakeOpenlane is an in-memory implementation of the openlane graph client methods used to manage organizations
+type fakeOpenlane struct {
+	openlaneclient.OpenlaneGraphClient
+
+	mu     sync.Mutex
+	nextID int
+	// orgs contains the organizations that currently exist, keyed by id
+	orgs map[string]openlaneclient.CreateOrganization_CreateOrganization_Organization
+	// failCreate contains organization names that will fail to be created
+	failCreate map[string]error
+}
+
+// CreateOrganization creates an organization in memory
+func (f *fakeOpenlane) CreateOrganization(_ context.Context, input openlaneclient.CreateOrganizationInput, _ *graphql.Upload, _ ...clientv2.RequestInterceptor) (*openlaneclient.CreateOrganization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err, ok := f.failCreate[input.Name]; ok {
+		return nil, err
+	}
+
+	f.nextID++
+
+	now := time.Now()
+
+	org := openlaneclient.CreateOrganization_CreateOrganization_Organization{
+		ID:          fmt.Sprintf("org-%03d", f.nextID),
+		Name:        input.Name,
+		Description: input.Description,
+		Tags:        input.Tags,
+		CreatedAt:   &now,
+	}
+
+	if input.DisplayName != nil {
+		org.DisplayName = *input.DisplayName
+	}
+
+	if input.ParentID != nil {
+		org.Parent = &openlaneclient.CreateOrganization_CreateOrganization_Organization_Parent{ID: *input.ParentID}
+	}
+
+	f.orgs[org.ID] = org
+
+	return &openlaneclient.CreateOrganization{
+		CreateOrganization: openlaneclient.CreateOrganization_CreateOrganization{Organization: org},
+	}, nil
+}
+
+// DeleteOrganization deletes an organization from memory
+func (f *fakeOpenlane) DeleteOrganization(_ context.Context, id string, _ ...clientv2.RequestInterceptor) (*openlaneclient.DeleteOrganization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.orgs, id)
+
+	return &openlaneclient.DeleteOrganization{
+		DeleteOrganization: openlaneclient.DeleteOrganization_DeleteOrganization{DeletedID: id},
+	}, nil
+}
+
+// GetOrganizationByID returns an organization from memory
+func (f *fakeOpenlane) GetOrganizationByID(_ context.Context, id string, _ ...clientv2.RequestInterceptor) (*openlaneclient.GetOrganizationByID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	org, ok := f.orgs[id]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	out := openlaneclient.GetOrganizationByID_Organization{
+		ID:          org.ID,
+		Name:        org.Name,
+		DisplayName: org.DisplayName,
+		Description: org.Description,
+		Tags:        org.Tags,
+		CreatedAt:   org.CreatedAt,
+	}
+
+	if org.Parent != nil {
+		out.Parent = &openlaneclient.GetOrganizationByID_Organization_Parent{ID: org.Parent.ID}
+	}
+
+	return &openlaneclient.GetOrganizationByID{Organization: out}, nil
+}
+
+// GetOrganizations returns the root organizations or the children of the parents in the where filter from memory,
+// after the id in the filter when it is set
+func (f *fakeOpenlane) GetOrganizations(_ context.Context, where *openlaneclient.OrganizationWhereInput, _ ...clientv2.RequestInterceptor) (*openlaneclient.GetOrganizations, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := &openlaneclient.GetOrganizations{}
+
+	for _, org := range f.orgs {
+		if where.IDGt != nil && org.ID <= *where.IDGt {
+			continue
+		}
+
+		if where.ParentOrganizationIDIsNil != nil {
+			if org.Parent != nil {
+				continue
+			}
+		} else if org.Parent == nil || !slices.Contains(where.ParentOrganizationIDIn, org.Parent.ID) {
+			continue
+		}
+
+		node := &openlaneclient.GetOrganizations_Organizations_Edges_Node{
+			ID:          org.ID,
+			Name:        org.Name,
+			DisplayName: org.DisplayName,
+			Description: org.Description,
+			Tags:        org.Tags,
+			CreatedAt:   org.CreatedAt,
+		}
+
+		if org.Parent != nil {
+			node.Parent = &openlaneclient.GetOrganizations_Organizations_Edges_Node_Parent{ID: org.Parent.ID}
+		}
+
+		out.Organizations.Edges = append(out.Organizations.Edges, &openlaneclient.GetOrganizations_Organizations_Edges{Node: node})
+	}
+
+	return out, nil
+}
+
+// newTestServer starts an httptest server serving the echo router of the server, backed by the fake openlane client,
+// and returns a client for it
+func newTestServer(t *testing.T) (client.Client, *fakeOpenlane) {
+	t.Helper()
+
+	f := &fakeOpenlane{
+		orgs:       map[string]openlaneclient.CreateOrganization_CreateOrganization_Organization{},
+		failCreate: map[string]error{},
+	}
+
+	h := &handlers.Handler{
+		IsTest:         true,
+		OpenlaneClient: &openlaneclient.OpenlaneClient{OpenlaneGraphClient: f},
+		Concurrency:    2,
+		Jobs:           handlers.NewJobs(1, 10, time.Minute),
+		Audit:          audit.NewLog(audit.NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))),
+	}
+
+	h.AddReadinessCheck("openlane", func(context.Context) error { return nil })
+
+	router, err := server.NewRouter()
+	require.NoError(t, err)
+
+	router.Handler = h
+	// every request the client sends, and every response it receives, must match the specification
+	router.Validation = validation.Config{Enabled: true, Responses: true}
+
+	require.NoError(t, route.RegisterRoutes(router))
+
+	srv := httptest.NewServer(router.Echo)
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	c, err := client.New(client.NewDefaultConfig(), client.WithBaseURL(baseURL))
+	require.NoError(t, err)
+
+	return c, f
+}
+
+func TestOrganizationLifecycle(t *testing.T) {
+	c, _ := newTestServer(t)
+	ctx := context.Background()
+
+	plan, err := c.OrganizationPlan(ctx, &client.OrganizationRequest{Name: "MITB Inc.", Environments: []string{"production"}})
+	require.NoError(t, err)
+	assert.True(t, plan.DryRun)
+
+	created, err := c.OrganizationCreate(ctx, &client.OrganizationRequest{Name: "MITB Inc.", Environments: []string{"production"}})
+	require.NoError(t, err)
+	require.Len(t, created.Environments, 1)
+
+	got, err := c.OrganizationGet(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "MITB Inc.", got.Name)
+	assert.Equal(t, created.Environments[0].ID, got.Environments[0].ID)
+
+	updated, err := c.OrganizationUpdate(ctx, created.ID, &client.OrganizationUpdateRequest{Environments: []string{"staging"}})
+	require.NoError(t, err)
+	assert.Len(t, updated.Environments, 2)
+	assert.Len(t, updated.Created, 11)
+
+	reconciled, err := c.OrganizationReconcile(ctx, created.ID, &client.OrganizationReconcileRequest{
+		Environments: []string{"production", "staging"},
+	})
+	require.NoError(t, err)
+	assert.True(t, reconciled.InSync)
+
+	dryRun, err := c.OrganizationDelete(ctx, created.ID, true)
+	require.NoError(t, err)
+	assert.True(t, dryRun.DryRun)
+	assert.Len(t, dryRun.Organizations, 23)
+
+	deleted, err := c.OrganizationDelete(ctx, created.ID, false)
+	require.NoError(t, err)
+	assert.Len(t, deleted.Organizations, 23)
+
+	_, err = c.OrganizationGet(ctx, created.ID)
+	require.ErrorIs(t, err, client.ErrNotFound)
+
+	var rerr *client.RequestError
+	require.ErrorAs(t, err, &rerr)
+	assert.Equal(t, 404, rerr.StatusCode)
+
+	entries, err := c.Audit(ctx, client.AuditOptions{OrganizationID: created.ID})
+	require.NoError(t, err)
+	// the reconcile found nothing to repair so it is not recorded
+	require.Len(t, entries.Entries, 3)
+	assert.Equal(t, string(models.AuditActionCreate), entries.Entries[0].Action)
+	assert.Equal(t, string(models.AuditActionUpdate), entries.Entries[1].Action)
+	assert.Equal(t, string(models.AuditActionDelete), entries.Entries[2].Action)
+
+	entries, err = c.Audit(ctx, client.AuditOptions{OrganizationID: created.ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 1)
+	assert.Equal(t, string(models.AuditActionDelete), entries.Entries[0].Action)
+}
+
+func TestOrganizationCreateAsync(t *testing.T) {
+	c, _ := newTestServer(t)
+	ctx := context.Background()
+
+	submitted, err := c.OrganizationCreateAsync(ctx, &client.OrganizationRequest{Name: "MITB Inc.", Environments: []string{"production"}})
+	require.NoError(t, err)
+	require.NotEmpty(t, submitted.Job.ID)
+
+	var job *client.ProvisioningJobReply
+
+	require.Eventually(t, func() bool {
+		job, err = c.OrganizationJob(ctx, submitted.Job.ID)
+		require.NoError(t, err)
+
+		return job.Job.Status == string(models.JobStatusSucceeded)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NotNil(t, job.Job.Result)
+	assert.Equal(t, "MITB Inc.", job.Job.Result.Name)
+
+	_, err = c.OrganizationJob(ctx, "missing")
+	require.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestOrganizationBulkCreate(t *testing.T) {
+	c, f := newTestServer(t)
+	ctx := context.Background()
+
+	f.failCreate["broken inc"] = errUpstream
+
+	submitted, err := c.OrganizationBulkCreate(ctx, []client.OrganizationRequest{
+		{Name: "MITB Inc.", Environments: []string{"production"}},
+		{Name: "Broken Inc", Environments: []string{"production"}},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, submitted.Job.ID)
+
+	job, err := client.WaitForJob(ctx, c, submitted.Job.ID, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.JobStatusFailed), job.Job.Status)
+	require.NotNil(t, job.Job.BulkResult)
+	assert.Equal(t, 1, job.Job.BulkResult.Succeeded)
+	assert.Equal(t, 1, job.Job.BulkResult.Failed)
+
+	// a single organization that fails is returned as a typed error
+	_, err = c.OrganizationCreate(ctx, &client.OrganizationRequest{Name: "Broken Inc", Environments: []string{"production"}})
+	require.ErrorIs(t, err, client.ErrProvisioningFailed)
+
+	_, err = c.OrganizationCreate(ctx, &client.OrganizationRequest{Name: "x"})
+	require.ErrorIs(t, err, client.ErrInvalidInput)
+}
+
+func TestOrganizationList(t *testing.T) {
+	c, _ := newTestServer(t)
+	ctx := context.Background()
+
+	names := []string{"Cat Inc.", "Dog Inc.", "Meow Corp", "MITB Inc.", "Purr LLC"}
+
+	for _, name := range names {
+		_, err := c.OrganizationCreate(ctx, &client.OrganizationRequest{Name: name, Environments: []string{"production"}})
+		require.NoError(t, err)
+	}
+
+	page, err := c.OrganizationList(ctx, client.ListOptions{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Organizations, 2)
+	assert.Equal(t, page.Organizations[1].ID, page.NextCursor)
+
+	// only the root organizations are listed, in id order
+	all, err := client.ListAllOrganizations(ctx, c, 2)
+	require.NoError(t, err)
+
+	listed := []string{}
+	for _, org := range all {
+		listed = append(listed, org.Name)
+	}
+
+	assert.Equal(t, names, listed)
+
+	// iteration can stop early
+	count := 0
+
+	for _, err := range client.OrganizationPages(ctx, c, 2) {
+		require.NoError(t, err)
+
+		count++
+		if count == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, 3, count)
+
+	_, err = c.OrganizationList(ctx, client.ListOptions{Limit: models.MaxPageSize + 1})
+	require.ErrorIs(t, err, client.ErrInvalidInput)
+}
+
+func TestHealth(t *testing.T) {
+	c, _ := newTestServer(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Live(ctx))
+
+	status, err := c.Ready(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"openlane": "OK"}, status)
+}
@@ -2,12 +2,43 @@ package client
 
 import (
 	"net/url"
+	"time"
 )
 
 // Config is the configuration for the openlane cloud API client
 type Config struct {
 	// BaseURL is the base URL for the openlane API
 	BaseURL *url.URL `json:"baseUrl" yaml:"baseUrl" koanf:"baseUrl" default:"http://localhost:17610"`
+	// Timeout is the deadline for every request, including any retries; a shorter deadline of the request context is
+	// used instead, and there is no deadline when it is zero
+	Timeout time.Duration `json:"timeout" yaml:"timeout" koanf:"timeout" default:"1m"`
+	// Retry is the policy used to retry requests that failed with a transient error
+	Retry RetryPolicy `json:"retry" yaml:"retry" koanf:"retry"`
+	// Token is the API token or JWT sent as a bearer token in the Authorization header
+	Token string `json:"token" yaml:"token" koanf:"token"`
+	// APIKey is sent in the APIKeyHeader header, for gateways in front of the server that authenticate with an API key
+	APIKey string `json:"apiKey" yaml:"apiKey" koanf:"apiKey"`
+	// APIKeyHeader is the header the APIKey is sent in
+	APIKeyHeader string `json:"apiKeyHeader" yaml:"apiKeyHeader" koanf:"apiKeyHeader" default:"X-API-Key"`
+	// OpenlaneToken is the openlane credential of the caller, used when the server forwards credentials to openlane
+	OpenlaneToken string `json:"openlaneToken" yaml:"openlaneToken" koanf:"openlaneToken"`
+	// UserAgent is sent in the User-Agent header of every request
+	UserAgent string `json:"userAgent" yaml:"userAgent" koanf:"userAgent" default:"openlane-cloud-client"`
+	// Headers are added to every request
+	Headers map[string]string `json:"headers" yaml:"headers" koanf:"headers"`
+	// TLS is the configuration used to verify the server, and to authenticate the client with a certificate
+	TLS TLSConfig `json:"tls" yaml:"tls" koanf:"tls"`
+}
+
+// TLSConfig contains the certificate files used for connections to servers signed by a private certificate authority,
+// or that require a client certificate
+type TLSConfig struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition to the system certificate authorities
+	CAFile string `json:"caFile" yaml:"caFile" koanf:"caFile"`
+	// CertFile is the PEM client certificate sent to servers that require mutual TLS, KeyFile must also be set
+	CertFile string `json:"certFile" yaml:"certFile" koanf:"certFile"`
+	// KeyFile is the PEM private key of the client certificate
+	KeyFile string `json:"keyFile" yaml:"keyFile" koanf:"keyFile"`
 }
 
 // NewDefaultConfig returns a new default configuration for the openlane cloud API client
@@ -20,4 +51,14 @@ var defaultClientConfig = Config{
 		Scheme: "http",
 		Host:   "localhost:17610",
 	},
+	Timeout:      time.Minute,
+	APIKeyHeader: DefaultAPIKeyHeader,
+	UserAgent:    DefaultUserAgent,
+	Retry: RetryPolicy{
+		MaxAttempts: 3,                      //nolint:mnd
+		BaseDelay:   500 * time.Millisecond, //nolint:mnd
+		Multiplier:  2,                      //nolint:mnd
+		Jitter:      0.2,                    //nolint:mnd
+		MaxDelay:    10 * time.Second,       //nolint:mnd
+	},
 }
@@ -1,8 +1,39 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
+
+	"github.com/theopenlane/utils/rout"
+)
+
+var (
+	// ErrInvalidInput is matched by a RequestError when the request was rejected because it is invalid
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrUnauthorized is matched by a RequestError when the credentials were rejected
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is matched by a RequestError when the credentials are not allowed to perform the request
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound is matched by a RequestError when the organization, or job, does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by a RequestError when the organization already exists or the request conflicts with another
+	ErrConflict = errors.New("conflict")
+	// ErrProvisioningFailed is matched by a RequestError when the hierarchy could not be created or deleted part way
+	// through; any organizations that were created have been rolled back
+	ErrProvisioningFailed = errors.New("provisioning failed")
+	// ErrRateLimited is matched by a RequestError when the request was rate limited and should be retried later
+	ErrRateLimited = errors.New("rate limited")
+	// ErrUpstream is matched by a RequestError when the openlane server failed or is unavailable
+	ErrUpstream = errors.New("openlane server error")
+	// ErrUnavailable is matched by a RequestError when the server, or a service it depends on, is unavailable
+	ErrUnavailable = errors.New("service unavailable")
+	// ErrTokenUnavailable is returned when the token source could not provide a token for the request
+	ErrTokenUnavailable = errors.New("unable to get token")
+	// ErrInvalidCABundle is returned when a CA bundle does not contain any PEM certificates
+	ErrInvalidCABundle = errors.New("ca bundle does not contain any certificates")
 )
 
 // RequestError is a generic error when a request with the client fails
@@ -11,6 +42,10 @@ type RequestError struct {
 	StatusCode int
 	// Body of the response
 	Body string
+	// Code is the error code returned by the server, if any
+	Code rout.ErrorCode
+	// RetryAfter is how long the server asked to wait before retrying, if any
+	RetryAfter time.Duration
 }
 
 // Error returns the RequestError in string format
@@ -22,10 +57,43 @@ func (e *RequestError) Error() string {
 	return fmt.Sprintf("unable to process request (status %d): %s", e.StatusCode, strings.ToLower(e.Body))
 }
 
+// Is allows the RequestError to be matched against the typed errors using errors.Is based on the status code
+func (e *RequestError) Is(target error) bool {
+	switch target {
+	case ErrInvalidInput:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrProvisioningFailed:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUpstream:
+		return e.StatusCode == http.StatusBadGateway || e.StatusCode == http.StatusServiceUnavailable
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	}
+
+	return false
+}
+
 // newRequestError returns an error when a openlane client request fails
-func newRequestError(statusCode int, body string) *RequestError {
-	return &RequestError{
-		StatusCode: statusCode,
-		Body:       body,
+func newRequestError(resp *http.Response, reply rout.Reply) *RequestError {
+	e := &RequestError{
+		StatusCode: resp.StatusCode,
+		Body:       reply.Error,
+		Code:       reply.ErrorCode,
 	}
+
+	if after, ok := retryAfter(resp.Header.Get(retryAfterHeader)); ok {
+		e.RetryAfter = after
+	}
+
+	return e
 }
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/theopenlane/utils/rout"
+)
+
+func TestRequestError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "30")
+
+	err := newRequestError(resp, rout.ErrorResponseWithCode("slow down", "RATE_LIMITED"))
+
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.False(t, errors.Is(err, ErrConflict))
+	assert.Equal(t, rout.ErrorCode("RATE_LIMITED"), err.Code)
+	assert.Equal(t, 30*time.Second, err.RetryAfter)
+	assert.Equal(t, "unable to process request (status 429): slow down", err.Error())
+
+	for _, tc := range []struct {
+		status int
+		target error
+	}{
+		{http.StatusBadRequest, ErrInvalidInput},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusUnprocessableEntity, ErrProvisioningFailed},
+		{http.StatusBadGateway, ErrUpstream},
+		{http.StatusServiceUnavailable, ErrUpstream},
+		{http.StatusServiceUnavailable, ErrUnavailable},
+	} {
+		err := newRequestError(&http.Response{StatusCode: tc.status, Header: http.Header{}}, rout.Reply{})
+
+		assert.ErrorIs(t, err, tc.target, tc.status)
+		assert.Zero(t, err.RetryAfter)
+	}
+}
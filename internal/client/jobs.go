@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// WaitForJob polls the asynchronous provisioning job every interval until it has succeeded or failed and returns the
+// finished job; an error is returned when the job cannot be retrieved or the context is done first
+func WaitForJob(ctx context.Context, c Client, id string, interval time.Duration) (*ProvisioningJobReply, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		out, err := c.OrganizationJob(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if out.Job.Status == string(models.JobStatusSucceeded) || out.Job.Status == string(models.JobStatusFailed) {
+			return out, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,429 @@
+// Code generated by openapi/openapi_generator.go; DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Address is the Address schema of the specification
+type Address struct {
+	City       string `json:"city,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Line1      string `json:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty"`
+	PostalCode string `json:"postalCode,omitempty"`
+	State      string `json:"state,omitempty"`
+}
+
+// AuditEntry is the AuditEntry schema of the specification
+type AuditEntry struct {
+	Action         string          `json:"action,omitempty"`
+	AuthMethod     string          `json:"authMethod,omitempty"`
+	Caller         string          `json:"caller,omitempty"`
+	Created        []string        `json:"created,omitempty"`
+	Deleted        []string        `json:"deleted,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	ID             string          `json:"id,omitempty"`
+	Input          json.RawMessage `json:"input,omitempty"`
+	OrganizationID string          `json:"organizationId,omitempty"`
+	Outcome        string          `json:"outcome,omitempty"`
+	RequestID      string          `json:"requestId,omitempty"`
+	Time           time.Time       `json:"time,omitempty"`
+}
+
+// AuditReply is the AuditReply schema of the specification
+type AuditReply struct {
+	Entries    []AuditEntry `json:"entries,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	ErrorCode  string       `json:"error_code,omitempty"`
+	Success    bool         `json:"success,omitempty"`
+	Unverified bool         `json:"unverified,omitempty"`
+}
+
+// Bucket is the Bucket schema of the specification
+type Bucket struct {
+	CreatedAt *time.Time     `json:"createdAt,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	ParentID  string         `json:"parentId,omitempty"`
+	Relations []Relationship `json:"relations,omitempty"`
+	Settings  *OrgSettings   `json:"settings,omitempty"`
+	Slug      string         `json:"slug,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+}
+
+// BulkOrganizationReply is the BulkOrganizationReply schema of the specification
+type BulkOrganizationReply struct {
+	Error      string                   `json:"error,omitempty"`
+	ErrorCode  string                   `json:"error_code,omitempty"`
+	Failed     int                      `json:"failed,omitempty"`
+	Results    []BulkOrganizationResult `json:"results,omitempty"`
+	Succeeded  int                      `json:"succeeded,omitempty"`
+	Success    bool                     `json:"success,omitempty"`
+	Total      int                      `json:"total,omitempty"`
+	Unverified bool                     `json:"unverified,omitempty"`
+}
+
+// BulkOrganizationRequest is the BulkOrganizationRequest schema of the specification
+type BulkOrganizationRequest []OrganizationRequest
+
+// BulkOrganizationResult is the BulkOrganizationResult schema of the specification
+type BulkOrganizationResult struct {
+	Error        string                 `json:"error,omitempty"`
+	ErrorCode    string                 `json:"error_code,omitempty"`
+	Fields       []FieldError           `json:"fields,omitempty"`
+	Index        int                    `json:"index,omitempty"`
+	Name         string                 `json:"name,omitempty"`
+	Organization *OrganizationReply     `json:"organization,omitempty"`
+	Orphaned     []OrgDetails           `json:"orphaned,omitempty"`
+	Plan         *OrganizationPlanReply `json:"plan,omitempty"`
+	Status       int                    `json:"status,omitempty"`
+	Success      bool                   `json:"success,omitempty"`
+	Unverified   bool                   `json:"unverified,omitempty"`
+}
+
+// DriftNode is the DriftNode schema of the specification
+type DriftNode struct {
+	Descendants int    `json:"descendants,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Path        string `json:"path,omitempty"`
+}
+
+// Environment is the Environment schema of the specification
+type Environment struct {
+	Buckets   []Bucket     `json:"buckets,omitempty"`
+	CreatedAt *time.Time   `json:"createdAt,omitempty"`
+	ID        string       `json:"id,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	ParentID  string       `json:"parentId,omitempty"`
+	Settings  *OrgSettings `json:"settings,omitempty"`
+	Slug      string       `json:"slug,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+}
+
+// ErrorResponse is the ErrorResponse schema of the specification
+type ErrorResponse struct {
+	Code  int    `json:"code,omitempty"`
+	Reply *Reply `json:"reply,omitempty"`
+}
+
+// FieldError is the FieldError schema of the specification
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// GroupDetails is the GroupDetails schema of the specification
+type GroupDetails struct {
+	ID             string               `json:"id,omitempty"`
+	Members        []GroupMemberDetails `json:"members,omitempty"`
+	Name           string               `json:"name,omitempty"`
+	OrganizationID string               `json:"organizationId,omitempty"`
+}
+
+// GroupMember is the GroupMember schema of the specification
+type GroupMember struct {
+	Role   string `json:"role,omitempty"`
+	UserID string `json:"userId,omitempty"`
+}
+
+// GroupMemberDetails is the GroupMemberDetails schema of the specification
+type GroupMemberDetails struct {
+	ID     string `json:"id,omitempty"`
+	Role   string `json:"role,omitempty"`
+	UserID string `json:"userId,omitempty"`
+}
+
+// InviteDetails is the InviteDetails schema of the specification
+type InviteDetails struct {
+	Email  string `json:"email,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Role   string `json:"role,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// JobNode is the JobNode schema of the specification
+type JobNode struct {
+	CreatedAt *time.Time   `json:"createdAt,omitempty"`
+	ID        string       `json:"id,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	ParentID  string       `json:"parentId,omitempty"`
+	Settings  *OrgSettings `json:"settings,omitempty"`
+	Slug      string       `json:"slug,omitempty"`
+	Status    string       `json:"status,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+}
+
+// OrgDetails is the OrgDetails schema of the specification
+type OrgDetails struct {
+	CreatedAt *time.Time   `json:"createdAt,omitempty"`
+	ID        string       `json:"id,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	ParentID  string       `json:"parentId,omitempty"`
+	Settings  *OrgSettings `json:"settings,omitempty"`
+	Slug      string       `json:"slug,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+}
+
+// OrgNode is the OrgNode schema of the specification
+type OrgNode struct {
+	Children  []OrgNode    `json:"children,omitempty"`
+	CreatedAt *time.Time   `json:"createdAt,omitempty"`
+	ID        string       `json:"id,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	ParentID  string       `json:"parentId,omitempty"`
+	Settings  *OrgSettings `json:"settings,omitempty"`
+	Slug      string       `json:"slug,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+}
+
+// OrgSettings is the OrgSettings schema of the specification
+type OrgSettings struct {
+	AllowedEmailDomains []string `json:"allowedEmailDomains,omitempty"`
+	BillingContact      string   `json:"billingContact,omitempty"`
+	BillingEmail        string   `json:"billingEmail,omitempty"`
+	BillingPhone        string   `json:"billingPhone,omitempty"`
+	Domains             []string `json:"domains,omitempty"`
+}
+
+// OrganizationDeleteReply is the OrganizationDeleteReply schema of the specification
+type OrganizationDeleteReply struct {
+	DryRun        bool         `json:"dryRun,omitempty"`
+	Error         string       `json:"error,omitempty"`
+	ErrorCode     string       `json:"error_code,omitempty"`
+	Failed        []OrgDetails `json:"failed,omitempty"`
+	Organizations []OrgDetails `json:"organizations,omitempty"`
+	Success       bool         `json:"success,omitempty"`
+	Unverified    bool         `json:"unverified,omitempty"`
+}
+
+// OrganizationGroup is the OrganizationGroup schema of the specification
+type OrganizationGroup struct {
+	Description  string        `json:"description,omitempty"`
+	Environments []string      `json:"environments,omitempty"`
+	Members      []GroupMember `json:"members,omitempty"`
+	Name         string        `json:"name,omitempty"`
+}
+
+// OrganizationInvite is the OrganizationInvite schema of the specification
+type OrganizationInvite struct {
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role,omitempty"`
+}
+
+// OrganizationListReply is the OrganizationListReply schema of the specification
+type OrganizationListReply struct {
+	Error         string       `json:"error,omitempty"`
+	ErrorCode     string       `json:"error_code,omitempty"`
+	NextCursor    string       `json:"nextCursor,omitempty"`
+	Organizations []OrgDetails `json:"organizations,omitempty"`
+	Success       bool         `json:"success,omitempty"`
+	Unverified    bool         `json:"unverified,omitempty"`
+}
+
+// OrganizationPlanReply is the OrganizationPlanReply schema of the specification
+type OrganizationPlanReply struct {
+	Collisions   []OrgDetails         `json:"collisions,omitempty"`
+	DryRun       bool                 `json:"dryRun,omitempty"`
+	Error        string               `json:"error,omitempty"`
+	ErrorCode    string               `json:"error_code,omitempty"`
+	Invites      []OrganizationInvite `json:"invites,omitempty"`
+	Organization *PlannedOrg          `json:"organization,omitempty"`
+	Success      bool                 `json:"success,omitempty"`
+	Template     string               `json:"template,omitempty"`
+	Total        int                  `json:"total,omitempty"`
+	Unverified   bool                 `json:"unverified,omitempty"`
+}
+
+// OrganizationReconcileReply is the OrganizationReconcileReply schema of the specification
+type OrganizationReconcileReply struct {
+	Created    []OrgDetails  `json:"created,omitempty"`
+	Deleted    []OrgDetails  `json:"deleted,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	ErrorCode  string        `json:"error_code,omitempty"`
+	Extra      []DriftNode   `json:"extra,omitempty"`
+	InSync     bool          `json:"inSync,omitempty"`
+	Missing    []DriftNode   `json:"missing,omitempty"`
+	Renamed    []RenamedNode `json:"renamed,omitempty"`
+	Repaired   bool          `json:"repaired,omitempty"`
+	Success    bool          `json:"success,omitempty"`
+	Template   string        `json:"template,omitempty"`
+	Unverified bool          `json:"unverified,omitempty"`
+}
+
+// OrganizationReconcileRequest is the OrganizationReconcileRequest schema of the specification
+type OrganizationReconcileRequest struct {
+	Buckets       []string `json:"buckets,omitempty"`
+	Environments  []string `json:"environments,omitempty"`
+	Prune         bool     `json:"prune,omitempty"`
+	Relationships []string `json:"relationships,omitempty"`
+	Repair        bool     `json:"repair,omitempty"`
+	Template      string   `json:"template,omitempty"`
+}
+
+// OrganizationReply is the OrganizationReply schema of the specification
+type OrganizationReply struct {
+	Children     []OrgNode       `json:"children,omitempty"`
+	CreatedAt    *time.Time      `json:"createdAt,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Domains      []string        `json:"domains,omitempty"`
+	Environments []Environment   `json:"environments,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	ErrorCode    string          `json:"error_code,omitempty"`
+	Groups       []GroupDetails  `json:"groups,omitempty"`
+	ID           string          `json:"id,omitempty"`
+	Invites      []InviteDetails `json:"invites,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	ParentID     string          `json:"parentId,omitempty"`
+	Settings     *OrgSettings    `json:"settings,omitempty"`
+	Slug         string          `json:"slug,omitempty"`
+	Success      bool            `json:"success,omitempty"`
+	Tags         []string        `json:"tags,omitempty"`
+	Template     string          `json:"template,omitempty"`
+	Unverified   bool            `json:"unverified,omitempty"`
+}
+
+// OrganizationRequest is the OrganizationRequest schema of the specification
+type OrganizationRequest struct {
+	Buckets       []string              `json:"buckets,omitempty"`
+	Description   string                `json:"description,omitempty"`
+	Domains       []string              `json:"domains,omitempty"`
+	DryRun        bool                  `json:"dryRun,omitempty"`
+	Environments  []string              `json:"environments,omitempty"`
+	Groups        []OrganizationGroup   `json:"groups,omitempty"`
+	Invites       []OrganizationInvite  `json:"invites,omitempty"`
+	Name          string                `json:"name,omitempty"`
+	Relationships []string              `json:"relationships,omitempty"`
+	Settings      *OrganizationSettings `json:"settings,omitempty"`
+	Template      string                `json:"template,omitempty"`
+}
+
+// OrganizationSettings is the OrganizationSettings schema of the specification
+type OrganizationSettings struct {
+	AllowedEmailDomains         []string `json:"allowedEmailDomains,omitempty"`
+	AvatarURL                   string   `json:"avatarURL,omitempty"`
+	BillingAddress              *Address `json:"billingAddress,omitempty"`
+	BillingContact              string   `json:"billingContact,omitempty"`
+	BillingEmail                string   `json:"billingEmail,omitempty"`
+	BillingNotificationsEnabled *bool    `json:"billingNotificationsEnabled,omitempty"`
+	BillingPhone                string   `json:"billingPhone,omitempty"`
+	GeoLocation                 string   `json:"geoLocation,omitempty"`
+	Inherit                     []string `json:"inherit,omitempty"`
+	Tags                        []string `json:"tags,omitempty"`
+	TaxIdentifier               string   `json:"taxIdentifier,omitempty"`
+}
+
+// OrganizationUpdateReply is the OrganizationUpdateReply schema of the specification
+type OrganizationUpdateReply struct {
+	Children     []OrgNode       `json:"children,omitempty"`
+	Created      []OrgDetails    `json:"created,omitempty"`
+	CreatedAt    *time.Time      `json:"createdAt,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Domains      []string        `json:"domains,omitempty"`
+	Environments []Environment   `json:"environments,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	ErrorCode    string          `json:"error_code,omitempty"`
+	Groups       []GroupDetails  `json:"groups,omitempty"`
+	ID           string          `json:"id,omitempty"`
+	Invites      []InviteDetails `json:"invites,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	ParentID     string          `json:"parentId,omitempty"`
+	Settings     *OrgSettings    `json:"settings,omitempty"`
+	Slug         string          `json:"slug,omitempty"`
+	Success      bool            `json:"success,omitempty"`
+	Tags         []string        `json:"tags,omitempty"`
+	Template     string          `json:"template,omitempty"`
+	Unverified   bool            `json:"unverified,omitempty"`
+}
+
+// OrganizationUpdateRequest is the OrganizationUpdateRequest schema of the specification
+type OrganizationUpdateRequest struct {
+	Buckets       []string `json:"buckets,omitempty"`
+	Environments  []string `json:"environments,omitempty"`
+	Relationships []string `json:"relationships,omitempty"`
+}
+
+// PlannedOrg is the PlannedOrg schema of the specification
+type PlannedOrg struct {
+	Children    []PlannedOrg `json:"children,omitempty"`
+	Description string       `json:"description,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Domains     []string     `json:"domains,omitempty"`
+	ExistingID  string       `json:"existingId,omitempty"`
+	Groups      []string     `json:"groups,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	Parent      string       `json:"parent,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+}
+
+// ProvisioningFailureReply is the ProvisioningFailureReply schema of the specification
+type ProvisioningFailureReply struct {
+	Created    []OrgDetails `json:"created,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	ErrorCode  string       `json:"error_code,omitempty"`
+	Orphaned   []OrgDetails `json:"orphaned,omitempty"`
+	RolledBack []OrgDetails `json:"rolledBack,omitempty"`
+	Success    bool         `json:"success,omitempty"`
+	Unverified bool         `json:"unverified,omitempty"`
+}
+
+// ProvisioningJob is the ProvisioningJob schema of the specification
+type ProvisioningJob struct {
+	BulkResult *BulkOrganizationReply    `json:"bulkResult,omitempty"`
+	Completed  int                       `json:"completed,omitempty"`
+	CreatedAt  time.Time                 `json:"createdAt,omitempty"`
+	Failure    *ProvisioningFailureReply `json:"failure,omitempty"`
+	ID         string                    `json:"id,omitempty"`
+	Nodes      []JobNode                 `json:"nodes,omitempty"`
+	Result     *OrganizationReply        `json:"result,omitempty"`
+	Status     string                    `json:"status,omitempty"`
+	Total      int                       `json:"total,omitempty"`
+	UpdatedAt  time.Time                 `json:"updatedAt,omitempty"`
+}
+
+// ProvisioningJobReply is the ProvisioningJobReply schema of the specification
+type ProvisioningJobReply struct {
+	Error      string           `json:"error,omitempty"`
+	ErrorCode  string           `json:"error_code,omitempty"`
+	Job        *ProvisioningJob `json:"job,omitempty"`
+	Success    bool             `json:"success,omitempty"`
+	Unverified bool             `json:"unverified,omitempty"`
+}
+
+// Relationship is the Relationship schema of the specification
+type Relationship struct {
+	CreatedAt *time.Time   `json:"createdAt,omitempty"`
+	ID        string       `json:"id,omitempty"`
+	Name      string       `json:"name,omitempty"`
+	ParentID  string       `json:"parentId,omitempty"`
+	Settings  *OrgSettings `json:"settings,omitempty"`
+	Slug      string       `json:"slug,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+}
+
+// RenamedNode is the RenamedNode schema of the specification
+type RenamedNode struct {
+	ExpectedName string `json:"expectedName,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Path         string `json:"path,omitempty"`
+}
+
+// Reply is the Reply schema of the specification
+type Reply struct {
+	Error      string `json:"error,omitempty"`
+	ErrorCode  string `json:"error_code,omitempty"`
+	Success    bool   `json:"success,omitempty"`
+	Unverified bool   `json:"unverified,omitempty"`
+}
+
+// ValidationFailureReply is the ValidationFailureReply schema of the specification
+type ValidationFailureReply struct {
+	Error      string       `json:"error,omitempty"`
+	ErrorCode  string       `json:"error_code,omitempty"`
+	Fields     []FieldError `json:"fields,omitempty"`
+	Success    bool         `json:"success,omitempty"`
+	Unverified bool         `json:"unverified,omitempty"`
+}
@@ -0,0 +1,283 @@
+// Code generated by openapi/openapi_generator.go; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/theopenlane/httpsling"
+)
+
+// auditParams are the parameters of the AuditHandler operation, parameters with a zero value are not sent
+type auditParams struct {
+	// Since is the since query parameter: only return entries recorded at or after the RFC 3339 time
+	Since time.Time
+	// Until is the until query parameter: only return entries recorded at or before the RFC 3339 time
+	Until time.Time
+	// Organization is the organization query parameter: only return entries for the hierarchy with the root organization, or that created or deleted the organization
+	Organization string
+	// Limit is the limit query parameter: the maximum number of entries to return, the most recent entries are returned
+	Limit int
+}
+
+// sendAudit sends the request of the AuditHandler operation, GET /audit
+func sendAudit(ctx context.Context, c *APIv1, params auditParams) (*AuditReply, error) {
+	opts := []httpsling.Option{httpsling.Get("/v1/audit")}
+
+	if !params.Since.IsZero() {
+		opts = append(opts, httpsling.QueryParam("since", params.Since.Format(time.RFC3339)))
+	}
+
+	if !params.Until.IsZero() {
+		opts = append(opts, httpsling.QueryParam("until", params.Until.Format(time.RFC3339)))
+	}
+
+	if params.Organization != "" {
+		opts = append(opts, httpsling.QueryParam("organization", params.Organization))
+	}
+
+	if params.Limit != 0 {
+		opts = append(opts, httpsling.QueryParam("limit", strconv.Itoa(params.Limit)))
+	}
+
+	out := new(AuditReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// bulkOrganizationParams are the parameters of the BulkOrganizationHandler operation, parameters with a zero value are not sent
+type bulkOrganizationParams struct {
+	// IdempotencyKey is the Idempotency-Key header parameter: unique key for the request, retries with the same key replay the original response instead of creating new hierarchies
+	IdempotencyKey string
+}
+
+// sendBulkOrganization sends the request of the BulkOrganizationHandler operation, POST /organizations/bulk
+func sendBulkOrganization(ctx context.Context, c *APIv1, params bulkOrganizationParams, body *BulkOrganizationRequest) (*ProvisioningJobReply, error) {
+	opts := []httpsling.Option{httpsling.Post("/v1/organizations/bulk")}
+
+	if params.IdempotencyKey != "" {
+		opts = append(opts, httpsling.Header("Idempotency-Key", params.IdempotencyKey))
+	}
+
+	if body != nil {
+		opts = append(opts, httpsling.Body(body))
+	}
+
+	out := new(ProvisioningJobReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// deleteOrganizationParams are the parameters of the DeleteOrganizationHandler operation, parameters with a zero value are not sent
+type deleteOrganizationParams struct {
+	// ID is the id path parameter: the id of the root organization
+	ID string
+	// DryRun is the dryRun query parameter: return the organizations that would be deleted without deleting them
+	DryRun bool
+	// XOpenlaneToken is the X-Openlane-Token header parameter: openlane bearer token or personal access token of the caller, used when the server forwards caller credentials to openlane
+	XOpenlaneToken string
+}
+
+// sendDeleteOrganization sends the request of the DeleteOrganizationHandler operation, DELETE /organization/{id}
+func sendDeleteOrganization(ctx context.Context, c *APIv1, params deleteOrganizationParams) (*OrganizationDeleteReply, error) {
+	opts := []httpsling.Option{httpsling.Delete("/v1/organization/" + url.PathEscape(params.ID))}
+
+	if params.DryRun {
+		opts = append(opts, httpsling.QueryParam("dryRun", strconv.FormatBool(params.DryRun)))
+	}
+
+	if params.XOpenlaneToken != "" {
+		opts = append(opts, httpsling.Header("X-Openlane-Token", params.XOpenlaneToken))
+	}
+
+	out := new(OrganizationDeleteReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// getOrganizationParams are the parameters of the GetOrganizationHandler operation, parameters with a zero value are not sent
+type getOrganizationParams struct {
+	// ID is the id path parameter: the id of the root organization
+	ID string
+	// XOpenlaneToken is the X-Openlane-Token header parameter: openlane bearer token or personal access token of the caller, used when the server forwards caller credentials to openlane
+	XOpenlaneToken string
+}
+
+// sendGetOrganization sends the request of the GetOrganizationHandler operation, GET /organization/{id}
+func sendGetOrganization(ctx context.Context, c *APIv1, params getOrganizationParams) (*OrganizationReply, error) {
+	opts := []httpsling.Option{httpsling.Get("/v1/organization/" + url.PathEscape(params.ID))}
+
+	if params.XOpenlaneToken != "" {
+		opts = append(opts, httpsling.Header("X-Openlane-Token", params.XOpenlaneToken))
+	}
+
+	out := new(OrganizationReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// listOrganizationsParams are the parameters of the ListOrganizationsHandler operation, parameters with a zero value are not sent
+type listOrganizationsParams struct {
+	// Limit is the limit query parameter: the maximum number of organizations to return
+	Limit int
+	// Cursor is the cursor query parameter: the nextCursor of the previous page, the first page is returned when it is not set
+	Cursor string
+	// XOpenlaneToken is the X-Openlane-Token header parameter: openlane bearer token or personal access token of the caller, used when the server forwards caller credentials to openlane
+	XOpenlaneToken string
+}
+
+// sendListOrganizations sends the request of the ListOrganizationsHandler operation, GET /organizations
+func sendListOrganizations(ctx context.Context, c *APIv1, params listOrganizationsParams) (*OrganizationListReply, error) {
+	opts := []httpsling.Option{httpsling.Get("/v1/organizations")}
+
+	if params.Limit != 0 {
+		opts = append(opts, httpsling.QueryParam("limit", strconv.Itoa(params.Limit)))
+	}
+
+	if params.Cursor != "" {
+		opts = append(opts, httpsling.QueryParam("cursor", params.Cursor))
+	}
+
+	if params.XOpenlaneToken != "" {
+		opts = append(opts, httpsling.Header("X-Openlane-Token", params.XOpenlaneToken))
+	}
+
+	out := new(OrganizationListReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// organizationParams are the parameters of the OrganizationHandler operation, parameters with a zero value are not sent
+type organizationParams struct {
+	// Async is the async query parameter: provision the hierarchy in the background and return a job that can be polled for status
+	Async bool
+	// IdempotencyKey is the Idempotency-Key header parameter: unique key for the request, retries with the same key replay the original response instead of creating a new hierarchy
+	IdempotencyKey string
+	// XOpenlaneToken is the X-Openlane-Token header parameter: openlane bearer token or personal access token of the caller, used when the server forwards caller credentials to openlane
+	XOpenlaneToken string
+}
+
+// organizationReplies are the replies of the OrganizationHandler operation
+type organizationReplies interface {
+	OrganizationReply | OrganizationPlanReply | ProvisioningJobReply
+}
+
+// sendOrganization sends the request of the OrganizationHandler operation, POST /organization, and decodes the reply into T
+func sendOrganization[T organizationReplies](ctx context.Context, c *APIv1, params organizationParams, body *OrganizationRequest) (*T, error) {
+	opts := []httpsling.Option{httpsling.Post("/v1/organization")}
+
+	if params.Async {
+		opts = append(opts, httpsling.QueryParam("async", strconv.FormatBool(params.Async)))
+	}
+
+	if params.IdempotencyKey != "" {
+		opts = append(opts, httpsling.Header("Idempotency-Key", params.IdempotencyKey))
+	}
+
+	if params.XOpenlaneToken != "" {
+		opts = append(opts, httpsling.Header("X-Openlane-Token", params.XOpenlaneToken))
+	}
+
+	if body != nil {
+		opts = append(opts, httpsling.Body(body))
+	}
+
+	out := new(T)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// organizationJobParams are the parameters of the OrganizationJobHandler operation, parameters with a zero value are not sent
+type organizationJobParams struct {
+	// ID is the id path parameter: the id of the provisioning job
+	ID string
+}
+
+// sendOrganizationJob sends the request of the OrganizationJobHandler operation, GET /organization/jobs/{id}
+func sendOrganizationJob(ctx context.Context, c *APIv1, params organizationJobParams) (*ProvisioningJobReply, error) {
+	opts := []httpsling.Option{httpsling.Get("/v1/organization/jobs/" + url.PathEscape(params.ID))}
+
+	out := new(ProvisioningJobReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// reconcileOrganizationParams are the parameters of the ReconcileOrganizationHandler operation, parameters with a zero value are not sent
+type reconcileOrganizationParams struct {
+	// ID is the id path parameter: the id of the root organization
+	ID string
+	// XOpenlaneToken is the X-Openlane-Token header parameter: openlane bearer token or personal access token of the caller, used when the server forwards caller credentials to openlane
+	XOpenlaneToken string
+}
+
+// sendReconcileOrganization sends the request of the ReconcileOrganizationHandler operation, POST /organization/{id}/reconcile
+func sendReconcileOrganization(ctx context.Context, c *APIv1, params reconcileOrganizationParams, body *OrganizationReconcileRequest) (*OrganizationReconcileReply, error) {
+	opts := []httpsling.Option{httpsling.Post("/v1/organization/" + url.PathEscape(params.ID) + "/reconcile")}
+
+	if params.XOpenlaneToken != "" {
+		opts = append(opts, httpsling.Header("X-Openlane-Token", params.XOpenlaneToken))
+	}
+
+	if body != nil {
+		opts = append(opts, httpsling.Body(body))
+	}
+
+	out := new(OrganizationReconcileReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// updateOrganizationParams are the parameters of the UpdateOrganizationHandler operation, parameters with a zero value are not sent
+type updateOrganizationParams struct {
+	// ID is the id path parameter: the id of the root organization
+	ID string
+	// XOpenlaneToken is the X-Openlane-Token header parameter: openlane bearer token or personal access token of the caller, used when the server forwards caller credentials to openlane
+	XOpenlaneToken string
+}
+
+// sendUpdateOrganization sends the request of the UpdateOrganizationHandler operation, PATCH /organization/{id}
+func sendUpdateOrganization(ctx context.Context, c *APIv1, params updateOrganizationParams, body *OrganizationUpdateRequest) (*OrganizationUpdateReply, error) {
+	opts := []httpsling.Option{httpsling.Patch("/v1/organization/" + url.PathEscape(params.ID))}
+
+	if params.XOpenlaneToken != "" {
+		opts = append(opts, httpsling.Header("X-Openlane-Token", params.XOpenlaneToken))
+	}
+
+	if body != nil {
+		opts = append(opts, httpsling.Body(body))
+	}
+
+	out := new(OrganizationUpdateReply)
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
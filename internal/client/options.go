@@ -1,7 +1,10 @@
 package client
 
 import (
+	"crypto/tls"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/theopenlane/httpsling"
 )
@@ -19,3 +22,121 @@ func WithBaseURL(baseURL *url.URL) Option {
 		return c.Requester.Apply(httpsling.URL(baseURL.String()))
 	}
 }
+
+// WithTimeout sets the deadline for every request made by the APIv1 client, including any retries; there is no
+// deadline when it is zero
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *APIv1) error {
+		c.Config.Timeout = timeout
+
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used by the APIv1 client to retry requests that failed with a transient error, use
+// NoRetries to send every request once
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *APIv1) error {
+		c.Config.Retry = policy
+
+		return nil
+	}
+}
+
+// WithBearerToken sets the API token or JWT sent in the Authorization header of every request
+func WithBearerToken(token string) Option {
+	return func(c *APIv1) error {
+		c.Config.Token = token
+
+		return c.Requester.Apply(httpsling.BearerAuth(token))
+	}
+}
+
+// WithTokenSource sets the source of the bearer token sent in the Authorization header of every request, it replaces
+// any static bearer token; use a RefreshingTokenSource for tokens that expire
+func WithTokenSource(tokens TokenSource) Option {
+	return func(c *APIv1) error {
+		c.tokens = tokens
+
+		return c.Requester.Apply(httpsling.DeleteHeader(httpsling.HeaderAuthorization))
+	}
+}
+
+// WithAPIKey sets the API key sent in the header of every request, the DefaultAPIKeyHeader is used when the header is
+// empty
+func WithAPIKey(header, key string) Option {
+	return func(c *APIv1) error {
+		if header == "" {
+			header = DefaultAPIKeyHeader
+		}
+
+		c.Config.APIKeyHeader = header
+		c.Config.APIKey = key
+
+		return c.Requester.Apply(httpsling.Header(header, key))
+	}
+}
+
+// WithOpenlaneToken sets the openlane credential of the caller sent in the X-Openlane-Token header of every request,
+// which the server uses to make requests to openlane as the caller when it forwards credentials
+func WithOpenlaneToken(token string) Option {
+	return func(c *APIv1) error {
+		c.Config.OpenlaneToken = token
+
+		return c.Requester.Apply(httpsling.Header(OpenlaneTokenHeader, token))
+	}
+}
+
+// WithHeader sets a header sent with every request
+func WithHeader(key, value string) Option {
+	return func(c *APIv1) error {
+		return c.Requester.Apply(httpsling.Header(key, value))
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *APIv1) error {
+		c.Config.UserAgent = userAgent
+
+		return c.Requester.Apply(httpsling.Header(httpsling.HeaderUserAgent, userAgent))
+	}
+}
+
+// WithHTTPClient sets the http client used to send every request, replacing the client built from the TLS configuration
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *APIv1) error {
+		return c.Requester.Apply(httpsling.WithDoer(client))
+	}
+}
+
+// WithTransport sets the transport used to send every request, replacing the client built from the TLS configuration
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *APIv1) error {
+		return c.Requester.Apply(httpsling.WithDoer(&http.Client{Transport: transport}))
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the server, for example to present a client certificate
+// for mutual TLS; it replaces the http client
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *APIv1) error {
+		return c.Requester.Apply(httpsling.WithDoer(tlsClient(cfg)))
+	}
+}
+
+// WithCABundle trusts the certificate authorities in the PEM bundle, in addition to the system certificate authorities,
+// to verify the server; it replaces the http client
+func WithCABundle(bundle []byte) Option {
+	return func(c *APIv1) error {
+		pool, err := certPool(bundle)
+		if err != nil {
+			return err
+		}
+
+		return c.Requester.Apply(httpsling.WithDoer(tlsClient(&tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		})))
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// OrganizationPages returns an iterator over every root organization visible to the caller, requesting pages of up to
+// pageSize organizations as the iteration progresses; iteration stops after the first error, which is yielded with an
+// empty organization
+func OrganizationPages(ctx context.Context, c Client, pageSize int) iter.Seq2[OrgDetails, error] {
+	return func(yield func(OrgDetails, error) bool) {
+		opts := ListOptions{Limit: pageSize}
+
+		for {
+			page, err := c.OrganizationList(ctx, opts)
+			if err != nil {
+				yield(OrgDetails{}, err)
+
+				return
+			}
+
+			for _, org := range page.Organizations {
+				if !yield(org, nil) {
+					return
+				}
+			}
+
+			if page.NextCursor == "" {
+				return
+			}
+
+			opts.Cursor = page.NextCursor
+		}
+	}
+}
+
+// ListAllOrganizations returns every root organization visible to the caller, requesting pages of up to pageSize
+// organizations
+func ListAllOrganizations(ctx context.Context, c Client, pageSize int) ([]OrgDetails, error) {
+	out := []OrgDetails{}
+
+	for org, err := range OrganizationPages(ctx, c, pageSize) {
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, org)
+	}
+
+	return out, nil
+}
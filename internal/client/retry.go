@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/theopenlane/httpsling"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header the server uses to replay, instead of repeat, a request that is retried
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// retryAfterHeader is the response header the server uses to ask for a delay before the request is retried
+	retryAfterHeader = "Retry-After"
+	// drainLimit is the maximum number of bytes read from a response that is discarded so the connection can be reused
+	drainLimit = 4096
+)
+
+// errBodyNotRewindable is returned when a request cannot be retried because its body cannot be read again
+var errBodyNotRewindable = errors.New("request body cannot be sent again")
+
+// idempotentPaths are the POST routes where the server replays the response of a request with an Idempotency-Key it has
+// already seen; POST requests to any other route are sent once
+var idempotentPaths = []string{"/v1/organization", "/v1/organizations/bulk"}
+
+// RetryPolicy controls how requests that failed with a transient error are retried; only requests that are safe to send
+// more than once are retried: GET, HEAD, OPTIONS, PUT, and DELETE requests, and POST requests to the routes that honor
+// an Idempotency-Key, which is added so the server can replay the response of an attempt that already succeeded
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the first attempt; requests are not retried when
+	// it is 1 or less
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts" koanf:"maxAttempts" default:"3"`
+	// BaseDelay is how long to wait before the first retry
+	BaseDelay time.Duration `json:"baseDelay" yaml:"baseDelay" koanf:"baseDelay" default:"500ms"`
+	// Multiplier is the factor each delay is multiplied by for the next retry
+	Multiplier float64 `json:"multiplier" yaml:"multiplier" koanf:"multiplier" default:"2"`
+	// Jitter is the fraction each delay is randomized by, so many clients do not retry at the same time
+	Jitter float64 `json:"jitter" yaml:"jitter" koanf:"jitter" default:"0.2"`
+	// MaxDelay is the longest wait between attempts; a request is not retried when the server asks to wait longer with
+	// the Retry-After header
+	MaxDelay time.Duration `json:"maxDelay" yaml:"maxDelay" koanf:"maxDelay" default:"10s"`
+}
+
+// NoRetries is a RetryPolicy that sends every request once
+var NoRetries = RetryPolicy{MaxAttempts: 1}
+
+// middleware returns the httpsling middleware that adds an Idempotency-Key to POST requests to the routes that honor
+// it and retries requests that failed with a transient error according to the policy
+func (p RetryPolicy) middleware() httpsling.Middleware {
+	return func(next httpsling.Doer) httpsling.Doer {
+		return httpsling.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			// the key is set once so every attempt of the request uses the same one
+			if acceptsIdempotencyKey(req) && req.Header.Get(IdempotencyKeyHeader) == "" {
+				req = req.Clone(req.Context())
+				req.Header.Set(IdempotencyKeyHeader, ulid.Make().String())
+			}
+
+			for attempt := 1; ; attempt++ {
+				resp, err := next.Do(req)
+
+				if attempt >= p.MaxAttempts || !idempotent(req) || !retryable(req, resp, err) {
+					return resp, err
+				}
+
+				delay, ok := p.delay(attempt, resp)
+				if !ok {
+					return resp, err
+				}
+
+				// there is no point waiting when the deadline passes before the next attempt is sent
+				if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < delay {
+					return resp, err
+				}
+
+				retry, rerr := rewind(req)
+				if rerr != nil {
+					return resp, err
+				}
+
+				if resp != nil {
+					drain(resp.Body)
+				}
+
+				if serr := sleep(req.Context(), delay); serr != nil {
+					return nil, serr
+				}
+
+				req = retry
+			}
+		})
+	}
+}
+
+// delay returns how long to wait before the attempt after the given one; the server's Retry-After is used when it is
+// longer than the backoff, and false is returned when it is longer than the maximum delay of the policy
+func (p RetryPolicy) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	backoff := &httpsling.ExponentialBackoff{
+		BaseDelay:  p.BaseDelay,
+		Multiplier: p.Multiplier,
+		Jitter:     p.Jitter,
+		MaxDelay:   p.MaxDelay,
+	}
+
+	d := backoff.Backoff(attempt)
+
+	if resp == nil {
+		return d, true
+	}
+
+	after, ok := retryAfter(resp.Header.Get(retryAfterHeader))
+	if !ok {
+		return d, true
+	}
+
+	if p.MaxDelay > 0 && after > p.MaxDelay {
+		return 0, false
+	}
+
+	return max(d, after), true
+}
+
+// idempotent returns true when the request can be sent more than once without changing the result
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return acceptsIdempotencyKey(req) && req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// acceptsIdempotencyKey returns true for POST requests to a route where the server honors the Idempotency-Key; the
+// base url may contain a path so the route is matched at the end of the request path
+func acceptsIdempotencyKey(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+
+	path := strings.TrimSuffix(req.URL.Path, "/")
+
+	return slices.ContainsFunc(idempotentPaths, func(p string) bool {
+		return strings.HasSuffix(path, p)
+	})
+}
+
+// retryable returns true when the request failed with an error that may succeed when the request is sent again: the
+// server could not be reached, or it was rate limited, overloaded, or could not reach openlane
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// a certificate that cannot be verified, or a token source that failed, will not succeed on the next attempt
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) || errors.Is(err, ErrTokenUnavailable) {
+			return false
+		}
+
+		// the request was canceled, or ran out of time, by the caller
+		return req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a number of seconds or a date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// rewind returns a copy of the request with a new body so it can be sent again
+func rewind(req *http.Request) (*http.Request, error) {
+	retry := *req
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return &retry, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errBodyNotRewindable
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	retry.Body = body
+
+	return &retry, nil
+}
+
+// drain reads the rest of a discarded response body, up to a limit, and closes it so the connection can be reused
+func drain(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, drainLimit))
+	_ = body.Close()
+}
+
+// sleep waits for the delay, or returns the error of the context when it is done first
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelBody releases the deadline of a request when the response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the response body and releases the deadline
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
+
+// timeoutMiddleware returns the httpsling middleware that sets a deadline on every request, including any retries;
+// an earlier deadline of the request context is kept, and there is no deadline when the timeout is not positive
+func timeoutMiddleware(timeout time.Duration) httpsling.Middleware {
+	return func(next httpsling.Doer) httpsling.Doer {
+		return httpsling.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if timeout <= 0 {
+				return next.Do(req)
+			}
+
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+
+			resp, err := next.Do(req.WithContext(ctx))
+			if err != nil || resp == nil || resp.Body == nil {
+				cancel()
+
+				return resp, err
+			}
+
+			// the body is read after the middleware returns so the deadline must outlive it
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+			return resp, nil
+		})
+	}
+}
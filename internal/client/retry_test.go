@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// flakyServer fails the first failures requests with the status and records the requests it receives
+type flakyServer struct {
+	mu         sync.Mutex
+	failures   int
+	status     int
+	retryAfter string
+	delay      time.Duration
+	requests   []*http.Request
+}
+
+// ServeHTTP implements http.Handler
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	fail := len(s.requests) <= s.failures
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if fail {
+		if s.retryAfter != "" {
+			w.Header().Set(retryAfterHeader, s.retryAfter)
+		}
+
+		w.WriteHeader(s.status)
+		_ = json.NewEncoder(w).Encode(rout.ErrorResponse("try again"))
+
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(models.OrganizationReply{Reply: rout.Reply{Success: true}, OrgDetails: models.OrgDetails{ID: "org"}})
+}
+
+// attempts returns the number of requests received
+func (s *flakyServer) attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.requests)
+}
+
+// newFlakyClient returns a client for the server that retries without waiting
+func newFlakyClient(t *testing.T, s *flakyServer, opts ...Option) Client {
+	t.Helper()
+
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+
+	c, err := New(NewDefaultConfig(), append([]Option{WithBaseURL(baseURL), WithRetryPolicy(policy)}, opts...)...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get is retried", func(t *testing.T) {
+		s := &flakyServer{failures: 2, status: http.StatusServiceUnavailable}
+
+		out, err := newFlakyClient(t, s).OrganizationGet(ctx, "org")
+		require.NoError(t, err)
+		assert.Equal(t, "org", out.ID)
+		assert.Equal(t, 3, s.attempts())
+	})
+
+	t.Run("attempts are limited", func(t *testing.T) {
+		s := &flakyServer{failures: 3, status: http.StatusBadGateway}
+
+		_, err := newFlakyClient(t, s).OrganizationGet(ctx, "org")
+		require.ErrorIs(t, err, ErrUpstream)
+		assert.Equal(t, 3, s.attempts())
+	})
+
+	t.Run("post is retried with the same idempotency key", func(t *testing.T) {
+		s := &flakyServer{failures: 1, status: http.StatusTooManyRequests, retryAfter: "0"}
+
+		_, err := newFlakyClient(t, s).OrganizationCreate(ctx, &OrganizationRequest{Name: "MITB Inc."})
+		require.NoError(t, err)
+		require.Equal(t, 2, s.attempts())
+
+		key := s.requests[0].Header.Get(IdempotencyKeyHeader)
+		assert.NotEmpty(t, key)
+		assert.Equal(t, key, s.requests[1].Header.Get(IdempotencyKeyHeader))
+	})
+
+	t.Run("bulk post is retried with the same idempotency key", func(t *testing.T) {
+		s := &flakyServer{failures: 1, status: http.StatusServiceUnavailable}
+
+		_, err := newFlakyClient(t, s).OrganizationBulkCreate(ctx, []OrganizationRequest{{Name: "MITB Inc."}})
+		require.NoError(t, err)
+		require.Equal(t, 2, s.attempts())
+
+		key := s.requests[0].Header.Get(IdempotencyKeyHeader)
+		assert.NotEmpty(t, key)
+		assert.Equal(t, key, s.requests[1].Header.Get(IdempotencyKeyHeader))
+	})
+
+	t.Run("post without idempotency is not retried", func(t *testing.T) {
+		s := &flakyServer{failures: 1, status: http.StatusBadGateway}
+
+		_, err := newFlakyClient(t, s).OrganizationReconcile(ctx, "org", &OrganizationReconcileRequest{Repair: true})
+		require.ErrorIs(t, err, ErrUpstream)
+		assert.Equal(t, 1, s.attempts())
+
+		// the server ignores the key on reconcile so it is not sent
+		assert.Empty(t, s.requests[0].Header.Get(IdempotencyKeyHeader))
+	})
+
+	t.Run("patch is not retried", func(t *testing.T) {
+		s := &flakyServer{failures: 1, status: http.StatusBadGateway}
+
+		_, err := newFlakyClient(t, s).OrganizationUpdate(ctx, "org", &OrganizationUpdateRequest{Environments: []string{"staging"}})
+		require.ErrorIs(t, err, ErrUpstream)
+		assert.Equal(t, 1, s.attempts())
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		s := &flakyServer{failures: 1, status: http.StatusBadRequest}
+
+		_, err := newFlakyClient(t, s).OrganizationGet(ctx, "org")
+		require.ErrorIs(t, err, ErrInvalidInput)
+		assert.Equal(t, 1, s.attempts())
+	})
+
+	t.Run("retry after longer than the maximum delay", func(t *testing.T) {
+		s := &flakyServer{failures: 1, status: http.StatusTooManyRequests, retryAfter: "60"}
+
+		_, err := newFlakyClient(t, s).OrganizationGet(ctx, "org")
+		require.ErrorIs(t, err, ErrRateLimited)
+		assert.Equal(t, 1, s.attempts())
+
+		var rerr *RequestError
+		require.ErrorAs(t, err, &rerr)
+		assert.Equal(t, time.Minute, rerr.RetryAfter)
+	})
+
+	t.Run("no retries", func(t *testing.T) {
+		s := &flakyServer{failures: 1, status: http.StatusServiceUnavailable}
+
+		_, err := newFlakyClient(t, s, WithRetryPolicy(NoRetries)).OrganizationGet(ctx, "org")
+		require.ErrorIs(t, err, ErrUnavailable)
+		assert.Equal(t, 1, s.attempts())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		s := &flakyServer{delay: 100 * time.Millisecond}
+
+		_, err := newFlakyClient(t, s, WithTimeout(10*time.Millisecond)).OrganizationGet(ctx, "org")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, s.attempts())
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, Multiplier: 2, MaxDelay: 5 * time.Second}
+
+	header := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{retryAfterHeader: []string{value}}}
+	}
+
+	tests := []struct {
+		name    string
+		attempt int
+		resp    *http.Response
+		delay   time.Duration
+		retry   bool
+	}{
+		{name: "first retry", attempt: 1, delay: time.Second, retry: true},
+		{name: "backoff", attempt: 3, delay: 4 * time.Second, retry: true},
+		{name: "maximum delay", attempt: 10, delay: 5 * time.Second, retry: true},
+		{name: "retry after seconds", attempt: 1, resp: header("3"), delay: 3 * time.Second, retry: true},
+		{name: "retry after shorter than backoff", attempt: 2, resp: header("1"), delay: 2 * time.Second, retry: true},
+		{name: "retry after too long", attempt: 1, resp: header("30"), retry: false},
+		{name: "retry after date too long", attempt: 1, resp: header(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)), retry: false},
+		{name: "invalid retry after", attempt: 1, resp: header("soon"), delay: time.Second, retry: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, retry := p.delay(tc.attempt, tc.resp)
+
+			assert.Equal(t, tc.retry, retry)
+			assert.Equal(t, tc.delay, delay)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/audit"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+func TestLog(t *testing.T) {
+	var stdout bytes.Buffer
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := audit.NewLog(audit.NewWriterSink(&stdout), audit.NewFileSink(path))
+
+	start := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, id := range []string{"1", "2", "3"} {
+		require.NoError(t, l.Record(models.AuditEntry{
+			Time:           start.Add(time.Duration(i) * time.Hour),
+			Action:         models.AuditActionCreate,
+			OrganizationID: id,
+			Created:        []string{id, id + "0"},
+			Outcome:        models.AuditOutcomeSuccess,
+		}))
+	}
+
+	// every sink receives the entries with an id assigned
+	lines := bytes.Split(bytes.TrimSpace(stdout.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+
+	var first models.AuditEntry
+	require.NoError(t, json.Unmarshal(lines[0], &first))
+	assert.NotEmpty(t, first.ID)
+
+	entries, err := l.Query(models.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, first.ID, entries[0].ID)
+
+	// the file is reopened and appended to by a new log
+	require.NoError(t, audit.NewLog(audit.NewFileSink(path)).Record(models.AuditEntry{
+		Time:           start.Add(3 * time.Hour),
+		Action:         models.AuditActionDelete,
+		OrganizationID: "1",
+		Deleted:        []string{"10", "1"},
+		Outcome:        models.AuditOutcomeSuccess,
+	}))
+
+	tests := []struct {
+		name   string
+		filter models.AuditFilter
+		orgs   []string
+	}{
+		{
+			name:   "all",
+			filter: models.AuditFilter{},
+			orgs:   []string{"1", "2", "3", "1"},
+		},
+		{
+			name:   "time range",
+			filter: models.AuditFilter{Since: start.Add(time.Hour), Until: start.Add(2 * time.Hour)},
+			orgs:   []string{"2", "3"},
+		},
+		{
+			name:   "created or deleted organization",
+			filter: models.AuditFilter{OrganizationID: "10"},
+			orgs:   []string{"1", "1"},
+		},
+		{
+			name:   "most recent entries are kept",
+			filter: models.AuditFilter{Limit: 2},
+			orgs:   []string{"3", "1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := l.Query(tc.filter)
+			require.NoError(t, err)
+
+			orgs := []string{}
+			for _, e := range entries {
+				orgs = append(orgs, e.OrganizationID)
+			}
+
+			assert.Equal(t, tc.orgs, orgs)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	l, err := audit.New(audit.Config{Sinks: []string{audit.SinkStdout}})
+	require.NoError(t, err)
+
+	_, err = l.Query(models.AuditFilter{})
+	assert.ErrorIs(t, err, audit.ErrNotQueryable)
+
+	_, err = audit.New(audit.Config{Sinks: []string{"syslog"}})
+	assert.ErrorIs(t, err, audit.ErrUnknownSink)
+
+	// the file does not exist until the first entry is recorded
+	l, err = audit.New(audit.Config{Sinks: []string{audit.SinkFile}, Path: filepath.Join(t.TempDir(), "audit.jsonl")})
+	require.NoError(t, err)
+
+	entries, err := l.Query(models.AuditFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
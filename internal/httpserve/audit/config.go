@@ -0,0 +1,18 @@
+package audit
+
+const (
+	// SinkFile appends entries to a JSON lines file which can be queried with the audit endpoint
+	SinkFile = "file"
+	// SinkStdout writes entries to stdout as JSON lines, e.g. to be collected with the container logs
+	SinkStdout = "stdout"
+)
+
+// Config is the configuration for the audit log
+type Config struct {
+	// Enabled turns on recording provisioning actions to the audit log
+	Enabled bool `json:"enabled" koanf:"enabled" default:"true"`
+	// Sinks are the destinations entries are written to, file and stdout
+	Sinks []string `json:"sinks" koanf:"sinks" default:"[file]"`
+	// Path is the location of the file used by the file sink
+	Path string `json:"path" koanf:"path" default:"audit.jsonl"`
+}
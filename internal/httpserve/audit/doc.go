@@ -0,0 +1,2 @@
+// Package audit records provisioning actions to an append-only audit log
+package audit
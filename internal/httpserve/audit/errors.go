@@ -0,0 +1,13 @@
+package audit
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnknownSink is returned when the configured sink type is not supported
+	ErrUnknownSink = errors.New("unknown audit sink")
+
+	// ErrNotQueryable is returned when the audit log is queried without a file sink
+	ErrNotQueryable = errors.New("the audit log can only be queried when the file sink is enabled")
+)
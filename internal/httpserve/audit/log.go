@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// Log records audit entries to every sink and queries the first sink that can be read
+type Log struct {
+	sinks  []Sink
+	reader Reader
+}
+
+// New returns the audit log with the sinks configured by the config
+func New(c Config) (*Log, error) {
+	sinks := make([]Sink, 0, len(c.Sinks))
+
+	for _, s := range c.Sinks {
+		switch s {
+		case SinkFile:
+			sinks = append(sinks, NewFileSink(c.Path))
+		case SinkStdout:
+			sinks = append(sinks, NewStdoutSink())
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSink, s)
+		}
+	}
+
+	return NewLog(sinks...), nil
+}
+
+// NewLog returns an audit log that writes to the sinks
+func NewLog(sinks ...Sink) *Log {
+	l := &Log{sinks: sinks}
+
+	for _, s := range sinks {
+		if r, ok := s.(Reader); ok {
+			l.reader = r
+
+			break
+		}
+	}
+
+	return l
+}
+
+// Record assigns the entry an id and time, when they are not set, and writes it to every sink; every sink is written
+// to even when an earlier sink fails
+func (l *Log) Record(e models.AuditEntry) error {
+	if e.ID == "" {
+		e.ID = ulid.Make().String()
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	var errs []error
+
+	for _, s := range l.sinks {
+		if err := s.Write(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Query returns the entries matching the filter, oldest first
+func (l *Log) Query(f models.AuditFilter) ([]models.AuditEntry, error) {
+	if l.reader == nil {
+		return nil, ErrNotQueryable
+	}
+
+	return l.reader.Read(f)
+}
@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+const (
+	// ownerReadWrite is the file mode of the audit log, which contains the input of every request
+	ownerReadWrite = 0600
+	// maxLineSize is the largest entry that can be read back from the audit log
+	maxLineSize = 4 << 20
+)
+
+// Sink is a destination for audit entries
+type Sink interface {
+	// Write appends the entry to the sink
+	Write(e models.AuditEntry) error
+}
+
+// Reader is a sink that entries can be read back from
+type Reader interface {
+	// Read returns the entries matching the filter, oldest first
+	Read(f models.AuditFilter) ([]models.AuditEntry, error)
+}
+
+// FileSink appends entries to a JSON lines file, the file is only ever appended to
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// Ensure FileSink implements the Sink and Reader interfaces
+var (
+	_ Sink   = &FileSink{}
+	_ Reader = &FileSink{}
+)
+
+// NewFileSink returns a sink that appends to the file at path, the file is created on the first write
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Write appends the entry to the file
+func (s *FileSink) Write(e models.AuditEntry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, ownerReadWrite)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+// Read scans the file and returns the most recent entries matching the filter, up to the limit
+func (s *FileSink) Read(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []models.AuditEntry{}
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxLineSize)
+
+	for scanner.Scan() {
+		var e models.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, err
+		}
+
+		if !filter.Match(e) {
+			continue
+		}
+
+		out = append(out, e)
+
+		if filter.Limit > 0 && len(out) > filter.Limit {
+			out = out[1:]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// WriterSink writes entries to a writer as JSON lines
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Ensure WriterSink implements the Sink interface
+var _ Sink = &WriterSink{}
+
+// NewWriterSink returns a sink that writes to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// NewStdoutSink returns a sink that writes to stdout
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink(os.Stdout)
+}
+
+// Write writes the entry to the writer
+func (s *WriterSink) Write(e models.AuditEntry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(line, '\n'))
+
+	return err
+}
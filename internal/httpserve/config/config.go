@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
 	"time"
@@ -38,12 +39,17 @@ type Config struct {
 	Routes []http.Handler
 	// DefaultMiddleware to enable on the echo server used on all requests
 	DefaultMiddleware []echo.MiddlewareFunc
+	// AuthMiddleware to enable on the echo server used on authenticated requests
+	AuthMiddleware []echo.MiddlewareFunc
 	// GraphMiddleware to enable on the echo server used on graph requests
 	GraphMiddleware []echo.MiddlewareFunc
 	// Handler contains the required settings for REST handlers including ready checks and JWT keys
 	Handler handlers.Handler
 	// SessionConfig manages sessions for users
 	SessionConfig *sessions.SessionConfig
+	// Shutdown contains the functions that release resources once the server has stopped serving requests, they must
+	// finish before the context is done
+	Shutdown []func(context.Context) error
 }
 
 // Ensure that *Config implements ConfigProvider interface.
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+var (
+	// ErrAuditDisabled is returned when the audit log is queried but it is not enabled
+	ErrAuditDisabled = errors.New("the audit log is not enabled")
+
+	// ErrInvalidAuditFilter is returned when the audit log is queried with an invalid filter
+	ErrInvalidAuditFilter = errors.New("invalid audit filter")
+)
+
+// requestIDKey is the context key for the id of the request
+type requestIDKey struct{}
+
+// withRequestID returns a copy of the context containing the request id so actions that complete in the background are
+// recorded against the request that started them
+func withRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// audit records the outcome of a provisioning action, along with the caller and request id from the context, when the
+// audit log is enabled; the audit log does not change the response so a failure to record the entry is logged
+func (h *Handler) audit(ctx context.Context, entry models.AuditEntry, input any, cause error) {
+	if h.Audit == nil {
+		return
+	}
+
+	entry.RequestID, _ = ctx.Value(requestIDKey{}).(string)
+
+	if caller, ok := auth.CallerFromContext(ctx); ok {
+		entry.Caller = caller.Subject
+		entry.AuthMethod = string(caller.Method)
+	}
+
+	if input != nil {
+		data, err := json.Marshal(input)
+		if err != nil {
+			log.Error().Err(err).Str("action", entry.Action).Msg("failed to encode audit input")
+		}
+
+		entry.Input = data
+	}
+
+	entry.Outcome = models.AuditOutcomeSuccess
+
+	if cause != nil {
+		entry.Outcome = models.AuditOutcomeFailure
+		entry.Error = cause.Error()
+	}
+
+	if err := h.Audit.Record(entry); err != nil {
+		log.Error().Err(err).Str("action", entry.Action).Str("id", entry.OrganizationID).Msg("failed to record audit entry")
+	}
+}
+
+// organizationIDs returns the ids of the organizations
+func organizationIDs(orgs []models.OrgDetails) []string {
+	if len(orgs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(orgs))
+	for _, o := range orgs {
+		ids = append(ids, o.ID)
+	}
+
+	return ids
+}
+
+// AuditHandler is the handler for querying the audit log of provisioning actions; only the entries recorded for the
+// caller of the request are returned so one tenant cannot read the actions of another
+func (h *Handler) AuditHandler(ctx echo.Context) error {
+	if h.Audit == nil {
+		return h.NotFound(ctx, ErrAuditDisabled)
+	}
+
+	filter, err := auditFilter(ctx)
+	if err != nil {
+		return h.BadRequest(ctx, err)
+	}
+
+	if caller, ok := auth.CallerFromContext(ctx.Request().Context()); ok {
+		filter.Caller = caller.Subject
+		filter.AuthMethod = string(caller.Method)
+	}
+
+	entries, err := h.Audit.Query(filter)
+	if err != nil {
+		return h.InternalServerError(ctx, err)
+	}
+
+	return h.Success(ctx, models.AuditReply{
+		Reply:   rout.Reply{Success: true},
+		Entries: entries,
+	})
+}
+
+// auditFilter returns the filter for the audit log from the query parameters of the request
+func auditFilter(ctx echo.Context) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		OrganizationID: ctx.QueryParam("organization"),
+		Limit:          models.DefaultAuditLimit,
+	}
+
+	times := []struct {
+		param string
+		t     *time.Time
+	}{{"since", &filter.Since}, {"until", &filter.Until}}
+
+	for _, p := range times {
+		value := ctx.QueryParam(p.param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s must be an RFC 3339 time", ErrInvalidAuditFilter, p.param)
+		}
+
+		*p.t = parsed
+	}
+
+	if value := ctx.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > models.MaxAuditLimit {
+			return filter, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidAuditFilter, models.MaxAuditLimit)
+		}
+
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// BindAuditHandler is used to bind the audit log endpoint to the OpenAPI schema
+func (h *Handler) BindAuditHandler() *openapi3.Operation {
+	audit := openapi3.NewOperation()
+	audit.Description = "Audit returns the entries of the audit log of provisioning actions recorded for the caller, oldest first"
+	audit.OperationID = "AuditHandler"
+	audit.Security = authenticated()
+
+	audit.AddParameter(openapi3.NewQueryParameter("since").
+		WithDescription("only return entries recorded at or after the RFC 3339 time").
+		WithSchema(openapi3.NewDateTimeSchema()))
+	audit.AddParameter(openapi3.NewQueryParameter("until").
+		WithDescription("only return entries recorded at or before the RFC 3339 time").
+		WithSchema(openapi3.NewDateTimeSchema()))
+	audit.AddParameter(openapi3.NewQueryParameter("organization").
+		WithDescription("only return entries for the hierarchy with the root organization, or that created or deleted the organization").
+		WithSchema(openapi3.NewStringSchema()))
+	audit.AddParameter(openapi3.NewQueryParameter("limit").
+		WithDescription("the maximum number of entries to return, the most recent entries are returned").
+		WithSchema(openapi3.NewIntegerSchema().WithMin(1).WithMax(models.MaxAuditLimit).WithDefault(models.DefaultAuditLimit)))
+
+	h.AddResponse("AuditReply", "success", models.ExampleAuditResponse, audit, http.StatusOK)
+	audit.AddResponse(http.StatusInternalServerError, internalServerError())
+	audit.AddResponse(http.StatusUnauthorized, unauthorized())
+	audit.AddResponse(http.StatusBadRequest, badRequest())
+	audit.AddResponse(http.StatusNotFound, notFound())
+
+	return audit
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/audit"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// newAuditContext returns a test context for a request made by the ci caller with the request id
+func newAuditContext(t *testing.T, method, target, body, requestID string) (echo.Context, func() []byte) {
+	t.Helper()
+
+	return newCallerContext(t, "ci", method, target, body, requestID)
+}
+
+// newCallerContext returns a test context for a request made by the API token caller with the request id
+func newCallerContext(t *testing.T, caller, method, target, body, requestID string) (echo.Context, func() []byte) {
+	t.Helper()
+
+	return newAuthContext(t, caller, auth.MethodAPIToken, method, target, body, requestID)
+}
+
+// newAuthContext returns a test context for a request made by the caller authenticated with the auth method with the
+// request id
+func newAuthContext(t *testing.T, caller string, authMethod auth.Method, method, target, body, requestID string) (echo.Context, func() []byte) {
+	t.Helper()
+
+	ctx, rec := newTestContext(t, method, target, body)
+	ctx.SetRequest(ctx.Request().WithContext(auth.WithCaller(ctx.Request().Context(), &auth.Caller{Subject: caller, Method: authMethod})))
+	ctx.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+	return ctx, rec.Body.Bytes
+}
+
+func TestOrganizationAudit(t *testing.T) {
+	f := newFakeOpenlane()
+	f.failGroup["support"] = errUpstream
+
+	h := newTestHandler(f)
+	h.Audit = audit.NewLog(audit.NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl")))
+
+	ctx, body := newAuditContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","environments":["production"],"buckets":["assets"]}`, "req-1")
+	require.NoError(t, h.OrganizationHandler(ctx))
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(body(), &created))
+
+	// a dry run is not recorded
+	ctx, _ = newAuditContext(t, http.MethodPost, "/v1/organization", `{"name":"Meow Corp","dryRun":true}`, "req-2")
+	require.NoError(t, h.OrganizationHandler(ctx))
+
+	// a failure is recorded with the organizations that were rolled back
+	ctx, _ = newAuditContext(t, http.MethodPost, "/v1/organization", `{"name":"Meow Corp","environments":["production"],"buckets":[],"groups":[{"name":"support"}]}`, "req-3")
+	require.Error(t, h.OrganizationHandler(ctx))
+
+	ctx, _ = newAuditContext(t, http.MethodDelete, "/v1/organization/"+created.ID, "", "req-4")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+	require.NoError(t, h.DeleteOrganizationHandler(ctx))
+
+	ctx, body = newAuditContext(t, http.MethodGet, "/v1/audit", "", "req-5")
+	require.NoError(t, h.AuditHandler(ctx))
+
+	var out models.AuditReply
+	require.NoError(t, json.Unmarshal(body(), &out))
+	require.Len(t, out.Entries, 3)
+
+	create, failed, deleted := out.Entries[0], out.Entries[1], out.Entries[2]
+
+	assert.Equal(t, models.AuditActionCreate, create.Action)
+	assert.Equal(t, models.AuditOutcomeSuccess, create.Outcome)
+	assert.Equal(t, "req-1", create.RequestID)
+	assert.Equal(t, "ci", create.Caller)
+	assert.Equal(t, string(auth.MethodAPIToken), create.AuthMethod)
+	assert.Equal(t, created.ID, create.OrganizationID)
+	assert.Len(t, create.Created, 3)
+	assert.Contains(t, string(create.Input), `"name":"MITB Inc."`)
+
+	assert.Equal(t, models.AuditOutcomeFailure, failed.Outcome)
+	assert.Equal(t, "req-3", failed.RequestID)
+	assert.Len(t, failed.Created, 2)
+	assert.ElementsMatch(t, failed.Created, failed.Deleted)
+	assert.Equal(t, errUpstream.Error(), failed.Error)
+
+	assert.Equal(t, models.AuditActionDelete, deleted.Action)
+	assert.Equal(t, "req-4", deleted.RequestID)
+	assert.ElementsMatch(t, create.Created, deleted.Deleted)
+
+	// entries are filtered by organization and time
+	ctx, body = newAuditContext(t, http.MethodGet, "/v1/audit?organization="+create.Created[1], "", "req-6")
+	require.NoError(t, h.AuditHandler(ctx))
+
+	out = models.AuditReply{}
+	require.NoError(t, json.Unmarshal(body(), &out))
+	require.Len(t, out.Entries, 2)
+	assert.Equal(t, create.ID, out.Entries[0].ID)
+	assert.Equal(t, deleted.ID, out.Entries[1].ID)
+
+	ctx, body = newAuditContext(t, http.MethodGet, "/v1/audit?since="+time.Now().Add(time.Hour).Format(time.RFC3339), "", "req-7")
+	require.NoError(t, h.AuditHandler(ctx))
+
+	out = models.AuditReply{}
+	require.NoError(t, json.Unmarshal(body(), &out))
+	assert.Empty(t, out.Entries)
+}
+
+func TestAuditHandlerCaller(t *testing.T) {
+	f := newFakeOpenlane()
+
+	h := newTestHandler(f)
+	h.Audit = audit.NewLog(audit.NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl")))
+
+	ctx, body := newCallerContext(t, "meow", http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","environments":["production"]}`, "req-1")
+	require.NoError(t, h.OrganizationHandler(ctx))
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(body(), &created))
+
+	ctx, _ = newCallerContext(t, "woof", http.MethodPost, "/v1/organization", `{"name":"Meow Corp","environments":["production"]}`, "req-2")
+	require.NoError(t, h.OrganizationHandler(ctx))
+
+	// every caller only sees their own entries, even when filtering by an organization created by another caller
+	for _, target := range []string{"/v1/audit", "/v1/audit?organization=" + created.ID} {
+		ctx, body = newCallerContext(t, "woof", http.MethodGet, target, "", "req-3")
+		require.NoError(t, h.AuditHandler(ctx))
+
+		var out models.AuditReply
+		require.NoError(t, json.Unmarshal(body(), &out))
+
+		for _, e := range out.Entries {
+			assert.Equal(t, "woof", e.Caller, target)
+			assert.NotEqual(t, created.ID, e.OrganizationID, target)
+		}
+	}
+
+	ctx, body = newCallerContext(t, "meow", http.MethodGet, "/v1/audit", "", "req-4")
+	require.NoError(t, h.AuditHandler(ctx))
+
+	var out models.AuditReply
+	require.NoError(t, json.Unmarshal(body(), &out))
+	require.Len(t, out.Entries, 1)
+	assert.Equal(t, created.ID, out.Entries[0].OrganizationID)
+
+	// entries recorded for an authenticated caller are not returned to requests without one
+	ctx, rec := newTestContext(t, http.MethodGet, "/v1/audit", "")
+	require.NoError(t, h.AuditHandler(ctx))
+
+	out = models.AuditReply{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	assert.Empty(t, out.Entries)
+}
+
+func TestAuditHandlerAuthMethod(t *testing.T) {
+	f := newFakeOpenlane()
+
+	h := newTestHandler(f)
+	h.Audit = audit.NewLog(audit.NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl")))
+
+	// an API token and a JWT with the same subject are different callers
+	names := map[auth.Method]string{
+		auth.MethodAPIToken: "MITB Inc.",
+		auth.MethodJWT:      "Meow Corp",
+	}
+
+	ids := map[auth.Method]string{}
+
+	for authMethod, name := range names {
+		ctx, body := newAuthContext(t, "ci", authMethod, http.MethodPost, "/v1/organization", `{"name":"`+name+`","environments":["production"]}`, "req-"+string(authMethod))
+		require.NoError(t, h.OrganizationHandler(ctx))
+
+		var created models.OrganizationReply
+		require.NoError(t, json.Unmarshal(body(), &created))
+
+		ids[authMethod] = created.ID
+	}
+
+	for authMethod, id := range ids {
+		ctx, body := newAuthContext(t, "ci", authMethod, http.MethodGet, "/v1/audit", "", "req-audit")
+		require.NoError(t, h.AuditHandler(ctx))
+
+		var out models.AuditReply
+		require.NoError(t, json.Unmarshal(body(), &out))
+		require.Len(t, out.Entries, 1, authMethod)
+		assert.Equal(t, id, out.Entries[0].OrganizationID, authMethod)
+		assert.Equal(t, string(authMethod), out.Entries[0].AuthMethod, authMethod)
+	}
+}
+
+func TestAuditHandlerInvalid(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+
+	ctx, rec := newTestContext(t, http.MethodGet, "/v1/audit", "")
+	require.ErrorIs(t, h.AuditHandler(ctx), ErrAuditDisabled)
+	requireStatus(t, rec, http.StatusNotFound)
+
+	h.Audit = audit.NewLog(audit.NewStdoutSink())
+
+	for _, query := range []string{"since=yesterday", "limit=0", "limit=1001"} {
+		ctx, rec = newTestContext(t, http.MethodGet, "/v1/audit?"+query, "")
+		require.ErrorIs(t, h.AuditHandler(ctx), ErrInvalidAuditFilter)
+		requireStatus(t, rec, http.StatusBadRequest)
+	}
+
+	// the stdout sink cannot be queried
+	ctx, rec = newTestContext(t, http.MethodGet, "/v1/audit", "")
+	require.ErrorIs(t, h.AuditHandler(ctx), audit.ErrNotQueryable)
+	requireStatus(t, rec, http.StatusInternalServerError)
+}
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+const (
+	// MIMETextCSV is the content type of a csv file of organizations
+	MIMETextCSV = "text/csv"
+
+	// bulkFileField is the multipart form field containing the csv or json file of organizations
+	bulkFileField = "file"
+)
+
+// BulkOrganizationHandler is the handler for creating many organization hierarchies in a single request; every
+// organization is validated before any are created, then each valid organization is provisioned with bounded concurrency
+// and rolled back on its own if it fails, so one failure does not affect the others. The organizations are provisioned
+// by a background job so large requests are not limited by the server write timeout, the job is returned to be polled
+// for the result of each organization
+func (h *Handler) BulkOrganizationHandler(ctx echo.Context) error {
+	in, err := bulkOrganizationRequests(ctx)
+	if err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	if len(in) == 0 {
+		return h.InvalidInput(ctx, rout.MissingField("organizations"))
+	}
+
+	if h.MaxBulkSize > 0 && len(in) > h.MaxBulkSize {
+		return h.InvalidInput(ctx, &models.ValidationError{Fields: []models.FieldError{{
+			Field:   "organizations",
+			Message: fmt.Sprintf("the request contains %d organizations, the maximum is %d", len(in), h.MaxBulkSize),
+		}}})
+	}
+
+	reqCtx, err := h.requestContext(ctx)
+	if err != nil {
+		return h.Unauthorized(ctx, err)
+	}
+
+	log.Debug().Int("organizations", len(in)).Msg("creating organizations in bulk")
+
+	return h.withIdempotency(ctx, in, func() error {
+		return h.submitBulkJob(ctx, reqCtx, in)
+	})
+}
+
+// submitBulkJob queues the bulk request to be processed in the background using the request context and returns the job
+func (h *Handler) submitBulkJob(ctx echo.Context, reqCtx context.Context, in []models.OrganizationRequest) error {
+	if h.Jobs == nil {
+		return h.BadRequest(ctx, ErrAsyncDisabled)
+	}
+
+	results, valid := validateOrganizations(in)
+
+	job, err := h.Jobs.SubmitBulk(reqCtx, h.bulkTotal(in, valid), func(jobCtx context.Context, progress func(models.OrgDetails), failed func(*ProvisioningError)) models.BulkOrganizationReply {
+		return h.provisionOrganizations(jobCtx, in, results, valid, progress, failed)
+	})
+	if err != nil {
+		return h.TooManyRequests(ctx, err)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderLocation, "/v1/organization/jobs/"+job.ID)
+
+	return h.Accepted(ctx, models.ProvisioningJobReply{
+		Reply: rout.Reply{Success: true},
+		Job:   job,
+	})
+}
+
+// bulkTotal returns the number of organizations expected to be created for the valid organizations, including the root
+// of each hierarchy; dry runs and organizations without a valid template are not created
+func (h *Handler) bulkTotal(in []models.OrganizationRequest, valid []bool) int {
+	total := 0
+
+	for i := range in {
+		if !valid[i] || in[i].DryRun {
+			continue
+		}
+
+		bp, err := h.resolveOrganizationBlueprint(in[i])
+		if err != nil {
+			continue
+		}
+
+		total += 1 + bp.Count()
+	}
+
+	return total
+}
+
+// bulkOrganizationRequests reads the organizations from the request body, which is either a json array of organization
+// requests, a csv file, or a multipart form with a json or csv file in the file field
+func bulkOrganizationRequests(ctx echo.Context) ([]models.OrganizationRequest, error) {
+	mediaType, _, _ := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+
+	switch mediaType {
+	case MIMETextCSV:
+		return models.ParseOrganizationsCSV(ctx.Request().Body)
+	case echo.MIMEMultipartForm:
+		fh, err := ctx.FormFile(bulkFileField)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := fh.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		if !strings.EqualFold(filepath.Ext(fh.Filename), ".json") {
+			return models.ParseOrganizationsCSV(f)
+		}
+
+		var in []models.OrganizationRequest
+		if err := json.NewDecoder(f).Decode(&in); err != nil {
+			return nil, err
+		}
+
+		return in, nil
+	default:
+		var in []models.OrganizationRequest
+		if err := echo.BindBody(ctx, &in); err != nil {
+			return nil, err
+		}
+
+		return in, nil
+	}
+}
+
+// validateOrganizations validates every organization, rejecting names used more than once in the request, and returns
+// the result of each organization that failed validation along with which organizations are valid
+func validateOrganizations(in []models.OrganizationRequest) ([]models.BulkOrganizationResult, []bool) {
+	results := make([]models.BulkOrganizationResult, len(in))
+	valid := make([]bool, len(in))
+	// names contains the index of the first organization using each name
+	names := map[string]int{}
+
+	for i := range in {
+		results[i] = models.BulkOrganizationResult{Index: i, Name: in[i].Name}
+
+		if err := in[i].Validate(); err != nil {
+			results[i] = bulkFailure(results[i], err)
+
+			continue
+		}
+
+		name := strings.ToLower(in[i].Name)
+		if first, ok := names[name]; ok {
+			results[i] = bulkFailure(results[i], &models.ValidationError{Fields: []models.FieldError{{
+				Field:   "name",
+				Message: fmt.Sprintf("%q is already used by organization %d in the request", in[i].Name, first),
+			}}})
+
+			continue
+		}
+
+		names[name] = i
+		valid[i] = true
+	}
+
+	return results, valid
+}
+
+// provisionOrganizations creates the valid organizations, up to the bulk concurrency at a time; the results are in the
+// same order as the request. The optional progress function is called for every organization created and the optional
+// failed function for every hierarchy that was rolled back
+func (h *Handler) provisionOrganizations(ctx context.Context, in []models.OrganizationRequest, results []models.BulkOrganizationResult,
+	valid []bool, progress func(models.OrgDetails), failed func(*ProvisioningError)) models.BulkOrganizationReply {
+	var g errgroup.Group
+
+	g.SetLimit(max(h.BulkConcurrency, 1))
+
+	for i := range in {
+		if !valid[i] {
+			continue
+		}
+
+		g.Go(func() error {
+			results[i] = h.provisionBulkOrganization(ctx, results[i], in[i], progress, failed)
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	out := models.BulkOrganizationReply{
+		Total:   len(in),
+		Results: results,
+	}
+
+	for _, r := range results {
+		if r.Success {
+			out.Succeeded++
+		} else {
+			out.Failed++
+		}
+	}
+
+	out.Success = out.Failed == 0
+
+	return out
+}
+
+// provisionBulkOrganization creates, or plans for a dry run, a single validated organization from a bulk request
+func (h *Handler) provisionBulkOrganization(ctx context.Context, result models.BulkOrganizationResult, in models.OrganizationRequest,
+	progress func(models.OrgDetails), failed func(*ProvisioningError)) models.BulkOrganizationResult {
+	bp, err := h.resolveOrganizationBlueprint(in)
+	if err != nil {
+		return bulkFailure(result, err)
+	}
+
+	if err := validateSize("template", bp); err != nil {
+		return bulkFailure(result, err)
+	}
+
+	if err := validateGroups(bp, in.Groups); err != nil {
+		return bulkFailure(result, err)
+	}
+
+	result.Reply = rout.Reply{Success: true}
+	result.Status = http.StatusOK
+
+	if in.DryRun {
+		result.Plan, err = h.planOrganization(ctx, in, bp)
+		if err != nil {
+			return bulkFailure(result, err)
+		}
+
+		return result
+	}
+
+	result.Organization, err = h.provisionOrganization(ctx, in, bp, progress)
+	if err != nil {
+		var perr *ProvisioningError
+		if failed != nil && errors.As(err, &perr) {
+			failed(perr)
+		}
+
+		return bulkFailure(result, err)
+	}
+
+	return result
+}
+
+// bulkFailure records the error on the result with the status and error code the organization would have received
+// if it was created on its own
+func bulkFailure(result models.BulkOrganizationResult, err error) models.BulkOrganizationResult {
+	result.Reply = rout.ErrorResponse(err)
+	result.Status = http.StatusBadRequest
+
+	var verr *models.ValidationError
+	if errors.As(err, &verr) {
+		reply := verr.Reply(InvalidInputErrCode)
+
+		result.Reply = reply.Reply
+		result.Fields = reply.Fields
+
+		return result
+	}
+
+	var perr *ProvisioningError
+	if errors.As(err, &perr) {
+		result.Status = http.StatusUnprocessableEntity
+		result.Orphaned = perr.Orphaned
+	}
+
+	var oerr *OpenlaneError
+	if errors.As(openlaneError(err), &oerr) {
+		result.Status = oerr.StatusCode
+		result.ErrorCode = oerr.Code
+	}
+
+	return result
+}
+
+// BindBulkOrganizationHandler is used to bind the bulk organization endpoint to the OpenAPI schema
+func (h *Handler) BindBulkOrganizationHandler() *openapi3.Operation {
+	bulk := openapi3.NewOperation()
+	bulk.Description = "BulkOrganization creates an opinionated organization hierarchy for every organization in the request, from a json array or a csv file, in a background job; the job contains the result for each once it has finished"
+	bulk.OperationID = "BulkOrganizationHandler"
+	bulk.Security = authenticated()
+
+	bulk.AddParameter(openapi3.NewHeaderParameter(IdempotencyKeyHeader).
+		WithDescription("unique key for the request, retries with the same key replay the original response instead of creating new hierarchies").
+		WithSchema(openapi3.NewStringSchema()))
+
+	h.AddRequestBody("BulkOrganizationRequest", models.ExampleBulkOrganizationRequest, bulk)
+	bulk.RequestBody.Value.Content[MIMETextCSV] = openapi3.NewMediaType().
+		WithSchema(openapi3.NewStringSchema()).
+		WithExample("success", "name,template,domains\nMITB Inc.,,mitb.com;meow.com\nMeow Corp,retail,\n")
+	bulk.RequestBody.Value.Content[echo.MIMEMultipartForm] = openapi3.NewMediaType().
+		WithSchema(openapi3.NewObjectSchema().WithProperty(bulkFileField, openapi3.NewStringSchema().WithFormat("binary")))
+
+	h.AddResponse("ProvisioningJobReply", "the bulk provisioning job was queued, poll the job for the result of every organization", models.ExampleProvisioningJobResponse, bulk, http.StatusAccepted)
+	bulk.AddResponse(http.StatusInternalServerError, internalServerError())
+	bulk.AddResponse(http.StatusUnauthorized, unauthorized())
+	h.AddResponse("ValidationFailureReply", "the request has invalid fields", models.ExampleValidationFailureResponse, bulk, http.StatusBadRequest)
+	bulk.AddResponse(http.StatusConflict, conflict())
+	bulk.AddResponse(http.StatusTooManyRequests, tooManyRequests())
+
+	return bulk
+}
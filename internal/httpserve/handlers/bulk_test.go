@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+func TestBulkOrganizationHandler(t *testing.T) {
+	f := newFakeOpenlane()
+	f.failCreate["broken.production"] = errUpstream
+
+	h := newTestHandler(f)
+	h.Jobs = NewJobs(1, 1, time.Hour)
+	h.BulkConcurrency = 2
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organizations/bulk", `[
+		{"name":"MITB Inc.","description":"meow","environments":["production"]},
+		{"name":"me"},
+		{"name":"mitb inc.","description":"meow"},
+		{"name":"broken","description":"meow","environments":["production"]},
+		{"name":"Meow Corp","description":"meow","environments":["production"],"dryRun":true}
+	]`)
+
+	require.NoError(t, h.BulkOrganizationHandler(ctx))
+
+	job := waitForBulkJob(t, h, rec)
+	assert.Equal(t, models.JobStatusFailed, job.Status)
+	assert.Equal(t, job.Completed, len(job.Nodes))
+
+	// the organizations of the failed hierarchy were rolled back, the others remain created
+	for _, node := range job.Nodes {
+		if strings.HasPrefix(node.Name, "broken") {
+			assert.Equal(t, models.NodeStatusRolledBack, node.Status, node.Name)
+		} else {
+			assert.Equal(t, models.NodeStatusCreated, node.Status, node.Name)
+		}
+	}
+
+	require.NotNil(t, job.BulkResult)
+	out := *job.BulkResult
+
+	assert.False(t, out.Success)
+	assert.Equal(t, 5, out.Total)
+	assert.Equal(t, 2, out.Succeeded)
+	assert.Equal(t, 3, out.Failed)
+	require.Len(t, out.Results, 5)
+
+	for i, r := range out.Results {
+		assert.Equal(t, i, r.Index)
+	}
+
+	created := out.Results[0]
+	assert.True(t, created.Success)
+	assert.Equal(t, http.StatusOK, created.Status)
+	require.NotNil(t, created.Organization)
+	assert.Equal(t, "MITB Inc.", created.Organization.Name)
+
+	invalid := out.Results[1]
+	assert.False(t, invalid.Success)
+	assert.Equal(t, http.StatusBadRequest, invalid.Status)
+	assert.Equal(t, InvalidInputErrCode, invalid.ErrorCode)
+	require.Len(t, invalid.Fields, 1)
+	assert.Equal(t, "name", invalid.Fields[0].Field)
+
+	duplicate := out.Results[2]
+	assert.False(t, duplicate.Success)
+	assert.Equal(t, http.StatusBadRequest, duplicate.Status)
+	require.Len(t, duplicate.Fields, 1)
+	assert.Contains(t, duplicate.Fields[0].Message, "organization 0")
+
+	failed := out.Results[3]
+	assert.False(t, failed.Success)
+	assert.Equal(t, http.StatusUnprocessableEntity, failed.Status)
+	assert.Equal(t, errUpstream.Error(), failed.Error)
+	assert.Nil(t, failed.Organization)
+
+	planned := out.Results[4]
+	assert.True(t, planned.Success)
+	require.NotNil(t, planned.Plan)
+	assert.Equal(t, "meow corp", planned.Plan.Organization.Name)
+
+	// only the first organization remains, the failed organization was rolled back and the dry run created nothing
+	for _, org := range f.orgs {
+		assert.Contains(t, org.Name, "mitb inc.")
+	}
+}
+
+func TestBulkOrganizationHandlerConcurrency(t *testing.T) {
+	f := newFakeOpenlane()
+	f.delay = func(string) time.Duration { return 5 * time.Millisecond }
+
+	h := newTestHandler(f)
+	h.Jobs = NewJobs(1, 1, time.Hour)
+	h.Concurrency = 1
+	h.BulkConcurrency = 1
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organizations/bulk",
+		`[{"name":"meow","environments":["production"]},{"name":"woof","environments":["production"]},{"name":"purr","environments":["production"]}]`)
+
+	require.NoError(t, h.BulkOrganizationHandler(ctx))
+
+	job := waitForBulkJob(t, h, rec)
+	assert.Equal(t, models.JobStatusSucceeded, job.Status)
+	assert.Equal(t, 1, f.maxInFlight)
+}
+
+func TestBulkOrganizationHandlerCSV(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+	h.Jobs = NewJobs(1, 1, time.Hour)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organizations/bulk",
+		"name,description,domains,environments,buckets,relationships\nMITB Inc.,meow,mitb.com;meow.com,production,assets,\n")
+	ctx.Request().Header.Set(echo.HeaderContentType, MIMETextCSV)
+
+	require.NoError(t, h.BulkOrganizationHandler(ctx))
+
+	job := waitForBulkJob(t, h, rec)
+	// 1 root + 1 environment + 1 bucket
+	assert.Equal(t, 3, job.Total)
+	require.NotNil(t, job.BulkResult)
+	out := *job.BulkResult
+
+	assert.True(t, out.Success)
+	require.Len(t, out.Results, 1)
+	require.NotNil(t, out.Results[0].Organization)
+	assert.Equal(t, []string{"mitb.com", "meow.com"}, out.Results[0].Organization.Domains)
+
+	// 1 root + 1 environment + 1 bucket
+	assert.Len(t, f.created, 3)
+}
+
+func TestBulkOrganizationHandlerMultipart(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+	h.Jobs = NewJobs(1, 1, time.Hour)
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	part, err := w.CreateFormFile("file", "organizations.json")
+	require.NoError(t, err)
+
+	_, err = part.Write([]byte(`[{"name":"meow","description":"meow","dryRun":true}]`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/organizations/bulk", body)
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+
+	rec := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, rec)
+
+	require.NoError(t, h.BulkOrganizationHandler(ctx))
+
+	job := waitForBulkJob(t, h, rec)
+	assert.Equal(t, 0, job.Total)
+	require.NotNil(t, job.BulkResult)
+	out := *job.BulkResult
+
+	require.Len(t, out.Results, 1)
+	assert.NotNil(t, out.Results[0].Plan)
+	assert.Empty(t, f.created)
+}
+
+func TestBulkOrganizationHandlerInvalid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		body        string
+		contentType string
+	}{
+		{
+			name: "empty",
+			body: `[]`,
+		},
+		{
+			name: "too many",
+			body: `[{"name":"meow"},{"name":"woof"},{"name":"purr"}]`,
+		},
+		{
+			name: "not an array",
+			body: `{"name":"meow"}`,
+		},
+		{
+			name:        "unknown csv column",
+			body:        "name,owner\nmeow,me\n",
+			contentType: MIMETextCSV,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFakeOpenlane()
+			h := newTestHandler(f)
+			h.Jobs = NewJobs(1, 1, time.Hour)
+			h.MaxBulkSize = 2
+
+			ctx, rec := newTestContext(t, http.MethodPost, "/v1/organizations/bulk", tc.body)
+			if tc.contentType != "" {
+				ctx.Request().Header.Set(echo.HeaderContentType, tc.contentType)
+			}
+
+			require.Error(t, h.BulkOrganizationHandler(ctx))
+			requireStatus(t, rec, http.StatusBadRequest)
+			assert.Empty(t, f.created)
+		})
+	}
+}
+
+func TestBulkOrganizationHandlerAsyncDisabled(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organizations/bulk", `[{"name":"meow","environments":["production"]}]`)
+
+	require.ErrorIs(t, h.BulkOrganizationHandler(ctx), ErrAsyncDisabled)
+	requireStatus(t, rec, http.StatusBadRequest)
+	assert.Empty(t, f.created)
+}
+
+// waitForBulkJob checks the bulk request was accepted and polls the job until it has finished
+func waitForBulkJob(t *testing.T, h *Handler, rec *httptest.ResponseRecorder) models.ProvisioningJob {
+	t.Helper()
+
+	requireStatus(t, rec, http.StatusAccepted)
+
+	var submitted models.ProvisioningJobReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &submitted))
+	assert.Equal(t, "/v1/organization/jobs/"+submitted.Job.ID, rec.Header().Get(echo.HeaderLocation))
+
+	var out models.ProvisioningJobReply
+
+	require.Eventually(t, func() bool {
+		ctx, rec := newTestContext(t, http.MethodGet, "/v1/organization/jobs/"+submitted.Job.ID, "")
+		ctx.SetPathParams(echo.PathParams{{Name: "id", Value: submitted.Job.ID}})
+
+		require.NoError(t, h.OrganizationJobHandler(ctx))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+		return out.Job.Status == models.JobStatusSucceeded || out.Job.Status == models.JobStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return out.Job
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+)
+
+// OpenlaneTokenHeader is the header used by callers to provide their own openlane bearer token or personal access token
+const OpenlaneTokenHeader = "X-Openlane-Token"
+
+// ErrOpenlaneCredentialsMissing is returned when caller credentials are forwarded to openlane but the request has none
+var ErrOpenlaneCredentialsMissing = errors.New("openlane credentials are required, provide a token in the " + OpenlaneTokenHeader + " header")
+
+// openlaneClientKey is the context key for the openlane client of the caller
+type openlaneClientKey struct{}
+
+// requestContext returns the context used to handle the request, containing the request id for the audit log; when
+// caller credentials are forwarded to openlane the client for the caller is added to the context so every organization
+// is created and owned by the caller
+func (h *Handler) requestContext(ctx echo.Context) (context.Context, error) {
+	reqCtx := withRequestID(ctx.Request().Context(), ctx.Response().Header().Get(echo.HeaderXRequestID))
+
+	if h.OpenlaneClients == nil {
+		return reqCtx, nil
+	}
+
+	token := openlaneCredential(ctx.Request())
+	if token == "" {
+		return nil, ErrOpenlaneCredentialsMissing
+	}
+
+	client, err := h.OpenlaneClients.Get(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return context.WithValue(reqCtx, openlaneClientKey{}, client), nil
+}
+
+// openlane returns the client used to make requests to openlane, the client of the caller is used when it is in the
+// context and the server-wide client otherwise
+func (h *Handler) openlane(ctx context.Context) *openlaneclient.OpenlaneClient {
+	if client, ok := ctx.Value(openlaneClientKey{}).(*openlaneclient.OpenlaneClient); ok {
+		return client
+	}
+
+	return h.OpenlaneClient
+}
+
+// openlaneCredential returns the openlane token of the caller from the request; a caller authenticated with a JWT
+// that does not set the header has its JWT forwarded, which allows tokens issued by openlane to be used for both servers
+func openlaneCredential(r *http.Request) string {
+	if token := strings.TrimSpace(r.Header.Get(OpenlaneTokenHeader)); token != "" {
+		return token
+	}
+
+	if caller, ok := auth.CallerFromContext(r.Context()); ok && caller.Method == auth.MethodJWT {
+		return caller.Token
+	}
+
+	return ""
+}
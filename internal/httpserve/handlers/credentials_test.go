@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/openlane"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// newForwardingHandler returns a handler that forwards caller credentials to openlane, with a fake client per token
+func newForwardingHandler(fakes map[string]*fakeOpenlane) (*Handler, *fakeOpenlane) {
+	server := newFakeOpenlane()
+
+	h := newTestHandler(server)
+	h.OpenlaneClients = openlane.NewClientPool(len(fakes), func(token string) (*openlaneclient.OpenlaneClient, error) {
+		f, ok := fakes[token]
+		if !ok {
+			f = newFakeOpenlane()
+		}
+
+		return &openlaneclient.OpenlaneClient{OpenlaneGraphClient: f}, nil
+	})
+
+	return h, server
+}
+
+func TestOrganizationHandlerForwardCredentials(t *testing.T) {
+	alice := newFakeOpenlane()
+	bob := newFakeOpenlane()
+
+	h, server := newForwardingHandler(map[string]*fakeOpenlane{"alice-token": alice, "bob-token": bob})
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow"}`)
+	ctx.Request().Header.Set(OpenlaneTokenHeader, "alice-token")
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	// the hierarchy is created with the client of the caller only
+	assert.NotEmpty(t, alice.created)
+	assert.Empty(t, bob.created)
+	assert.Empty(t, server.created)
+
+	// another caller cannot see the hierarchy
+	ctx, rec = newTestContext(t, http.MethodGet, "/v1/organization/"+created.ID, "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+	ctx.Request().Header.Set(OpenlaneTokenHeader, "bob-token")
+
+	require.Error(t, h.GetOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestOrganizationHandlerForwardCredentialsJWT(t *testing.T) {
+	alice := newFakeOpenlane()
+
+	h, _ := newForwardingHandler(map[string]*fakeOpenlane{"alice-jwt": alice})
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow"}`)
+
+	req := ctx.Request()
+	ctx.SetRequest(req.WithContext(auth.WithCaller(req.Context(), &auth.Caller{Subject: "alice", Method: auth.MethodJWT, Token: "alice-jwt"})))
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	assert.NotEmpty(t, alice.created)
+}
+
+func TestOrganizationHandlerForwardCredentialsMissing(t *testing.T) {
+	h, server := newForwardingHandler(map[string]*fakeOpenlane{})
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow"}`)
+
+	// api token callers have no openlane credential to forward
+	req := ctx.Request()
+	ctx.SetRequest(req.WithContext(auth.WithCaller(req.Context(), &auth.Caller{Subject: "ci", Method: auth.MethodAPIToken, Token: "ci-token"})))
+
+	require.ErrorIs(t, h.OrganizationHandler(ctx), ErrOpenlaneCredentialsMissing)
+	requireStatus(t, rec, http.StatusUnauthorized)
+
+	assert.Empty(t, server.created)
+}
+
+func TestOrganizationHandlerOpenlaneRejected(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+	}{
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "forbidden",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFakeOpenlane()
+			f.failCreate["meow"] = &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: tc.status, Message: http.StatusText(tc.status)}}
+
+			h := newTestHandler(f)
+
+			ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow"}`)
+
+			require.Error(t, h.OrganizationHandler(ctx))
+			requireStatus(t, rec, tc.status)
+		})
+	}
+}
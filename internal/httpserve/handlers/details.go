@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"github.com/theopenlane/core/pkg/openlaneclient"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// createdOrgDetails returns the response object for an organization created in openlane; every optional field returned
+// by openlane may be nil and is left empty in the response
+func createdOrgDetails(org *openlaneclient.CreateOrganization_CreateOrganization_Organization) models.OrgDetails {
+	out := models.OrgDetails{
+		ID:        org.ID,
+		Name:      org.DisplayName,
+		Slug:      org.Name,
+		Tags:      org.Tags,
+		CreatedAt: org.CreatedAt,
+	}
+
+	if org.Parent != nil {
+		out.ParentID = org.Parent.ID
+	}
+
+	if s := org.Setting; s != nil {
+		out.Settings = orgSettingsDetails(s.Domains, s.AllowedEmailDomains, s.BillingContact, s.BillingEmail, s.BillingPhone)
+	}
+
+	return out
+}
+
+// orgSettingsDetails returns the response object for the settings of an organization; the generated openlane types
+// differ for every query so the fields are passed individually
+func orgSettingsDetails(domains, allowedEmailDomains []string, billingContact, billingEmail, billingPhone *string) *models.OrgSettings {
+	return &models.OrgSettings{
+		Domains:             domains,
+		AllowedEmailDomains: allowedEmailDomains,
+		BillingContact:      stringValue(billingContact),
+		BillingEmail:        stringValue(billingEmail),
+		BillingPhone:        stringValue(billingPhone),
+	}
+}
+
+// domains returns the domains from the organization settings, nil when there are no settings
+func domains(s *models.OrgSettings) []string {
+	if s == nil {
+		return nil
+	}
+
+	return s.Domains
+}
+
+// stringValue returns the value of an optional string returned by openlane, or an empty string when it is not set
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -2,6 +2,12 @@ package handlers
 
 import (
 	"github.com/theopenlane/core/pkg/openlaneclient"
+
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/audit"
+	"github.com/theopenlane/openlane-cloud/internal/idempotency"
+	"github.com/theopenlane/openlane-cloud/internal/openlane"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
 )
 
 // Handler contains configuration options for handlers
@@ -12,4 +18,23 @@ type Handler struct {
 	ReadyChecks Checks
 	// OpenlaneClient is the client to interact with the openlane API
 	OpenlaneClient *openlaneclient.OpenlaneClient
+	// OpenlaneClients provides a client for each caller when caller credentials are forwarded to openlane instead of
+	// using the OpenlaneClient
+	OpenlaneClients *openlane.ClientPool
+	// Jobs runs asynchronous organization provisioning jobs
+	Jobs *Jobs
+	// Concurrency is the maximum number of organizations created in parallel for a single provisioning request
+	Concurrency int
+	// BulkConcurrency is the maximum number of organization hierarchies created in parallel for a single bulk request
+	BulkConcurrency int
+	// MaxBulkSize is the maximum number of organizations that can be created by a single bulk request
+	MaxBulkSize int
+	// Blueprints contains the hierarchy blueprints that can be selected when provisioning an organization
+	Blueprints *blueprint.Registry
+	// IdempotencyStore persists responses for requests made with an Idempotency-Key header
+	IdempotencyStore idempotency.Store
+	// Webhooks delivers notifications of organization lifecycle events, nil when webhooks are disabled
+	Webhooks *webhook.Dispatcher
+	// Audit records every provisioning action, nil when the audit log is disabled
+	Audit *audit.Log
 }
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// maxHierarchyDepth limits how many levels of child organizations are loaded for an existing hierarchy
+const maxHierarchyDepth = 32
+
+// ErrOrganizationNotFound is returned when the requested organization does not exist in openlane
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+// orgTree is an organization in an existing hierarchy loaded from openlane
+type orgTree struct {
+	// ID of the organization
+	ID string
+	// Name is the unique openlane name of the organization, the path from the root joined by '.'
+	Name string
+	// DisplayName is the name of the organization within its parent
+	DisplayName string
+	// Description of the organization
+	Description string
+	// Tags on the organization
+	Tags []string
+	// ParentID is the id of the parent organization
+	ParentID string
+	// Settings of the organization
+	Settings *models.OrgSettings
+	// CreatedAt is the time the organization was created
+	CreatedAt *time.Time
+	// Children are the organizations in the hierarchy underneath this organization, sorted by display name
+	Children []*orgTree
+}
+
+// loadHierarchy returns the organization and every organization underneath it; children are loaded a level at a time
+// using their parent ids. When managedOnly is set only organizations tagged with their path from the root are included,
+// so organizations added to the tree outside of openlane-cloud are ignored
+func (h *Handler) loadHierarchy(ctx context.Context, id string, managedOnly bool) (*orgTree, error) {
+	o, err := h.openlane(ctx).GetOrganizationByID(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrOrganizationNotFound
+		}
+
+		return nil, openlaneError(err)
+	}
+
+	org := o.Organization
+
+	root := &orgTree{
+		ID:          org.ID,
+		Name:        org.Name,
+		DisplayName: org.DisplayName,
+		Description: stringValue(org.Description),
+		Tags:        org.Tags,
+		CreatedAt:   org.CreatedAt,
+	}
+
+	if org.Parent != nil {
+		root.ParentID = org.Parent.ID
+	}
+
+	if s := org.Setting; s != nil {
+		root.Settings = orgSettingsDetails(s.Domains, s.AllowedEmailDomains, s.BillingContact, s.BillingEmail, s.BillingPhone)
+	}
+
+	// paths contains the path from the root for every organization in the tree, keyed by id
+	paths := map[string][]string{root.ID: {}}
+	nodes := map[string]*orgTree{root.ID: root}
+	level := []*orgTree{root}
+
+	for depth := 0; depth < maxHierarchyDepth && len(level) > 0; depth++ {
+		parentIDs := make([]string, 0, len(level))
+		for _, n := range level {
+			parentIDs = append(parentIDs, n.ID)
+		}
+
+		children, err := h.getOrganizations(ctx, openlaneclient.OrganizationWhereInput{
+			ParentOrganizationIDIn: parentIDs,
+		}, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		next := []*orgTree{}
+
+		for _, child := range children {
+			if child.Parent == nil {
+				continue
+			}
+
+			parent, ok := nodes[child.Parent.ID]
+			if !ok {
+				continue
+			}
+
+			path := append(slices.Clone(paths[parent.ID]), child.DisplayName)
+			if managedOnly && !hasTagPath(child.Tags, path) {
+				continue
+			}
+
+			n := &orgTree{
+				ID:          child.ID,
+				Name:        child.Name,
+				DisplayName: child.DisplayName,
+				Description: stringValue(child.Description),
+				Tags:        child.Tags,
+				ParentID:    parent.ID,
+				CreatedAt:   child.CreatedAt,
+			}
+
+			if s := child.Setting; s != nil {
+				n.Settings = orgSettingsDetails(s.Domains, s.AllowedEmailDomains, s.BillingContact, s.BillingEmail, s.BillingPhone)
+			}
+
+			parent.Children = append(parent.Children, n)
+			paths[n.ID] = path
+			nodes[n.ID] = n
+			next = append(next, n)
+		}
+
+		for _, n := range level {
+			slices.SortFunc(n.Children, func(a, b *orgTree) int {
+				return strings.Compare(a.DisplayName, b.DisplayName)
+			})
+		}
+
+		level = next
+	}
+
+	return root, nil
+}
+
+// getOrganizations returns the organizations matching the filter, ordered by id, stopping once at least limit have been
+// returned or every organization when limit is 0; openlane limits the number of organizations returned by a single query
+// and orders them by id, so the query is repeated for the organizations after the last id returned until no more are returned
+func (h *Handler) getOrganizations(ctx context.Context, where openlaneclient.OrganizationWhereInput, limit int) ([]*openlaneclient.GetOrganizations_Organizations_Edges_Node, error) {
+	out := []*openlaneclient.GetOrganizations_Organizations_Edges_Node{}
+
+	for {
+		resp, err := h.openlane(ctx).GetOrganizations(ctx, &where)
+		if err != nil {
+			return nil, openlaneError(err)
+		}
+
+		after := where.IDGt
+
+		for _, edge := range resp.Organizations.Edges {
+			if edge == nil || edge.Node == nil {
+				continue
+			}
+
+			out = append(out, edge.Node)
+
+			if where.IDGt == nil || edge.Node.ID > *where.IDGt {
+				where.IDGt = &edge.Node.ID
+			}
+		}
+
+		// nothing after the last id was returned, so every organization has been seen
+		if where.IDGt == after || (limit > 0 && len(out) >= limit) {
+			slices.SortFunc(out, func(a, b *openlaneclient.GetOrganizations_Organizations_Edges_Node) int {
+				return strings.Compare(a.ID, b.ID)
+			})
+
+			return out, nil
+		}
+	}
+}
+
+// reply returns the response object for the hierarchy; trees up to three levels deep are returned as environments,
+// buckets, and relations, deeper trees are returned as children
+func (t *orgTree) reply() *models.OrganizationReply {
+	out := &models.OrganizationReply{
+		Reply:       rout.Reply{Success: true},
+		OrgDetails:  t.details(),
+		Description: t.Description,
+		Domains:     domains(t.Settings),
+	}
+
+	children := t.orgNodes()
+
+	if t.depth() <= standardHierarchyDepth {
+		out.Environments = environmentsFromNodes(children)
+	} else {
+		out.Children = children
+	}
+
+	return out
+}
+
+// levels returns the organizations in the tree grouped by depth, starting with the deepest level and ending with the root
+func (t *orgTree) levels() [][]*orgTree {
+	out := [][]*orgTree{}
+
+	for level := []*orgTree{t}; len(level) > 0; {
+		out = append(out, level)
+
+		next := []*orgTree{}
+		for _, n := range level {
+			next = append(next, n.Children...)
+		}
+
+		level = next
+	}
+
+	slices.Reverse(out)
+
+	return out
+}
+
+// descendants returns the number of organizations underneath the organization
+func (t *orgTree) descendants() int {
+	count := len(t.Children)
+
+	for _, c := range t.Children {
+		count += c.descendants()
+	}
+
+	return count
+}
+
+// details returns the response object for the organization
+func (t *orgTree) details() models.OrgDetails {
+	return models.OrgDetails{
+		ID:        t.ID,
+		Name:      t.DisplayName,
+		Slug:      t.Name,
+		Tags:      t.Tags,
+		ParentID:  t.ParentID,
+		Settings:  t.Settings,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// standardHierarchyDepth is the depth of the environments, buckets, and relations hierarchy
+const standardHierarchyDepth = 3
+
+// orgNodes returns the children of the organization as response objects
+func (t *orgTree) orgNodes() []models.OrgNode {
+	out := make([]models.OrgNode, 0, len(t.Children))
+
+	for _, c := range t.Children {
+		out = append(out, models.OrgNode{
+			OrgDetails: c.details(),
+			Children:   c.orgNodes(),
+		})
+	}
+
+	return out
+}
+
+// depth returns the number of levels of children underneath the organization
+func (t *orgTree) depth() int {
+	depth := 0
+
+	for _, c := range t.Children {
+		depth = max(depth, 1+c.depth())
+	}
+
+	return depth
+}
+
+// hasTagPath returns true when the tags start with the path from the root written when the organization was provisioned
+func hasTagPath(tags, path []string) bool {
+	return len(tags) >= len(path) && slices.Equal(tags[:len(path)], path)
+}
+
+// isNotFound returns true when the openlane error indicates the requested object does not exist
+func isNotFound(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "not found")
+}
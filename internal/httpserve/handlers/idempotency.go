@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/idempotency"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header used to make a request idempotent
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses that were replayed from a previous request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+var (
+	// IdempotencyKeyReusedErrCode is returned when an idempotency key is reused with a different request
+	IdempotencyKeyReusedErrCode rout.ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	// IdempotencyRequestInProgressErrCode is returned when a request with the same idempotency key is still running
+	IdempotencyRequestInProgressErrCode rout.ErrorCode = "IDEMPOTENCY_REQUEST_IN_PROGRESS"
+)
+
+// responseCapture records the response body while writing it to the client
+type responseCapture struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes the data to the underlying writer and the captured body
+func (r *responseCapture) Write(b []byte) (int, error) {
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
+
+// withIdempotency runs next at most once for each Idempotency-Key header value; retries with the same key and request
+// replay the stored response, and reusing a key with a different request returns a conflict. Only successful responses
+// are stored, failed requests release the key so they can be retried
+func (h *Handler) withIdempotency(ctx echo.Context, request any, next func() error) error {
+	key := ctx.Request().Header.Get(IdempotencyKeyHeader)
+	if key == "" || h.IdempotencyStore == nil {
+		return next()
+	}
+
+	reqCtx := ctx.Request().Context()
+
+	// keys are scoped to the caller so one caller cannot replay the response of another
+	if caller, ok := auth.CallerFromContext(reqCtx); ok {
+		key = string(caller.Method) + ":" + caller.Subject + ":" + key
+	}
+
+	fingerprint, err := idempotency.Fingerprint(ctx.Request().Method+" "+ctx.Request().URL.RequestURI(), request)
+	if err != nil {
+		return h.InternalServerError(ctx, err)
+	}
+
+	rec, err := h.IdempotencyStore.Begin(reqCtx, key, fingerprint)
+
+	switch {
+	case errors.Is(err, idempotency.ErrFingerprintMismatch):
+		return h.Conflict(ctx, err.Error(), IdempotencyKeyReusedErrCode)
+	case errors.Is(err, idempotency.ErrRequestInProgress):
+		return h.Conflict(ctx, err.Error(), IdempotencyRequestInProgressErrCode)
+	case err != nil:
+		return h.InternalServerError(ctx, err)
+	}
+
+	// replay the stored response
+	if rec != nil {
+		ctx.Response().Header().Set(IdempotentReplayedHeader, "true")
+
+		return ctx.JSONBlob(rec.StatusCode, rec.Body)
+	}
+
+	capture := &responseCapture{ResponseWriter: ctx.Response().Writer}
+	ctx.Response().Writer = capture
+
+	defer func() {
+		ctx.Response().Writer = capture.ResponseWriter
+	}()
+
+	handlerErr := next()
+
+	status := ctx.Response().Status
+	if status >= http.StatusOK && status < http.StatusMultipleChoices {
+		err = h.IdempotencyStore.Complete(reqCtx, key, status, capture.body.Bytes())
+	} else {
+		err = h.IdempotencyStore.Release(reqCtx, key)
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("failed to store idempotency record")
+	}
+
+	return handlerErr
+}
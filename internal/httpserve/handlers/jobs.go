@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+var (
+	// ErrJobQueueFull is returned when an asynchronous job is submitted but no more jobs can be queued
+	ErrJobQueueFull = errors.New("too many provisioning jobs in progress, please try again later")
+
+	// ErrJobNotFound is returned when the requested job does not exist or has expired
+	ErrJobNotFound = errors.New("provisioning job not found")
+
+	// ErrAsyncDisabled is returned when an asynchronous request is made but no job runner is configured
+	ErrAsyncDisabled = errors.New("asynchronous provisioning is not enabled")
+
+	// ErrJobsClosed is returned when a job is submitted after the job runner has been closed
+	ErrJobsClosed = errors.New("the server is shutting down and is not accepting provisioning jobs, please try again later")
+)
+
+// JobFunc is the work performed by an asynchronous provisioning job; the progress function must be called
+// for every organization created so the status of the job can be reported while it runs
+type JobFunc func(ctx context.Context, progress func(models.OrgDetails)) (*models.OrganizationReply, error)
+
+// BulkJobFunc is the work performed by an asynchronous bulk provisioning job; the progress function must be called
+// for every organization created and the failed function for every organization hierarchy that was rolled back
+type BulkJobFunc func(ctx context.Context, progress func(models.OrgDetails), failed func(*ProvisioningError)) models.BulkOrganizationReply
+
+// Jobs runs asynchronous provisioning jobs on a bounded pool of background workers and keeps
+// their status in memory until the retention period has passed
+type Jobs struct {
+	mu        sync.RWMutex
+	jobs      map[string]*job
+	queue     chan *job
+	retention time.Duration
+	wg        sync.WaitGroup
+	// closed is set once the queue has been closed, guarded by mu so no job is queued afterwards
+	closed bool
+	// stop cancels the jobs that are still running when the job runner is not closed in time
+	stop   context.Context
+	cancel context.CancelFunc
+}
+
+// job is a single queued or running provisioning job
+type job struct {
+	mu    sync.RWMutex
+	state models.ProvisioningJob
+	ctx   context.Context
+	run   JobFunc
+	bulk  BulkJobFunc
+	// caller is the auth method and subject of the caller that submitted the job, only they can retrieve it
+	caller string
+}
+
+// NewJobs creates the job runner and starts the workers
+func NewJobs(workers, queueSize int, retention time.Duration) *Jobs {
+	j := &Jobs{
+		jobs:      map[string]*job{},
+		queue:     make(chan *job, queueSize),
+		retention: retention,
+	}
+
+	j.stop, j.cancel = context.WithCancel(context.Background())
+
+	for range workers {
+		j.wg.Add(1)
+
+		go j.work()
+	}
+
+	return j
+}
+
+// Submit queues a new job that will create the expected number of organizations; the context is detached
+// from cancellation so the job continues after the request that submitted it has completed
+func (j *Jobs) Submit(ctx context.Context, total int, run JobFunc) (models.ProvisioningJob, error) {
+	jb := newJob(ctx, total)
+	jb.run = run
+
+	return j.submit(jb)
+}
+
+// SubmitBulk queues a new bulk job that will create the expected number of organizations across every organization
+// hierarchy in the request; the context is detached from cancellation the same as Submit
+func (j *Jobs) SubmitBulk(ctx context.Context, total int, run BulkJobFunc) (models.ProvisioningJob, error) {
+	jb := newJob(ctx, total)
+	jb.bulk = run
+
+	return j.submit(jb)
+}
+
+// newJob creates a pending job for the expected number of organizations
+func newJob(ctx context.Context, total int) *job {
+	now := time.Now()
+
+	return &job{
+		state: models.ProvisioningJob{
+			ID:        ulid.Make().String(),
+			Status:    models.JobStatusPending,
+			Total:     total,
+			Nodes:     []models.JobNode{},
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		ctx:    context.WithoutCancel(ctx),
+		caller: jobCaller(ctx),
+	}
+}
+
+// jobCaller returns the auth method and subject of the authenticated caller in the context, jobs are scoped to it the
+// same as idempotency keys; it is empty when the request was not authenticated
+func jobCaller(ctx context.Context) string {
+	caller, ok := auth.CallerFromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return string(caller.Method) + ":" + caller.Subject
+}
+
+// submit adds the job to the queue, or returns an error when the queue is full or the job runner has been closed
+func (j *Jobs) submit(jb *job) (models.ProvisioningJob, error) {
+	j.expire()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return models.ProvisioningJob{}, ErrJobsClosed
+	}
+
+	select {
+	case j.queue <- jb:
+	default:
+		return models.ProvisioningJob{}, ErrJobQueueFull
+	}
+
+	j.jobs[jb.state.ID] = jb
+
+	return jb.snapshot(), nil
+}
+
+// Get returns the current state of the job submitted by the caller in the context; the job of another caller is not
+// found, so one caller cannot read the results of another by id
+func (j *Jobs) Get(ctx context.Context, id string) (models.ProvisioningJob, error) {
+	j.expire()
+
+	j.mu.RLock()
+	jb, ok := j.jobs[id]
+	j.mu.RUnlock()
+
+	if !ok || jb.caller != jobCaller(ctx) {
+		return models.ProvisioningJob{}, ErrJobNotFound
+	}
+
+	return jb.snapshot(), nil
+}
+
+// Close stops accepting jobs and waits for the queued and running jobs to finish. If the context is done first the
+// remaining jobs are canceled, so they stop creating organizations and roll back the ones they created, and the
+// context error is returned once they have been rolled back
+func (j *Jobs) Close(ctx context.Context) error {
+	j.mu.Lock()
+
+	if !j.closed {
+		j.closed = true
+		close(j.queue)
+	}
+
+	j.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		j.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	j.cancel()
+	<-done
+
+	return ctx.Err()
+}
+
+// work processes jobs from the queue until the queue is closed
+func (j *Jobs) work() {
+	defer j.wg.Done()
+
+	for jb := range j.queue {
+		ctx, cancel := context.WithCancel(jb.ctx)
+		stop := context.AfterFunc(j.stop, cancel)
+
+		jb.execute(ctx)
+
+		stop()
+		cancel()
+	}
+}
+
+// expire removes finished jobs that are older than the retention period
+func (j *Jobs) expire() {
+	if j.retention <= 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-j.retention)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for id, jb := range j.jobs {
+		state := jb.snapshot()
+
+		if (state.Status == models.JobStatusSucceeded || state.Status == models.JobStatusFailed) && state.UpdatedAt.Before(cutoff) {
+			delete(j.jobs, id)
+		}
+	}
+}
+
+// execute runs the job with the context and records the outcome
+func (jb *job) execute(ctx context.Context) {
+	jb.update(func(s *models.ProvisioningJob) {
+		s.Status = models.JobStatusRunning
+	})
+
+	progress := func(org models.OrgDetails) {
+		jb.update(func(s *models.ProvisioningJob) {
+			s.Nodes = append(s.Nodes, models.JobNode{OrgDetails: org, Status: models.NodeStatusCreated})
+			s.Completed++
+		})
+	}
+
+	if jb.bulk != nil {
+		out := jb.bulk(ctx, progress, func(perr *ProvisioningError) {
+			jb.update(func(s *models.ProvisioningJob) {
+				setNodeStatus(s.Nodes, perr.RolledBack, models.NodeStatusRolledBack)
+				setNodeStatus(s.Nodes, perr.Orphaned, models.NodeStatusOrphaned)
+			})
+		})
+
+		// the job has failed when any organization in the request failed, the result contains the details of each
+		jb.update(func(s *models.ProvisioningJob) {
+			s.Status = models.JobStatusSucceeded
+			if !out.Success {
+				s.Status = models.JobStatusFailed
+			}
+
+			s.BulkResult = &out
+		})
+
+		return
+	}
+
+	out, err := jb.run(ctx, progress)
+	if err != nil {
+		log.Error().Err(err).Str("job", jb.state.ID).Msg("asynchronous provisioning job failed")
+
+		jb.update(func(s *models.ProvisioningJob) {
+			s.Status = models.JobStatusFailed
+
+			var perr *ProvisioningError
+			if !errors.As(err, &perr) {
+				s.Failure = &models.ProvisioningFailureReply{Reply: rout.ErrorResponse(err)}
+
+				return
+			}
+
+			reply := perr.Reply()
+
+			var oerr *OpenlaneError
+			if errors.As(err, &oerr) {
+				reply.ErrorCode = oerr.Code
+			}
+
+			s.Failure = &reply
+
+			setNodeStatus(s.Nodes, perr.RolledBack, models.NodeStatusRolledBack)
+			setNodeStatus(s.Nodes, perr.Orphaned, models.NodeStatusOrphaned)
+		})
+
+		return
+	}
+
+	jb.update(func(s *models.ProvisioningJob) {
+		s.Status = models.JobStatusSucceeded
+		s.Result = out
+	})
+}
+
+// update applies the change to the job state while holding the lock
+func (jb *job) update(fn func(*models.ProvisioningJob)) {
+	jb.mu.Lock()
+	defer jb.mu.Unlock()
+
+	fn(&jb.state)
+	jb.state.UpdatedAt = time.Now()
+}
+
+// snapshot returns a copy of the job state that is safe to return to callers
+func (jb *job) snapshot() models.ProvisioningJob {
+	jb.mu.RLock()
+	defer jb.mu.RUnlock()
+
+	out := jb.state
+	out.Nodes = make([]models.JobNode, len(jb.state.Nodes))
+	copy(out.Nodes, jb.state.Nodes)
+
+	return out
+}
+
+// setNodeStatus sets the status of every node matching one of the organizations
+func setNodeStatus(nodes []models.JobNode, orgs []models.OrgDetails, status models.NodeStatus) {
+	ids := make(map[string]struct{}, len(orgs))
+	for _, org := range orgs {
+		ids[org.ID] = struct{}{}
+	}
+
+	for i := range nodes {
+		if _, ok := ids[nodes[i].ID]; ok {
+			nodes[i].Status = status
+		}
+	}
+}
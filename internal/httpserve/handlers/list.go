@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// ErrInvalidPageSize is returned when organizations are listed with a limit outside of the allowed range
+var ErrInvalidPageSize = errors.New("invalid limit")
+
+// ListOrganizationsHandler is the handler for listing the root organizations visible to the caller a page at a time;
+// openlane is queried for the organizations after the cursor in id order until the page, and one more organization to
+// know whether there is a next page, has been returned
+func (h *Handler) ListOrganizationsHandler(ctx echo.Context) error {
+	limit := models.DefaultPageSize
+
+	if value := ctx.QueryParam("limit"); value != "" {
+		var err error
+
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > models.MaxPageSize {
+			return h.BadRequest(ctx, fmt.Errorf("%w: must be between 1 and %d", ErrInvalidPageSize, models.MaxPageSize))
+		}
+	}
+
+	reqCtx, err := h.requestContext(ctx)
+	if err != nil {
+		return h.Unauthorized(ctx, err)
+	}
+
+	roots := true
+	// personal organizations are created by openlane for every user and are never part of a hierarchy
+	personal := false
+
+	where := openlaneclient.OrganizationWhereInput{
+		ParentOrganizationIDIsNil: &roots,
+		PersonalOrg:               &personal,
+	}
+
+	if cursor := ctx.QueryParam("cursor"); cursor != "" {
+		where.IDGt = &cursor
+	}
+
+	nodes, err := h.getOrganizations(reqCtx, where, limit+1)
+	if err != nil {
+		return h.openlaneFailure(ctx, err, h.InternalServerError)
+	}
+
+	orgs := make([]models.OrgDetails, 0, len(nodes))
+
+	for _, org := range nodes {
+		details := models.OrgDetails{
+			ID:        org.ID,
+			Name:      org.DisplayName,
+			Slug:      org.Name,
+			Tags:      org.Tags,
+			CreatedAt: org.CreatedAt,
+		}
+
+		if s := org.Setting; s != nil {
+			details.Settings = orgSettingsDetails(s.Domains, s.AllowedEmailDomains, s.BillingContact, s.BillingEmail, s.BillingPhone)
+		}
+
+		orgs = append(orgs, details)
+	}
+
+	out := models.OrganizationListReply{
+		Reply:         rout.Reply{Success: true},
+		Organizations: orgs,
+	}
+
+	if len(orgs) > limit {
+		out.Organizations = orgs[:limit]
+		out.NextCursor = orgs[limit-1].ID
+	}
+
+	return h.Success(ctx, out)
+}
+
+// BindListOrganizationsHandler is used to bind the list organizations endpoint to the OpenAPI schema
+func (h *Handler) BindListOrganizationsHandler() *openapi3.Operation {
+	list := openapi3.NewOperation()
+	list.Description = "ListOrganizations returns a page of the root organizations visible to the caller, ordered by id"
+	list.OperationID = "ListOrganizationsHandler"
+	list.Security = authenticated()
+
+	list.AddParameter(openapi3.NewQueryParameter("limit").
+		WithDescription("the maximum number of organizations to return").
+		WithSchema(openapi3.NewIntegerSchema().WithMin(1).WithMax(models.MaxPageSize).WithDefault(models.DefaultPageSize)))
+	list.AddParameter(openapi3.NewQueryParameter("cursor").
+		WithDescription("the nextCursor of the previous page, the first page is returned when it is not set").
+		WithSchema(openapi3.NewStringSchema()))
+
+	h.AddResponse("OrganizationListReply", "success", models.ExampleOrganizationListResponse, list, http.StatusOK)
+	list.AddResponse(http.StatusInternalServerError, internalServerError())
+	list.AddResponse(http.StatusUnauthorized, unauthorized())
+	addOpenlaneRequest(list)
+	list.AddResponse(http.StatusBadRequest, badRequest())
+
+	return list
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+func TestListOrganizationsHandler(t *testing.T) {
+	f := newFakeOpenlane()
+	f.pageSize = 2
+
+	personal := true
+	names := []string{"cat inc.", "dog inc.", "meow corp", "mitb inc.", "purr llc"}
+
+	for i, name := range names {
+		root, err := f.CreateOrganization(context.Background(), openlaneclient.CreateOrganizationInput{Name: name, DisplayName: &names[i]}, nil)
+		require.NoError(t, err)
+
+		_, err = f.CreateOrganization(context.Background(), openlaneclient.CreateOrganizationInput{Name: name + ".production", ParentID: &root.CreateOrganization.Organization.ID}, nil)
+		require.NoError(t, err)
+
+		if i == 0 {
+			_, err = f.CreateOrganization(context.Background(), openlaneclient.CreateOrganizationInput{Name: "meow's org", PersonalOrg: &personal}, nil)
+			require.NoError(t, err)
+		}
+	}
+
+	h := newTestHandler(f)
+
+	listed := []string{}
+	cursor := ""
+
+	for {
+		f.queries = 0
+
+		ctx, rec := newTestContext(t, http.MethodGet, "/v1/organizations?limit=2&cursor="+cursor, "")
+		require.NoError(t, h.ListOrganizationsHandler(ctx))
+		requireStatus(t, rec, http.StatusOK)
+
+		var out models.OrganizationListReply
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+		require.LessOrEqual(t, len(out.Organizations), 2)
+
+		// only the page and the next organization are requested from openlane
+		assert.LessOrEqual(t, f.queries, 2)
+
+		for _, org := range out.Organizations {
+			listed = append(listed, org.Name)
+		}
+
+		if out.NextCursor == "" {
+			break
+		}
+
+		assert.Equal(t, out.Organizations[len(out.Organizations)-1].ID, out.NextCursor)
+		cursor = out.NextCursor
+	}
+
+	// only the root organizations are listed, personal organizations are excluded
+	assert.Equal(t, names, listed)
+}
+
+func TestListOrganizationsHandlerInvalid(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+
+	for _, limit := range []string{"0", "meow", "501"} {
+		ctx, rec := newTestContext(t, http.MethodGet, "/v1/organizations?limit="+limit, "")
+		require.ErrorIs(t, h.ListOrganizationsHandler(ctx), ErrInvalidPageSize)
+		requireStatus(t, rec, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/theopenlane/core/pkg/enums"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// validateGroups ensures every environment a group is created in is part of the first level of the blueprint; the
+// blueprint is only known once the template has been resolved so this cannot be checked by the request
+func validateGroups(bp blueprint.Blueprint, groups []models.OrganizationGroup) error {
+	environments := make([]string, 0, len(bp.Children))
+	for _, n := range bp.Children {
+		environments = append(environments, strings.ToLower(n.Name))
+	}
+
+	fields := []models.FieldError{}
+
+	for i, g := range groups {
+		if len(g.Environments) == 0 && len(environments) == 0 {
+			fields = append(fields, models.FieldError{
+				Field:   fmt.Sprintf("groups[%d].environments", i),
+				Message: "the hierarchy does not contain any environments to create the group in",
+			})
+		}
+
+		for j, env := range g.Environments {
+			if !slices.Contains(environments, strings.ToLower(env)) {
+				fields = append(fields, models.FieldError{
+					Field:   fmt.Sprintf("groups[%d].environments[%d]", i, j),
+					Message: fmt.Sprintf("%q is not an environment in the hierarchy", env),
+				})
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return &models.ValidationError{Fields: fields}
+	}
+
+	return nil
+}
+
+// createMembers creates the groups, with their members, in the environments and then invites the users to the root
+// organization once the hierarchy has been created; the groups belong to the organizations in the hierarchy so they are
+// removed with the organizations if provisioning is rolled back. An invite email cannot be recalled, so the invites are
+// sent last, only once everything else has been created
+func (h *Handler) createMembers(ctx context.Context, in models.OrganizationRequest, rootID string, environments []models.OrgNode, tracker *provisioningTracker) ([]models.InviteDetails, []models.GroupDetails, error) {
+	groups, err := h.createGroups(ctx, in.Groups, environments, tracker)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invites, err := h.createInvites(ctx, rootID, in.Invites, tracker)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return invites, groups, nil
+}
+
+// createInvites sends the invitations to join the root organization in a single request
+func (h *Handler) createInvites(ctx context.Context, rootID string, invites []models.OrganizationInvite, tracker *provisioningTracker) ([]models.InviteDetails, error) {
+	if len(invites) == 0 {
+		return nil, nil
+	}
+
+	release, err := tracker.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	input := make([]*openlaneclient.CreateInviteInput, 0, len(invites))
+
+	for _, invite := range invites {
+		input = append(input, &openlaneclient.CreateInviteInput{
+			Recipient: invite.Email,
+			Role:      role(invite.Role),
+			OwnerID:   &rootID,
+		})
+	}
+
+	log.Debug().Int("invites", len(input)).Msg("inviting users to organization")
+
+	resp, err := h.openlane(ctx).CreateBulkInvite(ctx, input)
+	if err != nil {
+		return nil, openlaneError(err)
+	}
+
+	out := make([]models.InviteDetails, 0, len(resp.CreateBulkInvite.Invites))
+
+	for _, invite := range resp.CreateBulkInvite.Invites {
+		if invite == nil {
+			continue
+		}
+
+		out = append(out, models.InviteDetails{
+			ID:     invite.ID,
+			Email:  invite.Recipient,
+			Role:   invite.Role.String(),
+			Status: invite.Status.String(),
+		})
+	}
+
+	return out, nil
+}
+
+// createGroups creates every group in each of its environments, or every environment when none are set; the groups are
+// created in parallel and returned in the order of the request and then the environments. The first failure cancels
+// any outstanding creates
+func (h *Handler) createGroups(ctx context.Context, groups []models.OrganizationGroup, environments []models.OrgNode, tracker *provisioningTracker) ([]models.GroupDetails, error) {
+	type target struct {
+		group models.OrganizationGroup
+		orgID string
+	}
+
+	targets := []target{}
+
+	for _, g := range groups {
+		for _, env := range environments {
+			if createdIn(g, env.Name) {
+				targets = append(targets, target{group: g, orgID: env.ID})
+			}
+		}
+	}
+
+	out := make([]models.GroupDetails, len(targets))
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	for i, t := range targets {
+		g.Go(func() error {
+			group, err := h.createGroup(gctx, t.orgID, t.group, tracker)
+			if err != nil {
+				return err
+			}
+
+			out[i] = *group
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// createGroup creates a single group owned by the organization and adds its members
+func (h *Handler) createGroup(ctx context.Context, orgID string, group models.OrganizationGroup, tracker *provisioningTracker) (*models.GroupDetails, error) {
+	release, err := tracker.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	input := openlaneclient.CreateGroupInput{
+		Name:    group.Name,
+		OwnerID: &orgID,
+	}
+
+	if group.Description != "" {
+		input.Description = &group.Description
+	}
+
+	log.Debug().Str("group", group.Name).Str("organization", orgID).Msg("creating group")
+
+	resp, err := h.openlane(ctx).CreateGroup(ctx, input)
+	if err != nil {
+		return nil, openlaneError(err)
+	}
+
+	out := &models.GroupDetails{
+		ID:             resp.CreateGroup.Group.ID,
+		Name:           resp.CreateGroup.Group.Name,
+		OrganizationID: orgID,
+	}
+
+	for _, m := range group.Members {
+		member, err := h.openlane(ctx).AddUserToGroupWithRole(ctx, openlaneclient.CreateGroupMembershipInput{
+			GroupID: out.ID,
+			UserID:  m.UserID,
+			Role:    role(m.Role),
+		})
+		if err != nil {
+			return nil, openlaneError(err)
+		}
+
+		out.Members = append(out.Members, models.GroupMemberDetails{
+			ID:     member.CreateGroupMembership.GroupMembership.ID,
+			UserID: member.CreateGroupMembership.GroupMembership.UserID,
+			Role:   member.CreateGroupMembership.GroupMembership.Role.String(),
+		})
+	}
+
+	return out, nil
+}
+
+// createdIn reports whether the group is created in the environment, groups without environments are created in all
+func createdIn(g models.OrganizationGroup, environment string) bool {
+	return len(g.Environments) == 0 || slices.ContainsFunc(g.Environments, func(name string) bool {
+		return strings.EqualFold(name, environment)
+	})
+}
+
+// role returns the openlane role for an invite or group member, nil when it is not set so openlane uses its default
+func role(r string) *enums.Role {
+	if r == "" {
+		return nil
+	}
+
+	return enums.ToRole(r)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/core/pkg/enums"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+func TestOrganizationHandlerMembers(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{
+		"name": "MITB Inc.",
+		"environments": ["production", "testing"],
+		"buckets": ["assets"],
+		"invites": [{"email": " Owner@MITB.com ", "role": "admin"}, {"email": "cat@mitb.com"}],
+		"groups": [
+			{"name": "engineering", "members": [{"userId": "user-1", "role": "ADMIN"}]},
+			{"name": "support", "environments": ["Production"]}
+		]
+	}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	// the users are invited to the root organization
+	require.Len(t, out.Invites, 2)
+	assert.Equal(t, "owner@mitb.com", out.Invites[0].Email)
+	assert.Equal(t, "ADMIN", out.Invites[0].Role)
+	assert.Equal(t, "MEMBER", out.Invites[1].Role)
+	assert.NotEmpty(t, out.Invites[0].ID)
+
+	require.Len(t, f.invites, 2)
+	assert.Equal(t, out.ID, *f.invites[0].OwnerID)
+	assert.Equal(t, enums.RoleAdmin, *f.invites[0].Role)
+	assert.Nil(t, f.invites[1].Role)
+
+	// groups without environments are created in every environment
+	require.Len(t, out.Environments, 2)
+	require.Len(t, out.Groups, 3)
+
+	production, test := out.Environments[0].ID, out.Environments[1].ID
+
+	assert.Equal(t, "engineering", out.Groups[0].Name)
+	assert.Equal(t, production, out.Groups[0].OrganizationID)
+	assert.Equal(t, "engineering", out.Groups[1].Name)
+	assert.Equal(t, test, out.Groups[1].OrganizationID)
+	assert.Equal(t, "support", out.Groups[2].Name)
+	assert.Equal(t, production, out.Groups[2].OrganizationID)
+
+	require.Len(t, out.Groups[0].Members, 1)
+	assert.Equal(t, "user-1", out.Groups[0].Members[0].UserID)
+	assert.Equal(t, "ADMIN", out.Groups[0].Members[0].Role)
+	assert.NotEmpty(t, out.Groups[0].Members[0].ID)
+
+	assert.Equal(t, []string{"user-1"}, f.members[out.Groups[1].ID])
+	assert.Empty(t, f.members[out.Groups[2].ID])
+	assert.Equal(t, production, *f.groups[out.Groups[2].ID].OwnerID)
+}
+
+func TestOrganizationHandlerMembersInvalid(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{
+		"name": "MITB Inc.",
+		"environments": ["production"],
+		"invites": [{"email": "owner"}, {"email": "cat@mitb.com", "role": "owner"}],
+		"groups": [{"name": "engineering", "environments": ["staging"]}]
+	}`)
+
+	require.Error(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusBadRequest)
+	assert.Empty(t, f.created)
+
+	var out models.ValidationFailureReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	fields := []string{}
+	for _, field := range out.Fields {
+		fields = append(fields, field.Field)
+	}
+
+	assert.Equal(t, []string{"invites[0].email", "invites[1].role"}, fields)
+
+	// the environments of the groups are checked against the hierarchy
+	ctx, rec = newTestContext(t, http.MethodPost, "/v1/organization",
+		`{"name":"MITB Inc.","environments":["production"],"groups":[{"name":"engineering","environments":["staging"]}]}`)
+
+	require.Error(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusBadRequest)
+	assert.Empty(t, f.created)
+	assert.Contains(t, rec.Body.String(), "groups[0].environments[0]")
+}
+
+func TestOrganizationHandlerMembersRollback(t *testing.T) {
+	f := newFakeOpenlane()
+	f.failGroup["support"] = errUpstream
+
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization",
+		`{"name":"MITB Inc.","environments":["production"],"buckets":["assets"],"invites":[{"email":"owner@mitb.com"}],"groups":[{"name":"support"}]}`)
+
+	err := h.OrganizationHandler(ctx)
+	require.ErrorIs(t, err, errUpstream)
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	// the hierarchy is rolled back when the groups cannot be created, and no invite is sent for it
+	assert.Len(t, f.created, 3)
+	assert.Empty(t, f.orgs)
+	assert.Empty(t, f.invites)
+}
+
+func TestOrganizationHandlerMembersDryRun(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{
+		"name": "MITB Inc.",
+		"environments": ["production", "testing"],
+		"invites": [{"email": "owner@mitb.com", "role": "ADMIN"}],
+		"groups": [{"name": "support", "environments": ["testing"]}],
+		"dryRun": true
+	}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+	assert.Empty(t, f.invites)
+	assert.Empty(t, f.groups)
+
+	var out models.OrganizationPlanReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	require.Len(t, out.Invites, 1)
+	assert.Equal(t, "owner@mitb.com", out.Invites[0].Email)
+
+	require.Len(t, out.Organization.Children, 2)
+	assert.Empty(t, out.Organization.Children[0].Groups)
+	assert.Equal(t, []string{"support"}, out.Organization.Children[1].Groups)
+}
@@ -1,30 +1,46 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/getkin/kin-openapi/openapi3"
 
 	"github.com/theopenlane/httpsling"
 )
 
+const (
+	// BearerSecurityScheme is the name of the security scheme for API tokens and JWTs sent in the authorization header
+	BearerSecurityScheme = "bearer"
+	// OpenIDSecurityScheme is the name of the security scheme for JWTs issued by the configured OpenID Connect provider
+	OpenIDSecurityScheme = "openid"
+)
+
+// authenticated returns the security requirements for endpoints that require a bearer token
+func authenticated() *openapi3.SecurityRequirements {
+	return &openapi3.SecurityRequirements{
+		openapi3.NewSecurityRequirement().Authenticate(BearerSecurityScheme),
+	}
+}
+
 // badRequest is a wrapper for openaAPI bad request response
 func badRequest() *openapi3.Response {
 	return openapi3.NewResponse().
 		WithDescription("Bad Request").
-		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/responses/BadRequest"}))
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
 }
 
 // internalServerError is a wrapper for openaAPI internal server error response
 func internalServerError() *openapi3.Response {
 	return openapi3.NewResponse().
 		WithDescription("Internal Server Error").
-		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/responses/InternalServerError"}))
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
 }
 
 // notFound is a wrapper for openaAPI not found response
-func notFound() *openapi3.Response { //nolint:unused
+func notFound() *openapi3.Response {
 	return openapi3.NewResponse().
 		WithDescription("Not Found").
-		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/responses/NotFound"}))
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
 }
 
 // created is a wrapper for openaAPI created response
@@ -35,17 +51,58 @@ func created() *openapi3.Response { //nolint:unused
 }
 
 // conflict is a wrapper for openaAPI conflict response
-func conflict() *openapi3.Response { //nolint:unused
+func conflict() *openapi3.Response {
 	return openapi3.NewResponse().
 		WithDescription("Conflict").
-		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/responses/Conflict"}))
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
 }
 
 // unauthorized is a wrapper for openaAPI unauthorized response
-func unauthorized() *openapi3.Response { //nolint:unused
+func unauthorized() *openapi3.Response {
 	return openapi3.NewResponse().
 		WithDescription("Unauthorized").
-		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/responses/Unauthorized"}))
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
+}
+
+// forbidden is a wrapper for openaAPI forbidden response
+func forbidden() *openapi3.Response {
+	return openapi3.NewResponse().
+		WithDescription("Forbidden").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
+}
+
+// tooManyRequests is a wrapper for openaAPI too many requests response
+func tooManyRequests() *openapi3.Response {
+	return openapi3.NewResponse().
+		WithDescription("Too Many Requests").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
+}
+
+// badGateway is a wrapper for openaAPI bad gateway response
+func badGateway() *openapi3.Response {
+	return openapi3.NewResponse().
+		WithDescription("Bad Gateway").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
+}
+
+// serviceUnavailable is a wrapper for openaAPI service unavailable response
+func serviceUnavailable() *openapi3.Response {
+	return openapi3.NewResponse().
+		WithDescription("Service Unavailable").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(&openapi3.SchemaRef{Ref: "#/components/schemas/ErrorResponse"}))
+}
+
+// addOpenlaneRequest adds the header used to forward the openlane credentials of the caller, and the responses
+// translated from openlane errors, to an operation that makes requests to openlane
+func addOpenlaneRequest(op *openapi3.Operation) {
+	op.AddParameter(openapi3.NewHeaderParameter(OpenlaneTokenHeader).
+		WithDescription("openlane bearer token or personal access token of the caller, used when the server forwards caller credentials to openlane").
+		WithSchema(openapi3.NewStringSchema()))
+	op.AddResponse(http.StatusForbidden, forbidden())
+	op.AddResponse(http.StatusConflict, conflict())
+	op.AddResponse(http.StatusTooManyRequests, tooManyRequests())
+	op.AddResponse(http.StatusBadGateway, badGateway())
+	op.AddResponse(http.StatusServiceUnavailable, serviceUnavailable())
 }
 
 // AddRequestBody is used to add a request body definition to the OpenAPI schema
@@ -68,3 +125,16 @@ func (h *Handler) AddResponse(name string, description string, body interface{},
 	response.Content.Get(httpsling.ContentTypeJSON).Examples = make(map[string]*openapi3.ExampleRef)
 	response.Content.Get(httpsling.ContentTypeJSON).Examples["success"] = &openapi3.ExampleRef{Value: openapi3.NewExample(body)}
 }
+
+// AddAlternateResponse is used to add another response object and example to an existing response definition in the
+// OpenAPI schema, for endpoints that return a different response object depending on the request
+func (h *Handler) AddAlternateResponse(name string, example string, body interface{}, op *openapi3.Operation, status int) {
+	content := op.Responses.Status(status).Value.Content.Get(httpsling.ContentTypeJSON)
+
+	// the response objects share fields so an example can match more than one of them, which oneOf does not allow
+	content.Schema = &openapi3.SchemaRef{Value: &openapi3.Schema{AnyOf: openapi3.SchemaRefs{
+		content.Schema,
+		&openapi3.SchemaRef{Ref: "#/components/schemas/" + name},
+	}}}
+	content.Examples[example] = &openapi3.ExampleRef{Value: openapi3.NewExample(body)}
+}
@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/core/pkg/enums"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	echo "github.com/theopenlane/echox"
+)
+
+// errOrganizationNotFound is the error returned by the fake openlane client for missing organizations
+var errOrganizationNotFound = errors.New("organization not found")
+
+// fakeOpenlane is an in-memory implementation of the openlane graph client methods used by the handlers
+type fakeOpenlane struct {
+	openlaneclient.OpenlaneGraphClient
+
+	mu sync.Mutex
+	// orgs contains the organizations that currently exist, keyed by id
+	orgs map[string]openlaneclient.CreateOrganization_CreateOrganization_Organization
+	// created contains the ids of created organizations in creation order
+	created []string
+	// deleted contains the ids of deleted organizations in deletion order
+	deleted []string
+	// failCreate contains organization names that will fail to be created
+	failCreate map[string]error
+	// failDelete contains organization ids that will fail to be deleted
+	failDelete map[string]error
+	// delay is an optional function returning how long creating the named organization takes
+	delay func(name string) time.Duration
+	// inFlight is the number of creates currently in progress
+	inFlight int
+	// maxInFlight is the highest number of creates in progress at the same time
+	maxInFlight int
+	// nextID is used to generate organization ids
+	nextID int
+	// omitSettings creates organizations without returning their settings
+	omitSettings bool
+	// invites contains the invitations that were sent
+	invites []openlaneclient.CreateInviteInput
+	// groups contains the created groups, keyed by id
+	groups map[string]openlaneclient.CreateGroupInput
+	// members contains the user ids added to each group, keyed by group id
+	members map[string][]string
+	// failGroup contains group names that will fail to be created
+	failGroup map[string]error
+	// pageSize limits the number of organizations returned by GetOrganizations when it is set
+	pageSize int
+	// queries is the number of GetOrganizations requests made
+	queries int
+}
+
+// newFakeOpenlane returns a new fake openlane client with no organizations
+func newFakeOpenlane() *fakeOpenlane {
+	return &fakeOpenlane{
+		orgs:       map[string]openlaneclient.CreateOrganization_CreateOrganization_Organization{},
+		failCreate: map[string]error{},
+		failDelete: map[string]error{},
+		groups:     map[string]openlaneclient.CreateGroupInput{},
+		members:    map[string][]string{},
+		failGroup:  map[string]error{},
+	}
+}
+
+// CreateOrganization creates an organization in memory
+func (f *fakeOpenlane) CreateOrganization(ctx context.Context, input openlaneclient.CreateOrganizationInput, _ *graphql.Upload, _ ...clientv2.RequestInterceptor) (*openlaneclient.CreateOrganization, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.maxInFlight = max(f.maxInFlight, f.inFlight)
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	if f.delay != nil {
+		select {
+		case <-time.After(f.delay(input.Name)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err, ok := f.failCreate[input.Name]; ok {
+		return nil, err
+	}
+
+	f.nextID++
+
+	now := time.Now()
+
+	org := openlaneclient.CreateOrganization_CreateOrganization_Organization{
+		ID:          fmt.Sprintf("org-%03d", f.nextID),
+		Name:        input.Name,
+		Description: input.Description,
+		Tags:        input.Tags,
+		PersonalOrg: input.PersonalOrg,
+		CreatedAt:   &now,
+	}
+
+	if !f.omitSettings {
+		org.Setting = &openlaneclient.CreateOrganization_CreateOrganization_Organization_Setting{}
+	}
+
+	if input.DisplayName != nil {
+		org.DisplayName = *input.DisplayName
+	}
+
+	if input.ParentID != nil {
+		org.Parent = &openlaneclient.CreateOrganization_CreateOrganization_Organization_Parent{ID: *input.ParentID}
+	}
+
+	if input.CreateOrgSettings != nil && org.Setting != nil {
+		org.Setting.Domains = input.CreateOrgSettings.Domains
+		org.Setting.AllowedEmailDomains = input.CreateOrgSettings.AllowedEmailDomains
+		org.Setting.BillingAddress = input.CreateOrgSettings.BillingAddress
+		org.Setting.BillingContact = input.CreateOrgSettings.BillingContact
+		org.Setting.BillingEmail = input.CreateOrgSettings.BillingEmail
+		org.Setting.BillingPhone = input.CreateOrgSettings.BillingPhone
+		org.Setting.GeoLocation = input.CreateOrgSettings.GeoLocation
+		org.Setting.Tags = input.CreateOrgSettings.Tags
+		org.Setting.TaxIdentifier = input.CreateOrgSettings.TaxIdentifier
+	}
+
+	org.AvatarRemoteURL = input.AvatarRemoteURL
+
+	f.orgs[org.ID] = org
+	f.created = append(f.created, org.ID)
+
+	return &openlaneclient.CreateOrganization{
+		CreateOrganization: openlaneclient.CreateOrganization_CreateOrganization{Organization: org},
+	}, nil
+}
+
+// DeleteOrganization deletes an organization from memory
+func (f *fakeOpenlane) DeleteOrganization(_ context.Context, id string, _ ...clientv2.RequestInterceptor) (*openlaneclient.DeleteOrganization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err, ok := f.failDelete[id]; ok {
+		return nil, err
+	}
+
+	delete(f.orgs, id)
+	f.deleted = append(f.deleted, id)
+
+	return &openlaneclient.DeleteOrganization{
+		DeleteOrganization: openlaneclient.DeleteOrganization_DeleteOrganization{DeletedID: id},
+	}, nil
+}
+
+// UpdateOrganization updates the display name of an organization in memory
+func (f *fakeOpenlane) UpdateOrganization(_ context.Context, id string, input openlaneclient.UpdateOrganizationInput, _ *graphql.Upload, _ ...clientv2.RequestInterceptor) (*openlaneclient.UpdateOrganization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	org, ok := f.orgs[id]
+	if !ok {
+		return nil, errOrganizationNotFound
+	}
+
+	if input.DisplayName != nil {
+		org.DisplayName = *input.DisplayName
+	}
+
+	f.orgs[id] = org
+
+	return &openlaneclient.UpdateOrganization{
+		UpdateOrganization: openlaneclient.UpdateOrganization_UpdateOrganization{
+			Organization: openlaneclient.UpdateOrganization_UpdateOrganization_Organization{ID: org.ID, Name: org.Name, DisplayName: org.DisplayName},
+		},
+	}, nil
+}
+
+// CreateBulkInvite records the invitations in memory
+func (f *fakeOpenlane) CreateBulkInvite(_ context.Context, input []*openlaneclient.CreateInviteInput, _ ...clientv2.RequestInterceptor) (*openlaneclient.CreateBulkInvite, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := &openlaneclient.CreateBulkInvite{}
+
+	for _, in := range input {
+		f.nextID++
+		f.invites = append(f.invites, *in)
+
+		invite := &openlaneclient.CreateBulkInvite_CreateBulkInvite_Invites{
+			ID:        fmt.Sprintf("invite-%03d", f.nextID),
+			Recipient: in.Recipient,
+			Role:      enums.RoleMember,
+			Status:    enums.InvitationSent,
+		}
+
+		if in.Role != nil {
+			invite.Role = *in.Role
+		}
+
+		out.CreateBulkInvite.Invites = append(out.CreateBulkInvite.Invites, invite)
+	}
+
+	return out, nil
+}
+
+// CreateGroup creates a group in memory
+func (f *fakeOpenlane) CreateGroup(_ context.Context, input openlaneclient.CreateGroupInput, _ ...clientv2.RequestInterceptor) (*openlaneclient.CreateGroup, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err, ok := f.failGroup[input.Name]; ok {
+		return nil, err
+	}
+
+	f.nextID++
+
+	id := fmt.Sprintf("group-%03d", f.nextID)
+	f.groups[id] = input
+
+	return &openlaneclient.CreateGroup{
+		CreateGroup: openlaneclient.CreateGroup_CreateGroup{
+			Group: openlaneclient.CreateGroup_CreateGroup_Group{ID: id, Name: input.Name, DisplayName: input.Name},
+		},
+	}, nil
+}
+
+// AddUserToGroupWithRole adds a user to a group in memory
+func (f *fakeOpenlane) AddUserToGroupWithRole(_ context.Context, input openlaneclient.CreateGroupMembershipInput, _ ...clientv2.RequestInterceptor) (*openlaneclient.AddUserToGroupWithRole, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	f.members[input.GroupID] = append(f.members[input.GroupID], input.UserID)
+
+	membership := openlaneclient.AddUserToGroupWithRole_CreateGroupMembership_GroupMembership{
+		ID:      fmt.Sprintf("membership-%03d", f.nextID),
+		GroupID: input.GroupID,
+		UserID:  input.UserID,
+		Role:    enums.RoleMember,
+	}
+
+	if input.Role != nil {
+		membership.Role = *input.Role
+	}
+
+	return &openlaneclient.AddUserToGroupWithRole{
+		CreateGroupMembership: openlaneclient.AddUserToGroupWithRole_CreateGroupMembership{GroupMembership: membership},
+	}, nil
+}
+
+// GetOrganizationByID returns an organization from memory
+func (f *fakeOpenlane) GetOrganizationByID(_ context.Context, id string, _ ...clientv2.RequestInterceptor) (*openlaneclient.GetOrganizationByID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	org, ok := f.orgs[id]
+	if !ok {
+		return nil, errOrganizationNotFound
+	}
+
+	out := openlaneclient.GetOrganizationByID_Organization{
+		ID:          org.ID,
+		Name:        org.Name,
+		DisplayName: org.DisplayName,
+		Description: org.Description,
+		Tags:        org.Tags,
+		CreatedAt:   org.CreatedAt,
+	}
+
+	if org.Setting != nil {
+		out.Setting = &openlaneclient.GetOrganizationByID_Organization_Setting{Domains: org.Setting.Domains}
+	}
+
+	if org.Parent != nil {
+		out.Parent = &openlaneclient.GetOrganizationByID_Organization_Parent{ID: org.Parent.ID}
+	}
+
+	return &openlaneclient.GetOrganizationByID{Organization: out}, nil
+}
+
+// GetOrganizations returns the organizations from memory matching the where filter ordered by id, at most pageSize at a
+// time when it is set
+func (f *fakeOpenlane) GetOrganizations(_ context.Context, where *openlaneclient.OrganizationWhereInput, _ ...clientv2.RequestInterceptor) (*openlaneclient.GetOrganizations, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.queries++
+
+	out := &openlaneclient.GetOrganizations{}
+
+	for _, id := range f.created {
+		org, ok := f.orgs[id]
+		if !ok || !matches(org, where) {
+			continue
+		}
+
+		if f.pageSize > 0 && len(out.Organizations.Edges) == f.pageSize {
+			break
+		}
+
+		node := &openlaneclient.GetOrganizations_Organizations_Edges_Node{
+			ID:          org.ID,
+			Name:        org.Name,
+			DisplayName: org.DisplayName,
+			Description: org.Description,
+			Tags:        org.Tags,
+			PersonalOrg: org.PersonalOrg,
+			CreatedAt:   org.CreatedAt,
+		}
+
+		if org.Setting != nil {
+			node.Setting = &openlaneclient.GetOrganizations_Organizations_Edges_Node_Setting{Domains: org.Setting.Domains}
+		}
+
+		if org.Parent != nil {
+			node.Parent = &openlaneclient.GetOrganizations_Organizations_Edges_Node_Parent{ID: org.Parent.ID}
+		}
+
+		out.Organizations.Edges = append(out.Organizations.Edges, &openlaneclient.GetOrganizations_Organizations_Edges{Node: node})
+	}
+
+	return out, nil
+}
+
+// matches returns true when the organization matches the id, parent, and personal organization predicates of the where
+// filter, every organization matches when there is no filter
+func matches(org openlaneclient.CreateOrganization_CreateOrganization_Organization, where *openlaneclient.OrganizationWhereInput) bool {
+	if where == nil {
+		return true
+	}
+
+	if where.IDGt != nil && org.ID <= *where.IDGt {
+		return false
+	}
+
+	if where.ParentOrganizationIDIsNil != nil && *where.ParentOrganizationIDIsNil != (org.Parent == nil) {
+		return false
+	}
+
+	if where.PersonalOrg != nil && *where.PersonalOrg != (org.PersonalOrg != nil && *org.PersonalOrg) {
+		return false
+	}
+
+	if where.ParentOrganizationIDIn == nil && where.ParentOrganizationID == nil {
+		return true
+	}
+
+	return org.Parent != nil && (slices.Contains(where.ParentOrganizationIDIn, org.Parent.ID) ||
+		(where.ParentOrganizationID != nil && *where.ParentOrganizationID == org.Parent.ID))
+}
+
+// newTestHandler returns a handler backed by the fake openlane client
+func newTestHandler(f *fakeOpenlane) *Handler {
+	return &Handler{
+		IsTest:         true,
+		OpenlaneClient: &openlaneclient.OpenlaneClient{OpenlaneGraphClient: f},
+	}
+}
+
+// newTestContext returns an echo context and response recorder for the request
+func newTestContext(t *testing.T, method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+
+	ctx := echo.New().NewContext(req, rec)
+
+	return ctx, rec
+}
+
+// requireStatus checks the status code of the response recorder
+func requireStatus(t *testing.T, rec *httptest.ResponseRecorder, status int) {
+	t.Helper()
+
+	require.Equal(t, status, rec.Code, http.StatusText(status)+": "+rec.Body.String())
+}
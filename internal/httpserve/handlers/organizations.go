@@ -2,21 +2,24 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/rs/zerolog/log"
+	"github.com/theopenlane/core/pkg/enums"
 	"github.com/theopenlane/core/pkg/openlaneclient"
 	echo "github.com/theopenlane/echox"
 	"github.com/theopenlane/utils/rout"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
 	"github.com/theopenlane/openlane-cloud/internal/v1/models"
-)
-
-const (
-	relationBucketName = "relationships"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
 )
 
 // OrganizationHandler is the handler for the organization endpoint
@@ -30,99 +33,275 @@ func (h *Handler) OrganizationHandler(ctx echo.Context) error {
 		return h.InvalidInput(ctx, err)
 	}
 
+	bp, err := h.resolveOrganizationBlueprint(in)
+	if err != nil {
+		return h.BadRequest(ctx, err)
+	}
+
+	if err := validateSize("template", bp); err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	if err := validateGroups(bp, in.Groups); err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	reqCtx, err := h.requestContext(ctx)
+	if err != nil {
+		return h.Unauthorized(ctx, err)
+	}
+
+	// a dry run does not create anything so it is not tracked by the idempotency key
+	if in.DryRun {
+		out, err := h.planOrganization(reqCtx, in, bp)
+		if err != nil {
+			return h.openlaneFailure(ctx, err, h.InternalServerError)
+		}
+
+		return h.Success(ctx, out)
+	}
+
 	log.Debug().Str("name", in.Name).
-		Strs("environments", in.Environments).
-		Strs("buckets", in.Buckets).
-		Strs("relationships", in.Relationships).
+		Str("template", bp.ID()).
+		Int("organizations", bp.Count()).
 		Msg("creating organization")
 
-	// create root organization
-	rootOrgName := in.Name
-	input := openlaneclient.CreateOrganizationInput{
-		Name:        strings.ToLower(rootOrgName),
-		DisplayName: &rootOrgName,
+	return h.withIdempotency(ctx, in, func() error {
+		if async, _ := strconv.ParseBool(ctx.QueryParam("async")); async {
+			return h.submitOrganizationJob(ctx, reqCtx, in, bp)
+		}
+
+		out, err := h.provisionOrganization(reqCtx, in, bp, nil)
+		if err != nil {
+			var perr *ProvisioningError
+			if errors.As(err, &perr) {
+				return h.ProvisioningFailed(ctx, perr)
+			}
+
+			return h.openlaneFailure(ctx, err, h.BadRequest)
+		}
+
+		return h.Success(ctx, out)
+	})
+}
+
+// submitOrganizationJob queues the provisioning request to be processed in the background using the request context
+// and returns the job
+func (h *Handler) submitOrganizationJob(ctx echo.Context, reqCtx context.Context, in models.OrganizationRequest, bp blueprint.Blueprint) error {
+	if h.Jobs == nil {
+		return h.BadRequest(ctx, ErrAsyncDisabled)
 	}
 
-	if in.Description != "" {
-		input.Description = &in.Description
+	// the total includes the root organization
+	job, err := h.Jobs.Submit(reqCtx, 1+bp.Count(), func(jobCtx context.Context, progress func(models.OrgDetails)) (*models.OrganizationReply, error) {
+		return h.provisionOrganization(jobCtx, in, bp, progress)
+	})
+	if err != nil {
+		return h.TooManyRequests(ctx, err)
 	}
 
-	if len(in.Domains) > 0 {
-		input.CreateOrgSettings = &openlaneclient.CreateOrganizationSettingInput{
-			Domains: in.Domains,
+	ctx.Response().Header().Set(echo.HeaderLocation, "/v1/organization/jobs/"+job.ID)
+
+	return h.Accepted(ctx, models.ProvisioningJobReply{
+		Reply: rout.Reply{Success: true},
+		Job:   job,
+	})
+}
+
+// OrganizationJobHandler is the handler for the organization provisioning job status endpoint
+func (h *Handler) OrganizationJobHandler(ctx echo.Context) error {
+	if h.Jobs == nil {
+		return h.NotFound(ctx, ErrJobNotFound)
+	}
+
+	job, err := h.Jobs.Get(ctx.Request().Context(), ctx.PathParam("id"))
+	if err != nil {
+		return h.NotFound(ctx, err)
+	}
+
+	return h.Success(ctx, models.ProvisioningJobReply{
+		Reply: rout.Reply{Success: true},
+		Job:   job,
+	})
+}
+
+// provisionOrganization creates the root organization and the full blueprint hierarchy for the request; if any step fails
+// every organization created so far is deleted and a ProvisioningError is returned. The outcome is recorded in the audit
+// log and the optional progress function is called every time an organization is created
+func (h *Handler) provisionOrganization(ctx context.Context, in models.OrganizationRequest, bp blueprint.Blueprint, progress func(models.OrgDetails)) (*models.OrganizationReply, error) {
+	tracker := newProvisioningTracker(h.Concurrency, progress)
+
+	out, err := h.createOrganizationHierarchy(ctx, in, bp, tracker)
+	if err != nil {
+		perr := h.rollback(ctx, tracker, err)
+
+		entry := models.AuditEntry{
+			Action:  models.AuditActionCreate,
+			Created: organizationIDs(perr.Created),
+			Deleted: organizationIDs(perr.RolledBack),
+		}
+
+		// the root organization is always created first
+		if len(perr.Created) > 0 {
+			entry.OrganizationID = perr.Created[0].ID
 		}
+
+		h.audit(ctx, entry, in, perr)
+
+		return nil, perr
 	}
 
-	ws, err := h.OpenlaneClient.CreateOrganization(ctx.Request().Context(), input, nil)
+	h.audit(ctx, models.AuditEntry{
+		Action:         models.AuditActionCreate,
+		OrganizationID: out.ID,
+		Created:        organizationIDs(tracker.createdOrganizations()),
+	}, in, nil)
+
+	h.notify(webhook.EventOrganizationCreated, models.OrganizationEvent{Organization: out})
+
+	return out, nil
+}
+
+// createOrganizationHierarchy creates the root organization and every organization in the blueprint underneath it
+// recording every created organization on the tracker
+func (h *Handler) createOrganizationHierarchy(ctx context.Context, in models.OrganizationRequest, bp blueprint.Blueprint, tracker *provisioningTracker) (*models.OrganizationReply, error) {
+	// create root organization
+	input := rootOrganizationInput(in)
+
+	organization, err := h.createOrganization(ctx, input, tracker)
 	if err != nil {
-		return h.BadRequest(ctx, err)
+		return nil, err
 	}
 
-	organization := ws.CreateOrganization.Organization
+	root := createdOrgDetails(organization)
 
-	out := models.OrganizationReply{
+	out := &models.OrganizationReply{
 		Reply:       rout.Reply{Success: true},
-		ID:          organization.ID,
-		Name:        organization.DisplayName,
-		Description: *organization.Description,
-		Domains:     organization.Setting.Domains,
+		OrgDetails:  root,
+		Description: stringValue(organization.Description),
+		Domains:     domains(root.Settings),
+		Template:    bp.ID(),
 	}
 
-	// create environments
-	envOrgs, err := h.createEnvironments(ctx.Request().Context(), organization.ID, in.Environments, input)
+	// create the blueprint hierarchy underneath the root organization
+	children, err := h.createChildOrganizations(ctx, input.Name, organization.ID, []string{}, bp.Children, tracker)
 	if err != nil {
-		return h.BadRequest(ctx, err)
+		return nil, err
 	}
 
-	// for each environment, create buckets
-	for i, envOrg := range envOrgs {
-		// add environments to the response
-		out.Environments = append(out.Environments, models.Environment{
-			OrgDetails: models.OrgDetails{
-				ID:   envOrg.ID,
-				Name: envOrg.DisplayName,
-			},
-			Buckets: []models.Bucket{},
-		})
+	// create the groups and invite the users once the hierarchy exists
+	out.Invites, out.Groups, err = h.createMembers(ctx, in, organization.ID, children, tracker)
+	if err != nil {
+		return nil, err
+	}
 
-		// create buckets
-		bucketOrgs, err := h.createBuckets(ctx.Request().Context(), envOrg.ID, envOrg.DisplayName, in.Buckets, input)
-		if err != nil {
-			return h.BadRequest(ctx, err)
+	// the default blueprint keeps the environments, buckets, and relations response
+	if bp.Name == blueprint.DefaultName {
+		out.Environments = environmentsFromNodes(children)
+	} else {
+		out.Children = children
+	}
+
+	return out, nil
+}
+
+// environmentsFromNodes converts the hierarchy created by the default blueprint to environments, buckets, and relations
+func environmentsFromNodes(nodes []models.OrgNode) []models.Environment {
+	envs := make([]models.Environment, 0, len(nodes))
+
+	for _, envNode := range nodes {
+		env := models.Environment{
+			OrgDetails: envNode.OrgDetails,
+			Buckets:    []models.Bucket{},
 		}
 
-		for j, bucketOrg := range bucketOrgs {
-			// add buckets to the response
-			out.Environments[i].Buckets = append(out.Environments[i].Buckets, models.Bucket{
-				OrgDetails: models.OrgDetails{
-					ID:   bucketOrg.ID,
-					Name: bucketOrg.DisplayName,
-				},
-			})
-
-			// create relationships under the relationships bucket
-			if bucketOrg.DisplayName == relationBucketName {
-				relationshipOrgs, err := h.createRelationships(ctx.Request().Context(), bucketOrg.ID, envOrg.DisplayName, in.Relationships, input)
-				if err != nil {
-					return h.BadRequest(ctx, err)
-				}
-
-				out.Environments[i].Buckets[j].Relations = []models.Relationship{}
-
-				for _, relationshipOrg := range relationshipOrgs {
-					// add relationships to the response
-					out.Environments[i].Buckets[j].Relations = append(out.Environments[i].Buckets[j].Relations, models.Relationship{
-						OrgDetails: models.OrgDetails{
-							ID:   relationshipOrg.ID,
-							Name: relationshipOrg.DisplayName,
-						},
-					})
-				}
+		for _, bucketNode := range envNode.Children {
+			bucket := models.Bucket{
+				OrgDetails: bucketNode.OrgDetails,
+			}
+
+			for _, relationNode := range bucketNode.Children {
+				bucket.Relations = append(bucket.Relations, models.Relationship{OrgDetails: relationNode.OrgDetails})
 			}
+
+			env.Buckets = append(env.Buckets, bucket)
 		}
+
+		envs = append(envs, env)
+	}
+
+	return envs
+}
+
+// inheritSettingsDepth is the number of levels below the root that inherit settings, the environments and buckets of the
+// default hierarchy
+const inheritSettingsDepth = 2
+
+// resolveOrganizationBlueprint returns the blueprint for an organization request with the settings named in
+// settings.inherit applied to the environments and buckets, or the first two levels of a template
+func (h *Handler) resolveOrganizationBlueprint(in models.OrganizationRequest) (blueprint.Blueprint, error) {
+	bp, err := h.resolveBlueprint(in.Template, in.Environments, in.Buckets, in.Relationships)
+	if err != nil {
+		return bp, err
+	}
+
+	if in.Settings == nil || len(in.Settings.Inherit) == 0 {
+		return bp, nil
+	}
+
+	inherited := in.Settings.Inherited()
+
+	return bp.InheritSettings(blueprintSettings(&inherited), inheritSettingsDepth), nil
+}
+
+// resolveBlueprint returns the blueprint for a request; the environments, buckets, and relationships describe a custom
+// default hierarchy when any of them are set, otherwise the template is selected from the registry
+func (h *Handler) resolveBlueprint(template string, environments, buckets, relationships []string) (blueprint.Blueprint, error) {
+	if environments != nil || buckets != nil || relationships != nil {
+		return blueprint.Standard(environments, buckets, relationships), nil
+	}
+
+	registry := h.Blueprints
+	if registry == nil {
+		registry = blueprint.Embedded()
+	}
+
+	if template == "" {
+		template = blueprint.DefaultName
 	}
 
-	return h.Success(ctx, out)
+	return registry.Get(template)
+}
+
+// validateSize ensures the blueprint does not create more organizations than are allowed for a single request
+func validateSize(field string, bp blueprint.Blueprint) error {
+	if count := bp.Count(); count > models.MaxOrganizations {
+		return &models.ValidationError{Fields: []models.FieldError{{
+			Field:   field,
+			Message: fmt.Sprintf("the hierarchy would contain %d organizations, the maximum is %d", count, models.MaxOrganizations),
+		}}}
+	}
+
+	return nil
+}
+
+// GetOrganizationHandler is the handler for returning an existing organization hierarchy
+func (h *Handler) GetOrganizationHandler(ctx echo.Context) error {
+	reqCtx, err := h.requestContext(ctx)
+	if err != nil {
+		return h.Unauthorized(ctx, err)
+	}
+
+	tree, err := h.loadHierarchy(reqCtx, ctx.PathParam("id"), true)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			return h.NotFound(ctx, err)
+		}
+
+		return h.openlaneFailure(ctx, err, h.InternalServerError)
+	}
+
+	return h.Success(ctx, tree.reply())
 }
 
 // BindOrganizationHandler is used to bind the organization endpoint to the OpenAPI schema
@@ -130,55 +309,249 @@ func (h *Handler) BindOrganizationHandler() *openapi3.Operation {
 	register := openapi3.NewOperation()
 	register.Description = "Organization creates an opinionated organization hierarchy for the new organization"
 	register.OperationID = "OrganizationHandler"
-	register.Security = &openapi3.SecurityRequirements{}
+	register.Security = authenticated()
+
+	register.AddParameter(openapi3.NewQueryParameter("async").
+		WithDescription("provision the hierarchy in the background and return a job that can be polled for status").
+		WithSchema(openapi3.NewBoolSchema()))
+	register.AddParameter(openapi3.NewHeaderParameter(IdempotencyKeyHeader).
+		WithDescription("unique key for the request, retries with the same key replay the original response instead of creating a new hierarchy").
+		WithSchema(openapi3.NewStringSchema()))
 
 	h.AddRequestBody("OrganizationRequest", models.ExampleOrganizationSuccessRequest, register)
 	h.AddResponse("OrganizationReply", "success", models.ExampleOrganizationSuccessResponse, register, http.StatusOK)
+	h.AddAlternateResponse("OrganizationPlanReply", "dryRun", models.ExampleOrganizationPlanResponse, register, http.StatusOK)
+	h.AddResponse("ProvisioningJobReply", "provisioning job accepted", models.ExampleProvisioningJobResponse, register, http.StatusAccepted)
+	h.AddResponse("ProvisioningFailureReply", "provisioning failed and created organizations were rolled back", models.ExampleProvisioningFailureResponse, register, http.StatusUnprocessableEntity)
 	register.AddResponse(http.StatusInternalServerError, internalServerError())
-	register.AddResponse(http.StatusBadRequest, badRequest())
+	register.AddResponse(http.StatusUnauthorized, unauthorized())
+	addOpenlaneRequest(register)
+	h.AddResponse("ValidationFailureReply", "the request has invalid fields", models.ExampleValidationFailureResponse, register, http.StatusBadRequest)
+	register.AddResponse(http.StatusConflict, conflict())
+	register.AddResponse(http.StatusTooManyRequests, tooManyRequests())
 
 	return register
 }
 
-// createChildOrganizations creates the child organizations for the organization
-func (h *Handler) createChildOrganizations(ctx context.Context, namePrefix, parentOrgID string, childNames, additionalTags []string) ([]openlaneclient.CreateOrganization_CreateOrganization_Organization, error) {
-	var orgs []openlaneclient.CreateOrganization_CreateOrganization_Organization
+// BindGetOrganizationHandler is used to bind the get organization endpoint to the OpenAPI schema
+func (h *Handler) BindGetOrganizationHandler() *openapi3.Operation {
+	org := openapi3.NewOperation()
+	org.Description = "GetOrganization returns an existing organization hierarchy created by openlane-cloud"
+	org.OperationID = "GetOrganizationHandler"
+	org.Security = authenticated()
 
-	for _, childName := range childNames {
-		log.Debug().Str("childName", childName).Msg("creating child organization")
+	org.AddParameter(openapi3.NewPathParameter("id").
+		WithDescription("the id of the root organization").
+		WithSchema(openapi3.NewStringSchema()))
 
-		orgName := childName
+	h.AddResponse("OrganizationReply", "success", models.ExampleOrganizationSuccessResponse, org, http.StatusOK)
+	org.AddResponse(http.StatusInternalServerError, internalServerError())
+	org.AddResponse(http.StatusUnauthorized, unauthorized())
+	addOpenlaneRequest(org)
+	org.AddResponse(http.StatusNotFound, notFound())
 
-		input := openlaneclient.CreateOrganizationInput{
-			Name:        strings.ToLower(fmt.Sprintf("%s.%s", namePrefix, orgName)),
-			DisplayName: &orgName,
-			ParentID:    &parentOrgID,
-			Tags:        append(additionalTags, orgName),
-		}
+	return org
+}
 
-		// create child organization
-		o, err := h.OpenlaneClient.CreateOrganization(ctx, input, nil)
-		if err != nil {
-			return nil, err
-		}
+// BindOrganizationJobHandler is used to bind the organization job status endpoint to the OpenAPI schema
+func (h *Handler) BindOrganizationJobHandler() *openapi3.Operation {
+	job := openapi3.NewOperation()
+	job.Description = "OrganizationJob returns the progress and result of an asynchronous organization provisioning job submitted by the caller"
+	job.OperationID = "OrganizationJobHandler"
+	job.Security = authenticated()
+
+	job.AddParameter(openapi3.NewPathParameter("id").
+		WithDescription("the id of the provisioning job").
+		WithSchema(openapi3.NewStringSchema()))
+
+	h.AddResponse("ProvisioningJobReply", "success", models.ExampleProvisioningJobResponse, job, http.StatusOK)
+	job.AddResponse(http.StatusInternalServerError, internalServerError())
+	job.AddResponse(http.StatusUnauthorized, unauthorized())
+	job.AddResponse(http.StatusNotFound, notFound())
+
+	return job
+}
+
+// createChildOrganizations creates the organizations for the blueprint nodes and all of their descendants underneath
+// the parent organization; siblings are created in parallel and returned in the same order as the nodes. The first
+// failure stops any further creates from starting, creates already in progress finish so they are tracked and rolled back
+func (h *Handler) createChildOrganizations(ctx context.Context, namePrefix, parentOrgID string, ancestors []string, nodes []blueprint.Node, tracker *provisioningTracker) ([]models.OrgNode, error) {
+	orgs := make([]models.OrgNode, len(nodes))
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	for i, node := range nodes {
+		g.Go(func() error {
+			log.Debug().Str("childName", node.Name).Msg("creating child organization")
+
+			path := append(slices.Clone(ancestors), node.Name)
+			input := childOrganizationInput(namePrefix, parentOrgID, path, node)
+
+			// create child organization
+			o, err := h.createOrganization(gctx, input, tracker)
+			if err != nil {
+				return err
+			}
+
+			children, err := h.createChildOrganizations(gctx, input.Name, o.ID, path, node.Children, tracker)
+			if err != nil {
+				return err
+			}
+
+			orgs[i] = models.OrgNode{
+				OrgDetails: createdOrgDetails(o),
+				Children:   children,
+			}
 
-		orgs = append(orgs, o.CreateOrganization.Organization)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
 	return orgs, nil
 }
 
-// createEnvironments creates the environments for the organization
-func (h *Handler) createEnvironments(ctx context.Context, rootOrgID string, environments []string, input openlaneclient.CreateOrganizationInput) ([]openlaneclient.CreateOrganization_CreateOrganization_Organization, error) {
-	return h.createChildOrganizations(ctx, input.Name, rootOrgID, environments, []string{})
+// rootOrganizationInput returns the input used to create the root organization for the request, the unique name is the
+// lower case name of the organization
+func rootOrganizationInput(in models.OrganizationRequest) openlaneclient.CreateOrganizationInput {
+	input := openlaneclient.CreateOrganizationInput{
+		Name:        strings.ToLower(in.Name),
+		DisplayName: &in.Name,
+	}
+
+	if in.Description != "" {
+		input.Description = &in.Description
+	}
+
+	if len(in.Domains) > 0 || in.Settings != nil {
+		settings := rootSettings(in)
+
+		input.CreateOrgSettings = orgSettings(&settings)
+		input.AvatarRemoteURL = avatarURL(&settings)
+	}
+
+	return input
+}
+
+// rootSettings returns the blueprint settings of the root organization for the request, combining the domains with the
+// settings block
+func rootSettings(in models.OrganizationRequest) blueprint.Settings {
+	out := blueprintSettings(in.Settings)
+	out.Domains = in.Domains
+
+	return out
+}
+
+// blueprintSettings converts the settings of a request to blueprint settings, so they can be applied to the root and
+// inherited by the blueprint nodes in the same way as the settings of a template
+func blueprintSettings(s *models.OrganizationSettings) blueprint.Settings {
+	if s == nil {
+		return blueprint.Settings{}
+	}
+
+	return blueprint.Settings{
+		AllowedEmailDomains:         s.AllowedEmailDomains,
+		AvatarURL:                   s.AvatarURL,
+		BillingAddress:              s.BillingAddress,
+		BillingContact:              s.BillingContact,
+		BillingEmail:                s.BillingEmail,
+		BillingNotificationsEnabled: s.BillingNotificationsEnabled,
+		BillingPhone:                s.BillingPhone,
+		GeoLocation:                 s.GeoLocation,
+		TaxIdentifier:               s.TaxIdentifier,
+		Tags:                        s.Tags,
+	}
+}
+
+// childOrganizationInput returns the input used to create the organization for the blueprint node underneath the parent;
+// the unique name is prefixed with the name of the parent and the organization is tagged with its path from the root
+// followed by any tags on the node
+func childOrganizationInput(namePrefix, parentOrgID string, path []string, node blueprint.Node) openlaneclient.CreateOrganizationInput {
+	input := openlaneclient.CreateOrganizationInput{
+		Name:              strings.ToLower(fmt.Sprintf("%s.%s", namePrefix, node.Name)),
+		DisplayName:       &node.Name,
+		ParentID:          &parentOrgID,
+		Tags:              append(slices.Clone(path), node.Tags...),
+		CreateOrgSettings: orgSettings(node.Settings),
+		AvatarRemoteURL:   avatarURL(node.Settings),
+	}
+
+	if node.Description != "" {
+		input.Description = &node.Description
+	}
+
+	return input
+}
+
+// orgSettings returns the organization settings input for the blueprint settings
+func orgSettings(s *blueprint.Settings) *openlaneclient.CreateOrganizationSettingInput {
+	if s == nil {
+		return nil
+	}
+
+	input := &openlaneclient.CreateOrganizationSettingInput{
+		Domains:                     s.Domains,
+		AllowedEmailDomains:         s.AllowedEmailDomains,
+		Tags:                        s.Tags,
+		BillingAddress:              s.BillingAddress,
+		BillingNotificationsEnabled: s.BillingNotificationsEnabled,
+	}
+
+	if s.BillingContact != "" {
+		input.BillingContact = &s.BillingContact
+	}
+
+	if s.BillingEmail != "" {
+		input.BillingEmail = &s.BillingEmail
+	}
+
+	if s.BillingPhone != "" {
+		input.BillingPhone = &s.BillingPhone
+	}
+
+	if s.TaxIdentifier != "" {
+		input.TaxIdentifier = &s.TaxIdentifier
+	}
+
+	if s.GeoLocation != "" {
+		input.GeoLocation = enums.ToRegion(s.GeoLocation)
+	}
+
+	return input
 }
 
-// createBuckets creates the buckets for the organization for each environment
-func (h *Handler) createBuckets(ctx context.Context, envOrgID, environment string, buckets []string, input openlaneclient.CreateOrganizationInput) ([]openlaneclient.CreateOrganization_CreateOrganization_Organization, error) {
-	return h.createChildOrganizations(ctx, fmt.Sprintf("%s.%s", input.Name, environment), envOrgID, buckets, []string{environment})
+// avatarURL returns the avatar url from the blueprint settings, which is set on the organization rather than its settings
+func avatarURL(s *blueprint.Settings) *string {
+	if s == nil || s.AvatarURL == "" {
+		return nil
+	}
+
+	return &s.AvatarURL
 }
 
-// createRelationships creates the relationships for the organization for each environment
-func (h *Handler) createRelationships(ctx context.Context, relationshipOrgID, environment string, relationships []string, input openlaneclient.CreateOrganizationInput) ([]openlaneclient.CreateOrganization_CreateOrganization_Organization, error) {
-	return h.createChildOrganizations(ctx, fmt.Sprintf("%s.%s.%s", input.Name, environment, "relationships"), relationshipOrgID, relationships, []string{environment, "relationships"})
+// createOrganization creates a single organization in openlane once a slot is available within the request's concurrency
+// limit and records it on the tracker; the context only stops the create from starting, once it is sent to openlane it
+// is not canceled so an organization created by openlane is never missing from the tracker
+func (h *Handler) createOrganization(ctx context.Context, input openlaneclient.CreateOrganizationInput, tracker *provisioningTracker) (*openlaneclient.CreateOrganization_CreateOrganization_Organization, error) {
+	release, err := tracker.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	ctx = context.WithoutCancel(ctx)
+
+	o, err := h.openlane(ctx).CreateOrganization(ctx, input, nil)
+	if err != nil {
+		return nil, openlaneError(err)
+	}
+
+	org := o.CreateOrganization.Organization
+
+	tracker.add(createdOrgDetails(&org))
+
+	return &org, nil
 }
@@ -0,0 +1,1239 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/core/pkg/enums"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/idempotency"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+var errUpstream = errors.New("upstream failure")
+
+func TestOrganizationHandler(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","description":"meow"}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.True(t, out.Success)
+	assert.Equal(t, "MITB Inc.", out.Name)
+	assert.Equal(t, "default@1", out.Template)
+	assert.Empty(t, out.Children)
+	require.Len(t, out.Environments, 2)
+
+	for _, env := range out.Environments {
+		require.Len(t, env.Buckets, 5)
+
+		for _, bucket := range env.Buckets {
+			if bucket.Name == "relationships" {
+				assert.Len(t, bucket.Relations, 5)
+			} else {
+				assert.Empty(t, bucket.Relations)
+			}
+		}
+	}
+
+	// 1 root + 2 environments + 10 buckets + 10 relationships
+	assert.Len(t, f.created, 23)
+	assert.Empty(t, f.deleted)
+}
+
+func TestOrganizationHandlerDetails(t *testing.T) {
+	f := newFakeOpenlane()
+	f.omitSettings = true
+
+	h := newTestHandler(f)
+
+	// openlane returns no description or settings for the organizations
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","environments":["production"],"buckets":["relationships"],"relationships":["vendors"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.Equal(t, "MITB Inc.", out.Name)
+	assert.Equal(t, "mitb inc.", out.Slug)
+	assert.Empty(t, out.Description)
+	assert.Empty(t, out.ParentID)
+	assert.Nil(t, out.Settings)
+	assert.NotNil(t, out.CreatedAt)
+
+	require.Len(t, out.Environments, 1)
+	require.Len(t, out.Environments[0].Buckets, 1)
+	require.Len(t, out.Environments[0].Buckets[0].Relations, 1)
+
+	env := out.Environments[0]
+	assert.Equal(t, "mitb inc..production", env.Slug)
+	assert.Equal(t, out.ID, env.ParentID)
+	assert.Equal(t, []string{"production"}, env.Tags)
+	assert.NotNil(t, env.CreatedAt)
+
+	relation := env.Buckets[0].Relations[0]
+	assert.Equal(t, "vendors", relation.Name)
+	assert.Equal(t, "mitb inc..production.relationships.vendors", relation.Slug)
+	assert.Equal(t, env.Buckets[0].ID, relation.ParentID)
+	assert.Equal(t, []string{"production", "relationships", "vendors"}, relation.Tags)
+
+	// the same details are returned for the existing hierarchy
+	ctx, rec = newTestContext(t, http.MethodGet, "/v1/organization/"+out.ID, "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: out.ID}})
+
+	require.NoError(t, h.GetOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var got models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+
+	assert.Equal(t, out.Slug, got.Slug)
+	assert.Nil(t, got.Settings)
+	require.Len(t, got.Environments, 1)
+	assert.Equal(t, env.Slug, got.Environments[0].Slug)
+	assert.Equal(t, env.ParentID, got.Environments[0].ParentID)
+	assert.Equal(t, relation.Tags, got.Environments[0].Buckets[0].Relations[0].Tags)
+}
+
+func TestOrganizationHandlerSettings(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{
+		"name": "MITB Inc.",
+		"environments": ["production"],
+		"buckets": ["relationships"],
+		"relationships": ["vendors"],
+		"settings": {
+			"billingContact": "Meow",
+			"billingEmail": "billing@mitb.com",
+			"geoLocation": "emea",
+			"taxIdentifier": "123-45-6789",
+			"avatarURL": "https://mitb.com/avatar.png",
+			"tags": ["customer"],
+			"inherit": ["billingEmail", "geoLocation"]
+		}
+	}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	require.NotNil(t, out.Settings)
+	assert.Equal(t, "Meow", out.Settings.BillingContact)
+	assert.Equal(t, "billing@mitb.com", out.Settings.BillingEmail)
+
+	root := f.orgs[out.ID]
+	require.NotNil(t, root.AvatarRemoteURL)
+	assert.Equal(t, "https://mitb.com/avatar.png", *root.AvatarRemoteURL)
+	assert.Equal(t, []string{"customer"}, root.Setting.Tags)
+	assert.Equal(t, "123-45-6789", *root.Setting.TaxIdentifier)
+	assert.Equal(t, enums.Emea, *root.Setting.GeoLocation)
+
+	require.Len(t, out.Environments, 1)
+	require.Len(t, out.Environments[0].Buckets, 1)
+	require.Len(t, out.Environments[0].Buckets[0].Relations, 1)
+
+	// only the inherited settings are applied to the environments and buckets
+	for _, id := range []string{out.Environments[0].ID, out.Environments[0].Buckets[0].ID} {
+		org := f.orgs[id]
+
+		require.NotNil(t, org.Setting.BillingEmail)
+		assert.Equal(t, "billing@mitb.com", *org.Setting.BillingEmail)
+		assert.Equal(t, enums.Emea, *org.Setting.GeoLocation)
+		assert.Nil(t, org.Setting.BillingContact)
+		assert.Nil(t, org.Setting.TaxIdentifier)
+		assert.Nil(t, org.AvatarRemoteURL)
+	}
+
+	// the relationships are below the levels that inherit settings
+	relation := f.orgs[out.Environments[0].Buckets[0].Relations[0].ID]
+	assert.Nil(t, relation.Setting.BillingEmail)
+	assert.Nil(t, relation.Setting.GeoLocation)
+}
+
+func TestOrganizationHandlerTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "retail.yaml"), []byte(`
+name: retail
+version: 1
+children:
+  - name: stores
+    description: physical locations
+    tags: [physical]
+    children:
+      - name: east
+        children:
+          - name: boston
+      - name: west
+  - name: online
+    settings:
+      domains: [shop.meow.com]
+`), 0600))
+
+	registry, err := blueprint.NewRegistry(dir)
+	require.NoError(t, err)
+
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+	h.Blueprints = registry
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow","template":"retail"}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.Equal(t, "retail@1", out.Template)
+	assert.Empty(t, out.Environments)
+	require.Len(t, out.Children, 2)
+	assert.Equal(t, "stores", out.Children[0].Name)
+	assert.Equal(t, "online", out.Children[1].Name)
+	require.Len(t, out.Children[0].Children, 2)
+	require.Len(t, out.Children[0].Children[0].Children, 1)
+	assert.Equal(t, "boston", out.Children[0].Children[0].Children[0].Name)
+
+	// 1 root + 2 children + 2 regions + 1 city
+	assert.Len(t, f.created, 6)
+
+	boston := f.orgs[out.Children[0].Children[0].Children[0].ID]
+	assert.Equal(t, "meow.stores.east.boston", boston.Name)
+	assert.Equal(t, []string{"stores", "east", "boston"}, boston.Tags)
+	assert.Equal(t, out.Children[0].Children[0].ID, boston.Parent.ID)
+
+	stores := f.orgs[out.Children[0].ID]
+	assert.Equal(t, []string{"stores", "physical"}, stores.Tags)
+	assert.Equal(t, "physical locations", *stores.Description)
+
+	online := f.orgs[out.Children[1].ID]
+	assert.Equal(t, []string{"shop.meow.com"}, online.Setting.Domains)
+}
+
+func TestOrganizationHandlerTemplateInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "unknown template",
+			body: `{"name":"meow","template":"missing"}`,
+		},
+		{
+			name: "unknown version",
+			body: `{"name":"meow","template":"default@2"}`,
+		},
+		{
+			name: "template with environments",
+			body: `{"name":"meow","template":"default","environments":["production"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFakeOpenlane()
+			h := newTestHandler(f)
+
+			ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", tc.body)
+
+			require.Error(t, h.OrganizationHandler(ctx))
+			requireStatus(t, rec, http.StatusBadRequest)
+			assert.Empty(t, f.created)
+		})
+	}
+}
+
+func TestOrganizationHandlerValidation(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization",
+		`{"name":"meow/cats","domains":["meow"],"environments":["prod.east","production"],"relationships":["relationships"]}`)
+
+	require.Error(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusBadRequest)
+	assert.Empty(t, f.created)
+
+	var out models.ValidationFailureReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.False(t, out.Success)
+	assert.Equal(t, InvalidInputErrCode, out.ErrorCode)
+
+	fields := []string{}
+	for _, field := range out.Fields {
+		fields = append(fields, field.Field)
+	}
+
+	assert.ElementsMatch(t, []string{"name", "domains[0]", "environments[0]", "relationships[0]"}, fields)
+}
+
+func TestOrganizationHandlerRollback(t *testing.T) {
+	f := newFakeOpenlane()
+	f.failCreate["mitb inc..testing.orders"] = errUpstream
+
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","description":"meow"}`)
+
+	err := h.OrganizationHandler(ctx)
+	require.ErrorIs(t, err, errUpstream)
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	var out models.ProvisioningFailureReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.False(t, out.Success)
+	assert.Equal(t, errUpstream.Error(), out.Error)
+	assert.Len(t, out.Created, len(f.created))
+	assert.Len(t, out.RolledBack, len(f.created))
+	assert.Empty(t, out.Orphaned)
+
+	// everything created is deleted, most recent first, leaving nothing behind
+	assert.Empty(t, f.orgs)
+	require.Len(t, f.deleted, len(f.created))
+
+	for i, id := range f.deleted {
+		assert.Equal(t, f.created[len(f.created)-1-i], id)
+	}
+}
+
+func TestOrganizationHandlerRollbackOrphaned(t *testing.T) {
+	f := newFakeOpenlane()
+	f.failCreate["mitb inc..production"] = errUpstream
+
+	h := newTestHandler(f)
+
+	// the root organization is the first organization created
+	f.failDelete["org-001"] = errUpstream
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","description":"meow"}`)
+
+	require.Error(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	var out models.ProvisioningFailureReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	// sibling environments may have been created before the failure
+	require.NotEmpty(t, out.Created)
+	assert.Len(t, out.RolledBack, len(out.Created)-1)
+	require.Len(t, out.Orphaned, 1)
+	assert.Equal(t, "org-001", out.Orphaned[0].ID)
+	assert.Contains(t, out.Error, "could not be rolled back")
+}
+
+func TestOrganizationHandlerAsync(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+	h.Jobs = NewJobs(1, 1, time.Hour)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization?async=true", `{"name":"MITB Inc.","description":"meow"}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusAccepted)
+
+	var submitted models.ProvisioningJobReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &submitted))
+
+	assert.Equal(t, "/v1/organization/jobs/"+submitted.Job.ID, rec.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, 23, submitted.Job.Total)
+
+	var out models.ProvisioningJobReply
+
+	require.Eventually(t, func() bool {
+		ctx, rec := newTestContext(t, http.MethodGet, "/v1/organization/jobs/"+submitted.Job.ID, "")
+		ctx.SetPathParams(echo.PathParams{{Name: "id", Value: submitted.Job.ID}})
+
+		require.NoError(t, h.OrganizationJobHandler(ctx))
+		requireStatus(t, rec, http.StatusOK)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+		return out.Job.Status == models.JobStatusSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 23, out.Job.Completed)
+	assert.Len(t, out.Job.Nodes, 23)
+	require.NotNil(t, out.Job.Result)
+	assert.Len(t, out.Job.Result.Environments, 2)
+}
+
+func TestJobsClose(t *testing.T) {
+	// submitJob submits an asynchronous organization request and returns the id of the job
+	submitJob := func(t *testing.T, h *Handler) string {
+		t.Helper()
+
+		ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization?async=true", `{"name":"MITB Inc.","description":"meow"}`)
+
+		require.NoError(t, h.OrganizationHandler(ctx))
+		requireStatus(t, rec, http.StatusAccepted)
+
+		var out models.ProvisioningJobReply
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+		return out.Job.ID
+	}
+
+	t.Run("running job finishes", func(t *testing.T) {
+		f := newFakeOpenlane()
+		f.delay = func(string) time.Duration { return time.Millisecond }
+
+		h := newTestHandler(f)
+		h.Jobs = NewJobs(1, 1, time.Hour)
+
+		id := submitJob(t, h)
+
+		require.NoError(t, h.Jobs.Close(context.Background()))
+
+		job, err := h.Jobs.Get(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobStatusSucceeded, job.Status)
+		assert.Len(t, f.created, 23)
+		assert.Empty(t, f.deleted)
+
+		// no more jobs are accepted once the job runner has been closed
+		ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization?async=true", `{"name":"Delta Inc","description":"meow"}`)
+
+		require.ErrorIs(t, h.OrganizationHandler(ctx), ErrJobsClosed)
+		requireStatus(t, rec, http.StatusTooManyRequests)
+	})
+
+	t.Run("running job is rolled back after the grace period", func(t *testing.T) {
+		f := newFakeOpenlane()
+		f.delay = func(string) time.Duration { return 20 * time.Millisecond }
+
+		h := newTestHandler(f)
+		h.Jobs = NewJobs(1, 1, time.Hour)
+
+		id := submitJob(t, h)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, h.Jobs.Close(ctx), context.DeadlineExceeded)
+
+		job, err := h.Jobs.Get(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobStatusFailed, job.Status)
+
+		// every organization created before the job was canceled has been deleted again
+		require.NotEmpty(t, f.created)
+		assert.Less(t, len(f.created), 23)
+		assert.ElementsMatch(t, f.created, f.deleted)
+		assert.Empty(t, f.orgs)
+
+		for _, node := range job.Nodes {
+			assert.Equal(t, models.NodeStatusRolledBack, node.Status)
+		}
+	})
+}
+
+func TestOrganizationJobHandlerCaller(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+	h.Jobs = NewJobs(1, 1, time.Hour)
+
+	owner := &auth.Caller{Subject: "ci", Method: auth.MethodAPIToken}
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization?async=true", `{"name":"MITB Inc.","description":"meow"}`)
+	ctx.SetRequest(ctx.Request().WithContext(auth.WithCaller(ctx.Request().Context(), owner)))
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusAccepted)
+
+	var submitted models.ProvisioningJobReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &submitted))
+
+	tests := map[string]struct {
+		caller *auth.Caller
+		status int
+	}{
+		"submitting caller":        {caller: owner, status: http.StatusOK},
+		"other caller":             {caller: &auth.Caller{Subject: "other", Method: auth.MethodAPIToken}, status: http.StatusNotFound},
+		"same subject, other auth": {caller: &auth.Caller{Subject: "ci", Method: auth.MethodJWT}, status: http.StatusNotFound},
+		"unauthenticated":          {status: http.StatusNotFound},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, rec := newTestContext(t, http.MethodGet, "/v1/organization/jobs/"+submitted.Job.ID, "")
+			ctx.SetPathParams(echo.PathParams{{Name: "id", Value: submitted.Job.ID}})
+
+			if tc.caller != nil {
+				ctx.SetRequest(ctx.Request().WithContext(auth.WithCaller(ctx.Request().Context(), tc.caller)))
+			}
+
+			err := h.OrganizationJobHandler(ctx)
+			requireStatus(t, rec, tc.status)
+
+			if tc.status == http.StatusNotFound {
+				require.ErrorIs(t, err, ErrJobNotFound)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOrganizationJobHandlerNotFound(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+	h.Jobs = NewJobs(1, 1, time.Hour)
+
+	ctx, rec := newTestContext(t, http.MethodGet, "/v1/organization/jobs/missing", "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.ErrorIs(t, h.OrganizationJobHandler(ctx), ErrJobNotFound)
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestOrganizationHandlerConcurrency(t *testing.T) {
+	f := newFakeOpenlane()
+
+	// later siblings finish first so the response order can only match the request if it is preserved explicitly
+	f.delay = func(name string) time.Duration {
+		return time.Duration(len(name)%7) * time.Millisecond
+	}
+
+	h := newTestHandler(f)
+	h.Concurrency = 4
+
+	body := `{"name":"meow","description":"meow","environments":["production","staging","testing"],"buckets":["sales","relationships","assets","orders"],"relationships":["vendors","partners"]}`
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", body)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.LessOrEqual(t, f.maxInFlight, h.Concurrency)
+	assert.Greater(t, f.maxInFlight, 1)
+
+	// 1 root + 3 environments + 12 buckets + 6 relationships
+	assert.Len(t, f.created, 22)
+
+	require.Len(t, out.Environments, 3)
+
+	for i, envName := range []string{"production", "staging", "testing"} {
+		env := out.Environments[i]
+		assert.Equal(t, envName, env.Name)
+
+		require.Len(t, env.Buckets, 4)
+
+		for j, bucketName := range []string{"sales", "relationships", "assets", "orders"} {
+			assert.Equal(t, bucketName, env.Buckets[j].Name)
+		}
+
+		require.Len(t, env.Buckets[1].Relations, 2)
+		assert.Equal(t, "vendors", env.Buckets[1].Relations[0].Name)
+		assert.Equal(t, "partners", env.Buckets[1].Relations[1].Name)
+	}
+}
+
+func TestOrganizationHandlerConcurrencyCancel(t *testing.T) {
+	f := newFakeOpenlane()
+	f.failCreate["meow.production"] = errUpstream
+
+	// the failing environment fails once every environment has started, while its siblings are still being created
+	f.delay = func(name string) time.Duration {
+		switch name {
+		case "meow":
+			return 0
+		case "meow.production":
+			return 20 * time.Millisecond
+		}
+
+		return 100 * time.Millisecond
+	}
+
+	h := newTestHandler(f)
+	h.Concurrency = 4
+
+	body := `{"name":"meow","description":"meow","environments":["production","staging","testing"]}`
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", body)
+
+	require.ErrorIs(t, h.OrganizationHandler(ctx), errUpstream)
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	var out models.ProvisioningFailureReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	// the environments in progress when production failed are still created, and rolled back, but none of their buckets
+	// are started
+	assert.Len(t, f.created, 3)
+	assert.ElementsMatch(t, f.created, f.deleted)
+	assert.Empty(t, f.orgs)
+	assert.Len(t, out.RolledBack, 3)
+	assert.Empty(t, out.Orphaned)
+}
+
+func TestOrganizationHandlerDryRun(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization",
+		`{"name":"MITB Inc.","description":"meow","domains":["meow.com"],"environments":["production"],"buckets":["relationships"],"relationships":["vendors"],"dryRun":true}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationPlanReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.True(t, out.Success)
+	assert.True(t, out.DryRun)
+	assert.Equal(t, 4, out.Total)
+	assert.Empty(t, out.Collisions)
+	assert.Empty(t, f.created)
+
+	root := out.Organization
+	assert.Equal(t, "mitb inc.", root.Name)
+	assert.Equal(t, "MITB Inc.", root.DisplayName)
+	assert.Equal(t, "meow", root.Description)
+	assert.Equal(t, []string{"meow.com"}, root.Domains)
+	assert.Empty(t, root.Parent)
+	require.Len(t, root.Children, 1)
+
+	env := root.Children[0]
+	assert.Equal(t, "mitb inc..production", env.Name)
+	assert.Equal(t, "mitb inc.", env.Parent)
+	require.Len(t, env.Children, 1)
+	require.Len(t, env.Children[0].Children, 1)
+
+	relation := env.Children[0].Children[0]
+	assert.Equal(t, "mitb inc..production.relationships.vendors", relation.Name)
+	assert.Equal(t, "vendors", relation.DisplayName)
+	assert.Equal(t, "mitb inc..production.relationships", relation.Parent)
+	assert.Equal(t, []string{"production", "relationships", "vendors"}, relation.Tags)
+}
+
+func TestOrganizationHandlerDryRunCollisions(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","description":"meow","environments":["production"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	created := len(f.created)
+
+	// the existing organizations are returned by openlane over several queries
+	f.pageSize = 2
+
+	ctx, rec = newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"mitb inc.","environments":["production","staging"],"dryRun":true}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationPlanReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.Len(t, f.created, created)
+
+	// the root, production and its buckets and relationships already exist, staging does not
+	assert.Len(t, out.Collisions, created)
+	assert.Equal(t, "org-001", out.Organization.ExistingID)
+	assert.Equal(t, "mitb inc.", out.Collisions[0].Slug)
+	assert.Equal(t, "MITB Inc.", out.Collisions[0].Name)
+
+	require.Len(t, out.Organization.Children, 2)
+	assert.NotEmpty(t, out.Organization.Children[0].ExistingID)
+	assert.Empty(t, out.Organization.Children[1].ExistingID)
+}
+
+func TestOrganizationHandlerIdempotency(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+	h.IdempotencyStore = idempotency.NewMemoryStore(time.Hour, time.Minute)
+
+	body := `{"name":"MITB Inc.","description":"meow"}`
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", body)
+	ctx.Request().Header.Set(IdempotencyKeyHeader, "meow")
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+	assert.Len(t, f.created, 23)
+
+	// retrying with the same key replays the original response without creating anything
+	ctx, replayed := newTestContext(t, http.MethodPost, "/v1/organization", body)
+	ctx.Request().Header.Set(IdempotencyKeyHeader, "meow")
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, replayed, http.StatusOK)
+	assert.Equal(t, "true", replayed.Header().Get(IdempotentReplayedHeader))
+	assert.JSONEq(t, rec.Body.String(), replayed.Body.String())
+	assert.Len(t, f.created, 23)
+
+	// reusing the key for a different request is rejected
+	ctx, conflict := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow"}`)
+	ctx.Request().Header.Set(IdempotencyKeyHeader, "meow")
+
+	require.Error(t, h.OrganizationHandler(ctx))
+	requireStatus(t, conflict, http.StatusConflict)
+	assert.Contains(t, conflict.Body.String(), string(IdempotencyKeyReusedErrCode))
+	assert.Len(t, f.created, 23)
+}
+
+func TestOrganizationHandlerIdempotencyFailure(t *testing.T) {
+	f := newFakeOpenlane()
+	f.failCreate["mitb inc..production"] = errUpstream
+
+	h := newTestHandler(f)
+	h.IdempotencyStore = idempotency.NewMemoryStore(time.Hour, time.Minute)
+
+	body := `{"name":"MITB Inc.","description":"meow"}`
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", body)
+	ctx.Request().Header.Set(IdempotencyKeyHeader, "meow")
+
+	require.Error(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	// failed requests are not stored so the same key can be retried
+	delete(f.failCreate, "mitb inc..production")
+
+	ctx, rec = newTestContext(t, http.MethodPost, "/v1/organization", body)
+	ctx.Request().Header.Set(IdempotencyKeyHeader, "meow")
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+	assert.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestGetOrganizationHandler(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","description":"meow","domains":["meow.com"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	// organizations added outside of openlane-cloud are not part of the hierarchy
+	unmanaged := "unmanaged"
+	_, err := f.CreateOrganization(context.Background(), openlaneclient.CreateOrganizationInput{
+		Name:        "unmanaged",
+		DisplayName: &unmanaged,
+		ParentID:    &created.Environments[0].ID,
+	}, nil)
+	require.NoError(t, err)
+
+	// every level of the hierarchy has more organizations than openlane returns for a single query
+	f.pageSize = 3
+
+	ctx, rec = newTestContext(t, http.MethodGet, "/v1/organization/"+created.ID, "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.NoError(t, h.GetOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	created.Template = ""
+
+	assert.Equal(t, created, out)
+}
+
+func TestGetOrganizationHandlerTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deep.yaml"), []byte(`
+name: deep
+version: 1
+children:
+  - name: a
+    children:
+      - name: b
+        children:
+          - name: c
+            children:
+              - name: d
+`), 0600))
+
+	registry, err := blueprint.NewRegistry(dir)
+	require.NoError(t, err)
+
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+	h.Blueprints = registry
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow","template":"deep"}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	ctx, rec = newTestContext(t, http.MethodGet, "/v1/organization/"+created.ID, "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.NoError(t, h.GetOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.Empty(t, out.Environments)
+	assert.Equal(t, created.Children, out.Children)
+}
+
+func TestGetOrganizationHandlerNotFound(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+
+	ctx, rec := newTestContext(t, http.MethodGet, "/v1/organization/missing", "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.ErrorIs(t, h.GetOrganizationHandler(ctx), ErrOrganizationNotFound)
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestDeleteOrganizationHandler(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","description":"meow"}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	// organizations added outside of openlane-cloud are removed with the hierarchy
+	unmanaged := "unmanaged"
+	_, err := f.CreateOrganization(context.Background(), openlaneclient.CreateOrganizationInput{
+		Name:        "unmanaged",
+		DisplayName: &unmanaged,
+		ParentID:    &created.Environments[0].Buckets[0].ID,
+	}, nil)
+	require.NoError(t, err)
+
+	// a dry run lists the organizations without deleting them
+	ctx, rec = newTestContext(t, http.MethodDelete, "/v1/organization/"+created.ID+"?dryRun=true", "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.NoError(t, h.DeleteOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var plan models.OrganizationDeleteReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &plan))
+
+	assert.True(t, plan.DryRun)
+	assert.Len(t, plan.Organizations, 24)
+	assert.Equal(t, created.ID, plan.Organizations[len(plan.Organizations)-1].ID)
+	assert.Empty(t, f.deleted)
+
+	ctx, rec = newTestContext(t, http.MethodDelete, "/v1/organization/"+created.ID, "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.NoError(t, h.DeleteOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationDeleteReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	assert.False(t, out.DryRun)
+	assert.Len(t, out.Organizations, 24)
+	assert.Empty(t, f.orgs)
+
+	// every organization is deleted after all of its children
+	position := map[string]int{}
+	for i, id := range f.deleted {
+		position[id] = i
+	}
+
+	for _, env := range created.Environments {
+		assert.Less(t, position[env.ID], position[created.ID])
+
+		for _, bucket := range env.Buckets {
+			assert.Less(t, position[bucket.ID], position[env.ID])
+
+			for _, relation := range bucket.Relations {
+				assert.Less(t, position[relation.ID], position[bucket.ID])
+			}
+		}
+	}
+}
+
+func TestDeleteOrganizationHandlerFailure(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow","environments":["production","testing"],"buckets":["sales"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	failing := created.Environments[1].Buckets[0].ID
+	f.failDelete[failing] = errUpstream
+
+	ctx, rec = newTestContext(t, http.MethodDelete, "/v1/organization/"+created.ID, "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.ErrorIs(t, h.DeleteOrganizationHandler(ctx), ErrDeleteFailed)
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	var out models.OrganizationDeleteReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	// the other bucket is deleted but the environments and root are kept because a child could not be removed
+	assert.False(t, out.Success)
+	require.Len(t, out.Failed, 1)
+	assert.Equal(t, failing, out.Failed[0].ID)
+	require.Len(t, out.Organizations, 1)
+	assert.Equal(t, created.Environments[0].Buckets[0].ID, out.Organizations[0].ID)
+	assert.Len(t, f.orgs, 4)
+}
+
+func TestDeleteOrganizationHandlerNotFound(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+
+	ctx, rec := newTestContext(t, http.MethodDelete, "/v1/organization/missing", "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.ErrorIs(t, h.DeleteOrganizationHandler(ctx), ErrOrganizationNotFound)
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestUpdateOrganizationHandler(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow","environments":["production"],"buckets":["sales","relationships"],"relationships":["vendors"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	// 1 root + 1 environment + 2 buckets + 1 relationship
+	require.Len(t, f.created, 5)
+
+	body := `{"environments":["staging","Production"],"buckets":["assets"],"relationships":["partners"]}`
+	ctx, rec = newTestContext(t, http.MethodPatch, "/v1/organization/"+created.ID, body)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.NoError(t, h.UpdateOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationUpdateReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	// production gets assets and partners, staging gets all 3 buckets and 2 relationships
+	assert.Len(t, out.Created, 2+6)
+	assert.Len(t, f.created, 5+8)
+
+	require.Len(t, out.Environments, 2)
+
+	for _, env := range out.Environments {
+		require.Len(t, env.Buckets, 3, env.Name)
+
+		for _, bucket := range env.Buckets {
+			if bucket.Name == "relationships" {
+				assert.Len(t, bucket.Relations, 2)
+			}
+		}
+	}
+
+	// existing organizations are kept and new organizations follow the naming and tagging rules
+	assert.Equal(t, created.Environments[0].ID, out.Environments[0].ID)
+
+	for _, org := range f.orgs {
+		if org.Name == "meow.staging.relationships.partners" {
+			assert.Equal(t, []string{"staging", "relationships", "partners"}, org.Tags)
+		}
+	}
+
+	// a second update creates nothing
+	ctx, rec = newTestContext(t, http.MethodPatch, "/v1/organization/"+created.ID, body)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.NoError(t, h.UpdateOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	assert.Empty(t, out.Created)
+	assert.Len(t, f.created, 13)
+}
+
+func TestUpdateOrganizationHandlerEnvironments(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow","environments":["production","testing"],"buckets":["sales","Relationships"],"relationships":["vendors"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	// 1 root + 2 environments + 4 buckets + 2 relationships
+	require.Len(t, f.created, 9)
+
+	// a bucket that only exists in production
+	audit := "audit"
+	_, err := f.CreateOrganization(context.Background(), openlaneclient.CreateOrganizationInput{
+		Name:        "meow.production.audit",
+		DisplayName: &audit,
+		ParentID:    &created.Environments[0].ID,
+		Tags:        []string{"production", "audit", "compliance"},
+	}, nil)
+	require.NoError(t, err)
+
+	update := func(body string) models.OrganizationUpdateReply {
+		t.Helper()
+
+		ctx, rec := newTestContext(t, http.MethodPatch, "/v1/organization/"+created.ID, body)
+		ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+		require.NoError(t, h.UpdateOrganizationHandler(ctx))
+		requireStatus(t, rec, http.StatusOK)
+
+		var out models.OrganizationUpdateReply
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+		return out
+	}
+
+	// the relationship is added to the existing Relationships bucket of each environment, and nothing else is added
+	out := update(`{"relationships":["partners"]}`)
+	require.Len(t, out.Created, 2)
+
+	buckets := []string{created.Environments[0].Buckets[1].ID, created.Environments[1].Buckets[1].ID}
+
+	for _, org := range out.Created {
+		assert.Equal(t, "partners", org.Name)
+		assert.Contains(t, buckets, org.ParentID)
+	}
+
+	// the new environment receives the buckets of every environment, the existing environments are unchanged
+	out = update(`{"environments":["staging"]}`)
+	assert.Len(t, out.Created, 1+3+2)
+
+	for _, org := range f.orgs {
+		if org.Name == "meow.staging.audit" {
+			assert.Equal(t, []string{"staging", "audit", "compliance"}, org.Tags)
+		}
+
+		assert.NotEqual(t, "meow.testing.audit", org.Name)
+	}
+}
+
+func TestUpdateOrganizationHandlerRollback(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow","environments":["production"],"buckets":["sales"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	f.failCreate["meow.staging.sales"] = errUpstream
+
+	ctx, rec = newTestContext(t, http.MethodPatch, "/v1/organization/"+created.ID, `{"environments":["staging"]}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.ErrorIs(t, h.UpdateOrganizationHandler(ctx), errUpstream)
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	// only the new staging environment is rolled back
+	assert.Len(t, f.deleted, 1)
+	assert.Len(t, f.orgs, 3)
+}
+
+func TestUpdateOrganizationHandlerInvalid(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+
+	ctx, rec := newTestContext(t, http.MethodPatch, "/v1/organization/missing", `{}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.Error(t, h.UpdateOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusBadRequest)
+
+	ctx, rec = newTestContext(t, http.MethodPatch, "/v1/organization/missing", `{"environments":["staging"]}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.ErrorIs(t, h.UpdateOrganizationHandler(ctx), ErrOrganizationNotFound)
+	requireStatus(t, rec, http.StatusNotFound)
+}
+
+func TestReconcileOrganizationHandler(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	hierarchy := `"environments":["production","testing"],"buckets":["sales","relationships"],"relationships":["vendors"]`
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow",`+hierarchy+`}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	reconcile := func(t *testing.T, body string) models.OrganizationReconcileReply {
+		t.Helper()
+
+		ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization/"+created.ID+"/reconcile", body)
+		ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+		require.NoError(t, h.ReconcileOrganizationHandler(ctx))
+		requireStatus(t, rec, http.StatusOK)
+
+		var out models.OrganizationReconcileReply
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+		return out
+	}
+
+	out := reconcile(t, `{`+hierarchy+`}`)
+	assert.True(t, out.InSync)
+	assert.Equal(t, "default@1", out.Template)
+
+	// rename an environment, delete a bucket, and add an organization outside of openlane-cloud
+	production := created.Environments[0]
+	testingEnv := created.Environments[1]
+
+	prod := "prod"
+	_, err := f.UpdateOrganization(context.Background(), production.ID, openlaneclient.UpdateOrganizationInput{DisplayName: &prod}, nil)
+	require.NoError(t, err)
+
+	_, err = f.DeleteOrganization(context.Background(), testingEnv.Buckets[0].ID)
+	require.NoError(t, err)
+
+	unmanaged := "unmanaged"
+	extra, err := f.CreateOrganization(context.Background(), openlaneclient.CreateOrganizationInput{
+		Name:        "unmanaged",
+		DisplayName: &unmanaged,
+		ParentID:    &created.ID,
+	}, nil)
+	require.NoError(t, err)
+
+	out = reconcile(t, `{`+hierarchy+`}`)
+	assert.False(t, out.InSync)
+	assert.False(t, out.Repaired)
+
+	require.Len(t, out.Renamed, 1)
+	assert.Equal(t, models.RenamedNode{ID: production.ID, Path: "production", Name: "prod", ExpectedName: "production"}, out.Renamed[0])
+
+	require.Len(t, out.Missing, 1)
+	assert.Equal(t, "testing.sales", out.Missing[0].Path)
+
+	require.Len(t, out.Extra, 1)
+	assert.Equal(t, extra.CreateOrganization.Organization.ID, out.Extra[0].ID)
+	assert.Equal(t, "unmanaged", out.Extra[0].Path)
+
+	// nothing was changed without repair
+	assert.Equal(t, "prod", f.orgs[production.ID].DisplayName)
+
+	out = reconcile(t, `{`+hierarchy+`,"repair":true,"prune":true}`)
+	assert.False(t, out.InSync)
+	assert.True(t, out.Repaired)
+	require.Len(t, out.Created, 1)
+	assert.Equal(t, "sales", out.Created[0].Name)
+	require.Len(t, out.Deleted, 1)
+	assert.Equal(t, extra.CreateOrganization.Organization.ID, out.Deleted[0].ID)
+
+	assert.Equal(t, "production", f.orgs[production.ID].DisplayName)
+	assert.Equal(t, []string{"testing", "sales"}, f.orgs[out.Created[0].ID].Tags)
+
+	out = reconcile(t, `{`+hierarchy+`}`)
+	assert.True(t, out.InSync)
+}
+
+func TestReconcileOrganizationHandlerRollback(t *testing.T) {
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+
+	hierarchy := `"environments":["production","testing"],"buckets":["sales"]`
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow",`+hierarchy+`}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var created models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	production := created.Environments[0]
+
+	prod := "prod"
+	_, err := f.UpdateOrganization(context.Background(), production.ID, openlaneclient.UpdateOrganizationInput{DisplayName: &prod}, nil)
+	require.NoError(t, err)
+
+	_, err = f.DeleteOrganization(context.Background(), created.Environments[1].Buckets[0].ID)
+	require.NoError(t, err)
+
+	f.failCreate["meow.testing.sales"] = errUpstream
+
+	ctx, rec = newTestContext(t, http.MethodPost, "/v1/organization/"+created.ID+"/reconcile", `{`+hierarchy+`,"repair":true}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: created.ID}})
+
+	require.ErrorIs(t, h.ReconcileOrganizationHandler(ctx), errUpstream)
+	requireStatus(t, rec, http.StatusUnprocessableEntity)
+
+	// the rename is undone along with the created organizations
+	assert.Equal(t, "prod", f.orgs[production.ID].DisplayName)
+}
+
+func TestReconcileOrganizationHandlerInvalid(t *testing.T) {
+	h := newTestHandler(newFakeOpenlane())
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization/missing/reconcile", `{"template":"default","buckets":["sales"]}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.Error(t, h.ReconcileOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusBadRequest)
+
+	// the default hierarchy is not used to prune
+	ctx, rec = newTestContext(t, http.MethodPost, "/v1/organization/missing/reconcile", `{"repair":true,"prune":true}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.Error(t, h.ReconcileOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusBadRequest)
+
+	ctx, rec = newTestContext(t, http.MethodPost, "/v1/organization/missing/reconcile", `{}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: "missing"}})
+
+	require.ErrorIs(t, h.ReconcileOrganizationHandler(ctx), ErrOrganizationNotFound)
+	requireStatus(t, rec, http.StatusNotFound)
+}
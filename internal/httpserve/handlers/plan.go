@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// planOrganization returns the hierarchy that would be created for the request without creating anything in openlane;
+// the planned organizations are built from the same inputs used to provision the hierarchy, and their unique names are
+// compared with the organizations that already exist so collisions are reported before anything is created
+func (h *Handler) planOrganization(ctx context.Context, in models.OrganizationRequest, bp blueprint.Blueprint) (*models.OrganizationPlanReply, error) {
+	input := rootOrganizationInput(in)
+
+	root := plannedOrg(input, "")
+	root.Children = planChildOrganizations(input.Name, []string{}, bp.Children)
+
+	for i := range root.Children {
+		root.Children[i].Groups = plannedGroups(in.Groups, root.Children[i].DisplayName)
+	}
+
+	existing, err := h.existingOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &models.OrganizationPlanReply{
+		Reply:        rout.Reply{Success: true},
+		DryRun:       true,
+		Template:     bp.ID(),
+		Total:        1 + bp.Count(),
+		Organization: root,
+		Invites:      in.Invites,
+	}
+
+	out.Collisions = markCollisions(&out.Organization, existing, nil)
+
+	return out, nil
+}
+
+// planChildOrganizations returns the organizations that would be created for the blueprint nodes and all of their
+// descendants underneath the parent, in the same order as the nodes
+func planChildOrganizations(namePrefix string, ancestors []string, nodes []blueprint.Node) []models.PlannedOrg {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	orgs := make([]models.PlannedOrg, 0, len(nodes))
+
+	for _, node := range nodes {
+		path := append(slices.Clone(ancestors), node.Name)
+		input := childOrganizationInput(namePrefix, "", path, node)
+
+		org := plannedOrg(input, namePrefix)
+		org.Children = planChildOrganizations(input.Name, path, node.Children)
+
+		orgs = append(orgs, org)
+	}
+
+	return orgs
+}
+
+// plannedGroups returns the names of the groups that would be created in the environment
+func plannedGroups(groups []models.OrganizationGroup, environment string) []string {
+	var out []string
+
+	for _, g := range groups {
+		if createdIn(g, environment) {
+			out = append(out, g.Name)
+		}
+	}
+
+	return out
+}
+
+// plannedOrg returns the planned organization for the create input
+func plannedOrg(input openlaneclient.CreateOrganizationInput, parent string) models.PlannedOrg {
+	org := models.PlannedOrg{
+		Name:   input.Name,
+		Parent: parent,
+		Tags:   input.Tags,
+	}
+
+	if input.DisplayName != nil {
+		org.DisplayName = *input.DisplayName
+	}
+
+	if input.Description != nil {
+		org.Description = *input.Description
+	}
+
+	if input.CreateOrgSettings != nil {
+		org.Domains = input.CreateOrgSettings.Domains
+	}
+
+	return org
+}
+
+// existingOrganizations returns every organization visible to the caller, keyed by their lower case unique name
+func (h *Handler) existingOrganizations(ctx context.Context) (map[string]models.OrgDetails, error) {
+	orgs, err := h.getOrganizations(ctx, openlaneclient.OrganizationWhereInput{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]models.OrgDetails{}
+
+	for _, node := range orgs {
+		org := models.OrgDetails{
+			ID:        node.ID,
+			Name:      node.DisplayName,
+			Slug:      node.Name,
+			Tags:      node.Tags,
+			CreatedAt: node.CreatedAt,
+		}
+
+		if node.Parent != nil {
+			org.ParentID = node.Parent.ID
+		}
+
+		existing[strings.ToLower(org.Slug)] = org
+	}
+
+	return existing, nil
+}
+
+// markCollisions sets the id of the existing organization on every planned organization whose name is already in use
+// and returns the existing organizations, parents first
+func markCollisions(org *models.PlannedOrg, existing map[string]models.OrgDetails, collisions []models.OrgDetails) []models.OrgDetails {
+	if e, ok := existing[strings.ToLower(org.Name)]; ok {
+		org.ExistingID = e.ID
+		collisions = append(collisions, e)
+	}
+
+	for i := range org.Children {
+		collisions = markCollisions(&org.Children[i], existing, collisions)
+	}
+
+	return collisions
+}
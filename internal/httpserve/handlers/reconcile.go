@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// drift contains the differences between an existing hierarchy and the expected hierarchy
+type drift struct {
+	missing []missingNode
+	extra   []extraNode
+	renamed []renamedNode
+}
+
+// missingNode is an expected organization, and its descendants, that does not exist underneath the parent
+type missingNode struct {
+	parent *orgTree
+	path   []string
+	node   blueprint.Node
+}
+
+// extraNode is an existing organization, and its descendants, that is not in the expected hierarchy
+type extraNode struct {
+	org  *orgTree
+	path []string
+}
+
+// renamedNode is an existing organization whose display name no longer matches the expected hierarchy
+type renamedNode struct {
+	org      *orgTree
+	path     []string
+	expected string
+}
+
+// inSync returns true when there are no differences
+func (d *drift) inSync() bool {
+	return len(d.missing) == 0 && len(d.extra) == 0 && len(d.renamed) == 0
+}
+
+// ReconcileOrganizationHandler is the handler for comparing an existing organization hierarchy with the expected
+// hierarchy and optionally repairing the differences
+func (h *Handler) ReconcileOrganizationHandler(ctx echo.Context) error {
+	var in models.OrganizationReconcileRequest
+	if err := ctx.Bind(&in); err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	if err := in.Validate(); err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	bp, err := h.resolveBlueprint(in.Template, in.Environments, in.Buckets, in.Relationships)
+	if err != nil {
+		return h.BadRequest(ctx, err)
+	}
+
+	reqCtx, err := h.requestContext(ctx)
+	if err != nil {
+		return h.Unauthorized(ctx, err)
+	}
+
+	// every child is loaded so renamed organizations and organizations added outside of openlane-cloud are found
+	tree, err := h.loadHierarchy(reqCtx, ctx.PathParam("id"), false)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			return h.NotFound(ctx, err)
+		}
+
+		return h.openlaneFailure(ctx, err, h.InternalServerError)
+	}
+
+	d := &drift{}
+	compareHierarchy(tree, []string{}, bp.Children, d)
+
+	out := d.reply()
+	out.Template = bp.ID()
+
+	log.Debug().Str("id", tree.ID).
+		Str("template", bp.ID()).
+		Int("missing", len(out.Missing)).
+		Int("extra", len(out.Extra)).
+		Int("renamed", len(out.Renamed)).
+		Msg("reconciling organization")
+
+	if !in.Repair || d.inSync() {
+		return h.Success(ctx, out)
+	}
+
+	entry := models.AuditEntry{Action: models.AuditActionReconcile, OrganizationID: tree.ID}
+
+	renamed, err := h.renameNodes(reqCtx, d.renamed)
+	if err != nil {
+		h.restoreNames(reqCtx, renamed)
+		h.audit(reqCtx, entry, in, err)
+
+		return h.openlaneFailure(ctx, err, h.InternalServerError)
+	}
+
+	tracker := newProvisioningTracker(h.Concurrency, nil)
+
+	for _, m := range d.missing {
+		if _, err := h.createChildOrganizations(reqCtx, m.parent.Name, m.parent.ID, m.path, []blueprint.Node{m.node}, tracker); err != nil {
+			perr := h.rollback(reqCtx, tracker, err)
+			h.restoreNames(reqCtx, renamed)
+
+			entry.Created = organizationIDs(perr.Created)
+			entry.Deleted = organizationIDs(perr.RolledBack)
+			h.audit(reqCtx, entry, in, perr)
+
+			return h.ProvisioningFailed(ctx, perr)
+		}
+	}
+
+	out.Created = tracker.createdOrganizations()
+	entry.Created = organizationIDs(out.Created)
+
+	if in.Prune {
+		for _, e := range d.extra {
+			deleted, err := h.deleteHierarchy(reqCtx, e.org)
+			if err != nil {
+				var derr *DeletionError
+				if errors.As(err, &derr) {
+					derr.Deleted = append(out.Deleted, derr.Deleted...)
+					entry.Deleted = organizationIDs(derr.Deleted)
+					h.audit(reqCtx, entry, in, derr)
+
+					return h.DeletionFailed(ctx, derr)
+				}
+
+				entry.Deleted = organizationIDs(out.Deleted)
+				h.audit(reqCtx, entry, in, err)
+
+				return h.InternalServerError(ctx, err)
+			}
+
+			out.Deleted = append(out.Deleted, deleted...)
+		}
+	}
+
+	entry.Deleted = organizationIDs(out.Deleted)
+	h.audit(reqCtx, entry, in, nil)
+
+	out.Repaired = true
+
+	return h.Success(ctx, out)
+}
+
+// compareHierarchy compares the children of the existing organization with the expected nodes and records the
+// differences; children are matched by display name first, and any remaining expected node is matched with an
+// organization that still has its unique name or tags, which means only the display name was changed
+func compareHierarchy(parent *orgTree, path []string, nodes []blueprint.Node, d *drift) {
+	remaining := slices.Clone(parent.Children)
+	unmatched := []blueprint.Node{}
+
+	for _, node := range nodes {
+		i := slices.IndexFunc(remaining, func(c *orgTree) bool { return strings.EqualFold(c.DisplayName, node.Name) })
+		if i < 0 {
+			unmatched = append(unmatched, node)
+
+			continue
+		}
+
+		existing := remaining[i]
+		remaining = slices.Delete(remaining, i, i+1)
+
+		compareHierarchy(existing, append(slices.Clone(path), node.Name), node.Children, d)
+	}
+
+	for _, node := range unmatched {
+		nodePath := append(slices.Clone(path), node.Name)
+		name := strings.ToLower(parent.Name + "." + node.Name)
+
+		i := slices.IndexFunc(remaining, func(c *orgTree) bool { return c.Name == name || hasTagPath(c.Tags, nodePath) })
+		if i < 0 {
+			d.missing = append(d.missing, missingNode{parent: parent, path: path, node: node})
+
+			continue
+		}
+
+		existing := remaining[i]
+		remaining = slices.Delete(remaining, i, i+1)
+
+		d.renamed = append(d.renamed, renamedNode{org: existing, path: nodePath, expected: node.Name})
+
+		compareHierarchy(existing, nodePath, node.Children, d)
+	}
+
+	for _, c := range remaining {
+		d.extra = append(d.extra, extraNode{org: c, path: append(slices.Clone(path), c.DisplayName)})
+	}
+}
+
+// reply returns the diff report for the differences
+func (d *drift) reply() models.OrganizationReconcileReply {
+	out := models.OrganizationReconcileReply{
+		Reply:  rout.Reply{Success: true},
+		InSync: d.inSync(),
+	}
+
+	for _, m := range d.missing {
+		out.Missing = append(out.Missing, models.DriftNode{
+			Path:        strings.Join(append(slices.Clone(m.path), m.node.Name), "."),
+			Descendants: blueprint.Blueprint{Children: m.node.Children}.Count(),
+		})
+	}
+
+	for _, e := range d.extra {
+		out.Extra = append(out.Extra, models.DriftNode{
+			ID:          e.org.ID,
+			Path:        strings.Join(e.path, "."),
+			Descendants: e.org.descendants(),
+		})
+	}
+
+	for _, r := range d.renamed {
+		out.Renamed = append(out.Renamed, models.RenamedNode{
+			ID:           r.org.ID,
+			Path:         strings.Join(r.path, "."),
+			Name:         r.org.DisplayName,
+			ExpectedName: r.expected,
+		})
+	}
+
+	return out
+}
+
+// renameNodes restores the expected display names of renamed organizations and returns the organizations that were
+// renamed, including when a later rename fails
+func (h *Handler) renameNodes(ctx context.Context, renamed []renamedNode) ([]renamedNode, error) {
+	for i, r := range renamed {
+		if _, err := h.openlane(ctx).UpdateOrganization(ctx, r.org.ID, openlaneclient.UpdateOrganizationInput{
+			DisplayName: &r.expected,
+		}, nil); err != nil {
+			return renamed[:i], openlaneError(err)
+		}
+	}
+
+	return renamed, nil
+}
+
+// restoreNames changes the display names of the renamed organizations back to the names they had before the repair,
+// so a repair that fails leaves the hierarchy as it was; the request context may already be canceled so the updates
+// are detached from it
+func (h *Handler) restoreNames(ctx context.Context, renamed []renamedNode) {
+	ctx = context.WithoutCancel(ctx)
+
+	for _, r := range renamed {
+		if _, err := h.openlane(ctx).UpdateOrganization(ctx, r.org.ID, openlaneclient.UpdateOrganizationInput{
+			DisplayName: &r.org.DisplayName,
+		}, nil); err != nil {
+			log.Error().Err(err).Str("id", r.org.ID).Str("name", r.org.DisplayName).Msg("failed to restore organization name")
+		}
+	}
+}
+
+// BindReconcileOrganizationHandler is used to bind the reconcile organization endpoint to the OpenAPI schema
+func (h *Handler) BindReconcileOrganizationHandler() *openapi3.Operation {
+	reconcile := openapi3.NewOperation()
+	reconcile.Description = "ReconcileOrganization compares an existing organization hierarchy with the expected hierarchy, reports missing, extra, and renamed organizations, and optionally repairs them"
+	reconcile.OperationID = "ReconcileOrganizationHandler"
+	reconcile.Security = authenticated()
+
+	reconcile.AddParameter(openapi3.NewPathParameter("id").
+		WithDescription("the id of the root organization").
+		WithSchema(openapi3.NewStringSchema()))
+
+	h.AddRequestBody("OrganizationReconcileRequest", models.ExampleOrganizationReconcileRequest, reconcile)
+	h.AddResponse("OrganizationReconcileReply", "success", models.ExampleOrganizationReconcileResponse, reconcile, http.StatusOK)
+	h.AddResponse("ProvisioningFailureReply", "creating missing organizations failed and created organizations were rolled back", models.ExampleProvisioningFailureResponse, reconcile, http.StatusUnprocessableEntity)
+	reconcile.AddResponse(http.StatusInternalServerError, internalServerError())
+	reconcile.AddResponse(http.StatusUnauthorized, unauthorized())
+	addOpenlaneRequest(reconcile)
+	h.AddResponse("ValidationFailureReply", "the request has invalid fields", models.ExampleValidationFailureResponse, reconcile, http.StatusBadRequest)
+	reconcile.AddResponse(http.StatusNotFound, notFound())
+
+	return reconcile
+}
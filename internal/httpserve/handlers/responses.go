@@ -8,6 +8,8 @@ import (
 	echo "github.com/theopenlane/echox"
 
 	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
 )
 
 var (
@@ -41,6 +43,15 @@ func (h *Handler) Unauthorized(ctx echo.Context, err error) error {
 	return err
 }
 
+// Forbidden returns a 403 Forbidden response with the error message.
+func (h *Handler) Forbidden(ctx echo.Context, err error) error {
+	if err := ctx.JSON(http.StatusForbidden, rout.ErrorResponse(err)); err != nil {
+		return err
+	}
+
+	return err
+}
+
 // NotFound returns a 404 Not Found response with the error message.
 func (h *Handler) NotFound(ctx echo.Context, err error) error {
 	if err := ctx.JSON(http.StatusNotFound, rout.ErrorResponse(err)); err != nil {
@@ -68,8 +79,18 @@ func (h *Handler) BadRequestWithCode(ctx echo.Context, err error, code rout.Erro
 	return err
 }
 
-// InvalidInput returns a 400 Bad Request response with the error message.
+// InvalidInput returns a 400 Bad Request response with the error message, and every invalid field when the request
+// failed validation.
 func (h *Handler) InvalidInput(ctx echo.Context, err error) error {
+	var verr *models.ValidationError
+	if errors.As(err, &verr) {
+		if err := ctx.JSON(http.StatusBadRequest, verr.Reply(InvalidInputErrCode)); err != nil {
+			return err
+		}
+
+		return err
+	}
+
 	if err := ctx.JSON(http.StatusBadRequest, rout.ErrorResponseWithCode(err, InvalidInputErrCode)); err != nil {
 		return err
 	}
@@ -95,11 +116,61 @@ func (h *Handler) TooManyRequests(ctx echo.Context, err error) error {
 	return err
 }
 
+// ProvisioningFailed returns a 422 Unprocessable Entity response with the organizations that were created, rolled back
+// and left behind when provisioning an organization hierarchy fails; the status and error code of the openlane error
+// are returned instead when openlane caused the failure
+func (h *Handler) ProvisioningFailed(ctx echo.Context, err *ProvisioningError) error {
+	status := http.StatusUnprocessableEntity
+	reply := err.Reply()
+
+	// errors returned by openlane keep their status so callers know whether to fix their token, retry, or wait
+	var oerr *OpenlaneError
+	if errors.As(err, &oerr) {
+		status = oerr.StatusCode
+		reply.ErrorCode = oerr.Code
+
+		setRetryAfter(ctx, oerr)
+	}
+
+	if err := ctx.JSON(status, reply); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// OpenlaneFailed returns the status and error code translated from an error returned by openlane, with a Retry-After
+// header when the caller should wait before retrying
+func (h *Handler) OpenlaneFailed(ctx echo.Context, err *OpenlaneError) error {
+	setRetryAfter(ctx, err)
+
+	if err := ctx.JSON(err.StatusCode, rout.ErrorResponseWithCode(err, err.Code)); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// DeletionFailed returns a 422 Unprocessable Entity response with the organizations that were deleted and could not be
+// deleted when deleting an organization hierarchy fails
+func (h *Handler) DeletionFailed(ctx echo.Context, err *DeletionError) error {
+	if err := ctx.JSON(http.StatusUnprocessableEntity, err.Reply()); err != nil {
+		return err
+	}
+
+	return err
+}
+
 // Success returns a 200 OK response with the response object.
 func (h *Handler) Success(ctx echo.Context, rep interface{}) error {
 	return ctx.JSON(http.StatusOK, rep)
 }
 
+// Accepted returns a 202 Accepted response with the response object.
+func (h *Handler) Accepted(ctx echo.Context, rep interface{}) error {
+	return ctx.JSON(http.StatusAccepted, rep)
+}
+
 // Created returns a 201 Created response with the response object.
 func (h *Handler) Created(ctx echo.Context, rep interface{}) error {
 	return ctx.JSON(http.StatusCreated, rep)
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// provisioningTracker keeps track of every organization created during a single provisioning request
+// so the hierarchy can be compensated if a later step fails
+type provisioningTracker struct {
+	mu      sync.Mutex
+	created []models.OrgDetails
+	// onCreate is an optional callback used to report progress every time an organization is created
+	onCreate func(models.OrgDetails)
+	// slots limits the number of organizations being created at the same time
+	slots chan struct{}
+}
+
+// newProvisioningTracker returns a tracker that allows up to concurrency organizations to be created at the same time
+func newProvisioningTracker(concurrency int, onCreate func(models.OrgDetails)) *provisioningTracker {
+	return &provisioningTracker{
+		onCreate: onCreate,
+		slots:    make(chan struct{}, max(concurrency, 1)),
+	}
+}
+
+// acquire blocks until a slot is available to create an organization or the context is canceled;
+// the returned function must be called to release the slot
+func (t *provisioningTracker) acquire(ctx context.Context) (func(), error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// a slot can become available at the same time the context is canceled, no new organization is created once it is
+	if err := ctx.Err(); err != nil {
+		<-t.slots
+
+		return nil, err
+	}
+
+	return func() { <-t.slots }, nil
+}
+
+// add records an organization that was created in openlane
+func (t *provisioningTracker) add(org models.OrgDetails) {
+	t.mu.Lock()
+	t.created = append(t.created, org)
+	t.mu.Unlock()
+
+	if t.onCreate != nil {
+		t.onCreate(org)
+	}
+}
+
+// createdOrganizations returns a copy of the organizations created so far, in creation order
+func (t *provisioningTracker) createdOrganizations() []models.OrgDetails {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]models.OrgDetails, len(t.created))
+	copy(out, t.created)
+
+	return out
+}
+
+// ProvisioningError is returned when provisioning an organization hierarchy fails part way through
+// and contains the organizations that were created, rolled back, and could not be cleaned up
+type ProvisioningError struct {
+	// Err is the error that caused provisioning to fail
+	Err error
+	// Created contains all organizations created before the failure, in creation order
+	Created []models.OrgDetails
+	// RolledBack contains the organizations that were successfully deleted during rollback
+	RolledBack []models.OrgDetails
+	// Orphaned contains the organizations that could not be deleted during rollback
+	Orphaned []models.OrgDetails
+}
+
+// Error returns the ProvisioningError in string format
+func (e *ProvisioningError) Error() string {
+	if len(e.Orphaned) > 0 {
+		return fmt.Sprintf("%s: %d organization(s) could not be rolled back", e.Err.Error(), len(e.Orphaned))
+	}
+
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error that caused provisioning to fail
+func (e *ProvisioningError) Unwrap() error {
+	return e.Err
+}
+
+// Reply returns the response object for the failed provisioning request
+func (e *ProvisioningError) Reply() models.ProvisioningFailureReply {
+	return models.ProvisioningFailureReply{
+		Reply:      rout.ErrorResponse(e),
+		Created:    e.Created,
+		RolledBack: e.RolledBack,
+		Orphaned:   e.Orphaned,
+	}
+}
+
+// rollback deletes every organization recorded by the tracker in reverse creation order so children are
+// removed before their parents; the request context may already be canceled so the deletes are detached from it
+func (h *Handler) rollback(ctx context.Context, t *provisioningTracker, cause error) *ProvisioningError {
+	ctx = context.WithoutCancel(ctx)
+
+	perr := &ProvisioningError{
+		Err:     cause,
+		Created: t.createdOrganizations(),
+	}
+
+	for i := len(perr.Created) - 1; i >= 0; i-- {
+		org := perr.Created[i]
+
+		if _, err := h.openlane(ctx).DeleteOrganization(ctx, org.ID); err != nil {
+			log.Error().Err(err).Str("id", org.ID).Str("name", org.Name).Msg("failed to roll back organization")
+
+			perr.Orphaned = append(perr.Orphaned, org)
+
+			continue
+		}
+
+		perr.RolledBack = append(perr.RolledBack, org)
+	}
+
+	log.Warn().Err(cause).
+		Int("created", len(perr.Created)).
+		Int("rolled_back", len(perr.RolledBack)).
+		Int("orphaned", len(perr.Orphaned)).
+		Msg("organization provisioning failed, rolled back created organizations")
+
+	return perr
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
+)
+
+// ErrDeleteFailed is returned when one or more organizations in the hierarchy could not be deleted
+var ErrDeleteFailed = errors.New("failed to delete organization")
+
+// DeletionError is returned when an organization hierarchy could only be partially deleted
+type DeletionError struct {
+	// Deleted contains the organizations that were deleted before the failure, leaf first
+	Deleted []models.OrgDetails
+	// Failed contains the organizations that could not be deleted
+	Failed []models.OrgDetails
+}
+
+// Error returns the DeletionError in string format
+func (e *DeletionError) Error() string {
+	return fmt.Sprintf("%s: %d organization(s) could not be deleted", ErrDeleteFailed.Error(), len(e.Failed))
+}
+
+// Unwrap returns ErrDeleteFailed
+func (e *DeletionError) Unwrap() error {
+	return ErrDeleteFailed
+}
+
+// Reply returns the response object for the failed delete request
+func (e *DeletionError) Reply() models.OrganizationDeleteReply {
+	return models.OrganizationDeleteReply{
+		Reply:         rout.ErrorResponse(e),
+		Organizations: e.Deleted,
+		Failed:        e.Failed,
+	}
+}
+
+// DeleteOrganizationHandler is the handler for deleting an organization and every organization underneath it
+func (h *Handler) DeleteOrganizationHandler(ctx echo.Context) error {
+	dryRun, _ := strconv.ParseBool(ctx.QueryParam("dryRun"))
+
+	reqCtx, err := h.requestContext(ctx)
+	if err != nil {
+		return h.Unauthorized(ctx, err)
+	}
+
+	tree, err := h.loadHierarchy(reqCtx, ctx.PathParam("id"), false)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			return h.NotFound(ctx, err)
+		}
+
+		return h.openlaneFailure(ctx, err, h.InternalServerError)
+	}
+
+	if dryRun {
+		orgs := []models.OrgDetails{}
+
+		for _, level := range tree.levels() {
+			for _, n := range level {
+				orgs = append(orgs, n.details())
+			}
+		}
+
+		return h.Success(ctx, models.OrganizationDeleteReply{
+			Reply:         rout.Reply{Success: true},
+			DryRun:        true,
+			Organizations: orgs,
+		})
+	}
+
+	entry := models.AuditEntry{Action: models.AuditActionDelete, OrganizationID: tree.ID}
+
+	deleted, err := h.deleteHierarchy(reqCtx, tree)
+	if err != nil {
+		var derr *DeletionError
+		if errors.As(err, &derr) {
+			entry.Deleted = organizationIDs(derr.Deleted)
+			h.audit(reqCtx, entry, nil, derr)
+
+			return h.DeletionFailed(ctx, derr)
+		}
+
+		h.audit(reqCtx, entry, nil, err)
+
+		return h.InternalServerError(ctx, err)
+	}
+
+	entry.Deleted = organizationIDs(deleted)
+	h.audit(reqCtx, entry, nil, nil)
+
+	h.notify(webhook.EventOrganizationDeleted, models.OrganizationEvent{
+		Organization: tree.reply(),
+		Deleted:      deleted,
+	})
+
+	return h.Success(ctx, models.OrganizationDeleteReply{
+		Reply:         rout.Reply{Success: true},
+		Organizations: deleted,
+	})
+}
+
+// deleteHierarchy deletes the organizations in the tree a level at a time starting with the deepest level so children are
+// always removed before their parents; organizations on the same level are deleted in parallel. Deletion stops after
+// the first level with a failure because the parents of the failed organizations cannot be removed. The deletes are
+// detached from the request context so a disconnected client does not leave the hierarchy half deleted
+func (h *Handler) deleteHierarchy(ctx context.Context, tree *orgTree) ([]models.OrgDetails, error) {
+	ctx = context.WithoutCancel(ctx)
+
+	var (
+		mu      sync.Mutex
+		deleted []models.OrgDetails
+		failed  []models.OrgDetails
+	)
+
+	for _, level := range tree.levels() {
+		var g errgroup.Group
+
+		g.SetLimit(max(h.Concurrency, 1))
+
+		for _, n := range level {
+			g.Go(func() error {
+				_, err := h.openlane(ctx).DeleteOrganization(ctx, n.ID)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if err != nil {
+					log.Error().Err(err).Str("id", n.ID).Str("name", n.Name).Msg("failed to delete organization")
+
+					failed = append(failed, n.details())
+
+					return nil
+				}
+
+				deleted = append(deleted, n.details())
+
+				return nil
+			})
+		}
+
+		_ = g.Wait()
+
+		if len(failed) > 0 {
+			return nil, &DeletionError{
+				Deleted: deleted,
+				Failed:  failed,
+			}
+		}
+	}
+
+	log.Info().Str("id", tree.ID).Int("deleted", len(deleted)).Msg("deleted organization hierarchy")
+
+	return deleted, nil
+}
+
+// BindDeleteOrganizationHandler is used to bind the delete organization endpoint to the OpenAPI schema
+func (h *Handler) BindDeleteOrganizationHandler() *openapi3.Operation {
+	del := openapi3.NewOperation()
+	del.Description = "DeleteOrganization deletes an organization and every organization underneath it, leaf first"
+	del.OperationID = "DeleteOrganizationHandler"
+	del.Security = authenticated()
+
+	del.AddParameter(openapi3.NewPathParameter("id").
+		WithDescription("the id of the root organization").
+		WithSchema(openapi3.NewStringSchema()))
+	del.AddParameter(openapi3.NewQueryParameter("dryRun").
+		WithDescription("return the organizations that would be deleted without deleting them").
+		WithSchema(openapi3.NewBoolSchema()))
+
+	h.AddResponse("OrganizationDeleteReply", "success", models.ExampleOrganizationDeleteResponse, del, http.StatusOK)
+	h.AddResponse("OrganizationDeleteReply", "one or more organizations could not be deleted", models.ExampleOrganizationDeleteFailureResponse, del, http.StatusUnprocessableEntity)
+	del.AddResponse(http.StatusInternalServerError, internalServerError())
+	del.AddResponse(http.StatusUnauthorized, unauthorized())
+	addOpenlaneRequest(del)
+	del.AddResponse(http.StatusNotFound, notFound())
+
+	return del
+}
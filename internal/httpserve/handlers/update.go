@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/rs/zerolog/log"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
+)
+
+// UpdateOrganizationHandler is the handler for adding environments, buckets, or relationships to an existing hierarchy
+func (h *Handler) UpdateOrganizationHandler(ctx echo.Context) error {
+	var in models.OrganizationUpdateRequest
+	if err := ctx.Bind(&in); err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	if err := in.Validate(); err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	reqCtx, err := h.requestContext(ctx)
+	if err != nil {
+		return h.Unauthorized(ctx, err)
+	}
+
+	tree, err := h.loadHierarchy(reqCtx, ctx.PathParam("id"), true)
+	if err != nil {
+		if errors.Is(err, ErrOrganizationNotFound) {
+			return h.NotFound(ctx, err)
+		}
+
+		return h.openlaneFailure(ctx, err, h.InternalServerError)
+	}
+
+	bp := extendHierarchy(tree, in)
+
+	if err := validateSize("environments", bp); err != nil {
+		return h.InvalidInput(ctx, err)
+	}
+
+	log.Debug().Str("id", tree.ID).
+		Strs("environments", in.Environments).
+		Strs("buckets", in.Buckets).
+		Strs("relationships", in.Relationships).
+		Msg("updating organization")
+
+	tracker := newProvisioningTracker(h.Concurrency, nil)
+
+	entry := models.AuditEntry{Action: models.AuditActionUpdate, OrganizationID: tree.ID}
+
+	if err := h.createMissingNodes(reqCtx, tree, []string{}, bp.Children, tracker); err != nil {
+		perr := h.rollback(reqCtx, tracker, err)
+
+		entry.Created = organizationIDs(perr.Created)
+		entry.Deleted = organizationIDs(perr.RolledBack)
+		h.audit(reqCtx, entry, in, perr)
+
+		return h.ProvisioningFailed(ctx, perr)
+	}
+
+	entry.Created = organizationIDs(tracker.createdOrganizations())
+	h.audit(reqCtx, entry, in, nil)
+
+	// reload the hierarchy so the response contains the existing and new organizations in order
+	tree, err = h.loadHierarchy(reqCtx, tree.ID, true)
+	if err != nil {
+		return h.InternalServerError(ctx, err)
+	}
+
+	out := models.OrganizationUpdateReply{
+		OrganizationReply: *tree.reply(),
+		Created:           tracker.createdOrganizations(),
+	}
+
+	h.notify(webhook.EventOrganizationUpdated, models.OrganizationEvent{
+		Organization: &out.OrganizationReply,
+		Created:      out.Created,
+	})
+
+	return h.Success(ctx, out)
+}
+
+// extendHierarchy returns the hierarchy to create for the request underneath the existing tree. New environments
+// receive the nodes found underneath the existing environments, at any depth so hierarchies created from a template are
+// extended in the same way, and the buckets and relationships in the request are added to every environment; nothing
+// else is added to the existing environments
+func extendHierarchy(tree *orgTree, in models.OrganizationUpdateRequest) blueprint.Blueprint {
+	requested := []blueprint.Node{}
+
+	for _, bucket := range in.Buckets {
+		requested = mergeNodes(requested, blueprint.Node{Name: bucket})
+	}
+
+	// relationships can only be added underneath the relationships bucket
+	if len(in.Relationships) > 0 {
+		bucket := blueprint.Node{Name: blueprint.RelationshipsBucket}
+
+		for _, relationship := range in.Relationships {
+			bucket.Children = mergeNodes(bucket.Children, blueprint.Node{Name: relationship})
+		}
+
+		requested = mergeNodes(requested, bucket)
+	}
+
+	bp := blueprint.Blueprint{
+		Name:    blueprint.DefaultName,
+		Version: 1,
+	}
+
+	// the nodes shared by the environments, the existing names are kept when the request uses a different case
+	shared := []blueprint.Node{}
+
+	for _, env := range tree.Children {
+		bp.Children = append(bp.Children, blueprint.Node{Name: env.DisplayName, Children: requested})
+		shared = mergeNodes(shared, treeNodes(env.Children, []string{env.DisplayName})...)
+	}
+
+	for _, env := range in.Environments {
+		if slices.ContainsFunc(bp.Children, func(n blueprint.Node) bool { return strings.EqualFold(n.Name, env) }) {
+			continue
+		}
+
+		bp.Children = append(bp.Children, blueprint.Node{Name: env, Children: mergeNodes(shared, requested...)})
+	}
+
+	return bp
+}
+
+// treeNodes returns the blueprint nodes for the organizations in an existing tree, along with the tags that follow their
+// path from the root
+func treeNodes(orgs []*orgTree, path []string) []blueprint.Node {
+	out := make([]blueprint.Node, 0, len(orgs))
+
+	for _, org := range orgs {
+		orgPath := append(slices.Clone(path), org.DisplayName)
+
+		node := blueprint.Node{
+			Name:        org.DisplayName,
+			Description: org.Description,
+			Children:    treeNodes(org.Children, orgPath),
+		}
+
+		if len(org.Tags) > len(orgPath) {
+			node.Tags = org.Tags[len(orgPath):]
+		}
+
+		out = append(out, node)
+	}
+
+	return out
+}
+
+// mergeNodes returns the nodes combined with the nodes to add; nodes with the same name, ignoring case, are combined
+// along with their children and keep the name they already had. The nodes passed in are not modified
+func mergeNodes(nodes []blueprint.Node, add ...blueprint.Node) []blueprint.Node {
+	out := slices.Clone(nodes)
+
+	for _, node := range add {
+		i := slices.IndexFunc(out, func(n blueprint.Node) bool { return strings.EqualFold(n.Name, node.Name) })
+		if i < 0 {
+			out = append(out, node)
+
+			continue
+		}
+
+		out[i].Children = mergeNodes(out[i].Children, node.Children...)
+	}
+
+	return out
+}
+
+// createMissingNodes creates the nodes that do not already exist underneath the organization, along with all of their
+// descendants, using the same naming and tagging rules as a new hierarchy; existing nodes are checked for missing children
+func (h *Handler) createMissingNodes(ctx context.Context, parent *orgTree, path []string, nodes []blueprint.Node, tracker *provisioningTracker) error {
+	missing := []blueprint.Node{}
+
+	for _, node := range nodes {
+		i := slices.IndexFunc(parent.Children, func(c *orgTree) bool { return strings.EqualFold(c.DisplayName, node.Name) })
+		if i < 0 {
+			missing = append(missing, node)
+
+			continue
+		}
+
+		existing := parent.Children[i]
+
+		if err := h.createMissingNodes(ctx, existing, append(slices.Clone(path), existing.DisplayName), node.Children, tracker); err != nil {
+			return err
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	_, err := h.createChildOrganizations(ctx, parent.Name, parent.ID, path, missing, tracker)
+
+	return err
+}
+
+// BindUpdateOrganizationHandler is used to bind the update organization endpoint to the OpenAPI schema
+func (h *Handler) BindUpdateOrganizationHandler() *openapi3.Operation {
+	update := openapi3.NewOperation()
+	update.Description = "UpdateOrganization adds environments, buckets, or relationships to an existing organization hierarchy, only missing organizations are created"
+	update.OperationID = "UpdateOrganizationHandler"
+	update.Security = authenticated()
+
+	update.AddParameter(openapi3.NewPathParameter("id").
+		WithDescription("the id of the root organization").
+		WithSchema(openapi3.NewStringSchema()))
+
+	h.AddRequestBody("OrganizationUpdateRequest", models.ExampleOrganizationUpdateRequest, update)
+	h.AddResponse("OrganizationUpdateReply", "success", models.ExampleOrganizationUpdateResponse, update, http.StatusOK)
+	h.AddResponse("ProvisioningFailureReply", "adding organizations failed and created organizations were rolled back", models.ExampleProvisioningFailureResponse, update, http.StatusUnprocessableEntity)
+	update.AddResponse(http.StatusInternalServerError, internalServerError())
+	update.AddResponse(http.StatusUnauthorized, unauthorized())
+	addOpenlaneRequest(update)
+	h.AddResponse("ValidationFailureReply", "the request has invalid fields", models.ExampleValidationFailureResponse, update, http.StatusBadRequest)
+	update.AddResponse(http.StatusNotFound, notFound())
+
+	return update
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+var (
+	// UnauthorizedErrCode is returned when openlane rejects the credentials used for the request
+	UnauthorizedErrCode rout.ErrorCode = "UNAUTHORIZED"
+	// ForbiddenErrCode is returned when the credentials used for the request are not allowed to perform the action in openlane
+	ForbiddenErrCode rout.ErrorCode = "FORBIDDEN"
+	// AlreadyExistsErrCode is returned when an organization with the same name already exists in openlane
+	AlreadyExistsErrCode rout.ErrorCode = "ALREADY_EXISTS"
+	// RateLimitedErrCode is returned when openlane rate limited the request
+	RateLimitedErrCode rout.ErrorCode = "RATE_LIMITED"
+	// UpstreamErrCode is returned when openlane failed to process the request
+	UpstreamErrCode rout.ErrorCode = "UPSTREAM_ERROR"
+	// UpstreamUnavailableErrCode is returned when openlane could not be reached or is unavailable
+	UpstreamUnavailableErrCode rout.ErrorCode = "UPSTREAM_UNAVAILABLE"
+)
+
+// defaultRetryAfter is returned in the Retry-After header when openlane does not say when to retry
+const defaultRetryAfter = 30 * time.Second
+
+// OpenlaneError is an error returned by openlane along with the status and error code returned to the caller
+type OpenlaneError struct {
+	// Err is the error returned by the openlane client
+	Err error
+	// StatusCode is the http status code returned to the caller
+	StatusCode int
+	// Code is the error code returned to the caller
+	Code rout.ErrorCode
+	// Message describes the error without the raw response from openlane
+	Message string
+	// RetryAfter is how long the caller should wait before retrying, only set for rate limited and unavailable errors
+	RetryAfter time.Duration
+}
+
+// Error returns the OpenlaneError in string format
+func (e *OpenlaneError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error returned by the openlane client
+func (e *OpenlaneError) Unwrap() error {
+	return e.Err
+}
+
+// openlaneError translates an error returned by the openlane client into an OpenlaneError based on the http status
+// of the response, the code in the GraphQL error extensions, or the GraphQL error message; errors that cannot be
+// translated are returned unchanged
+func openlaneError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var oerr *OpenlaneError
+	if errors.As(err, &oerr) {
+		return err
+	}
+
+	var (
+		gqlErr  *clientv2.ErrorResponse
+		authErr *openlaneclient.AuthenticationError
+		reqErr  *openlaneclient.RequestError
+		netErr  net.Error
+	)
+
+	switch {
+	case errors.As(err, &gqlErr) && gqlErr.NetworkError != nil:
+		return fromStatus(err, gqlErr.NetworkError.Code, "")
+	case errors.As(err, &gqlErr) && gqlErr.GqlErrors != nil:
+		return fromGraphQL(err, *gqlErr.GqlErrors)
+	case errors.As(err, &authErr):
+		return fromStatus(err, authErr.StatusCode, authErr.Body)
+	case errors.As(err, &reqErr):
+		return fromStatus(err, reqErr.StatusCode, reqErr.Body)
+	case errors.As(err, &netErr):
+		return &OpenlaneError{
+			Err:        err,
+			StatusCode: http.StatusServiceUnavailable,
+			Code:       UpstreamUnavailableErrCode,
+			Message:    "openlane is unavailable: " + netErr.Error(),
+			RetryAfter: defaultRetryAfter,
+		}
+	}
+
+	return err
+}
+
+// fromStatus translates the http status of an openlane response
+func fromStatus(err error, status int, message string) error {
+	if message == "" {
+		message = strings.ToLower(http.StatusText(status))
+	}
+
+	oerr := &OpenlaneError{
+		Err:     err,
+		Message: fmt.Sprintf("openlane returned status %d: %s", status, message),
+	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		oerr.StatusCode, oerr.Code = http.StatusUnauthorized, UnauthorizedErrCode
+	case status == http.StatusForbidden:
+		oerr.StatusCode, oerr.Code = http.StatusForbidden, ForbiddenErrCode
+	case status == http.StatusConflict:
+		oerr.StatusCode, oerr.Code = http.StatusConflict, AlreadyExistsErrCode
+	case status == http.StatusTooManyRequests:
+		oerr.StatusCode, oerr.Code, oerr.RetryAfter = http.StatusTooManyRequests, RateLimitedErrCode, defaultRetryAfter
+	case status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout:
+		oerr.StatusCode, oerr.Code, oerr.RetryAfter = http.StatusServiceUnavailable, UpstreamUnavailableErrCode, defaultRetryAfter
+	case status >= http.StatusInternalServerError:
+		oerr.StatusCode, oerr.Code = http.StatusBadGateway, UpstreamErrCode
+	default:
+		return err
+	}
+
+	return oerr
+}
+
+// fromGraphQL translates the first GraphQL error that has a known extension code or message
+func fromGraphQL(err error, errs gqlerror.List) error {
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+
+		status := graphQLStatus(e)
+		if status == 0 {
+			continue
+		}
+
+		oerr, ok := fromStatus(err, status, e.Message).(*OpenlaneError)
+		if !ok {
+			continue
+		}
+
+		oerr.Message = e.Message
+
+		if seconds, ok := retryAfter(e.Extensions); ok {
+			oerr.RetryAfter = seconds
+		}
+
+		return oerr
+	}
+
+	return err
+}
+
+// graphQLStatus returns the http status for a GraphQL error using the code in its extensions, falling back to the
+// messages returned by openlane; zero is returned when the error is not known
+func graphQLStatus(e *gqlerror.Error) int {
+	code, _ := e.Extensions["code"].(string)
+
+	switch strings.ToUpper(code) {
+	case "UNAUTHENTICATED", "UNAUTHORIZED":
+		return http.StatusUnauthorized
+	case "FORBIDDEN", "PERMISSION_DENIED":
+		return http.StatusForbidden
+	case "ALREADY_EXISTS", "CONFLICT":
+		return http.StatusConflict
+	case "RATE_LIMITED", "TOO_MANY_REQUESTS":
+		return http.StatusTooManyRequests
+	case "UNAVAILABLE", "SERVICE_UNAVAILABLE":
+		return http.StatusServiceUnavailable
+	case "INTERNAL_SERVER_ERROR":
+		return http.StatusBadGateway
+	}
+
+	msg := strings.ToLower(e.Message)
+
+	switch {
+	case strings.Contains(msg, "already exists"):
+		return http.StatusConflict
+	case strings.Contains(msg, "unauthorized"), strings.Contains(msg, "unauthenticated"):
+		return http.StatusUnauthorized
+	case strings.Contains(msg, "permission denied"), strings.Contains(msg, "not accessible"):
+		return http.StatusForbidden
+	case strings.Contains(msg, "rate limit"):
+		return http.StatusTooManyRequests
+	}
+
+	return 0
+}
+
+// retryAfter returns the retry delay in seconds from the GraphQL error extensions
+func retryAfter(extensions map[string]any) (time.Duration, bool) {
+	for _, key := range []string{"retryAfter", "retry_after"} {
+		switch v := extensions[key].(type) {
+		case float64:
+			return time.Duration(v) * time.Second, true
+		case int:
+			return time.Duration(v) * time.Second, true
+		case string:
+			if seconds, err := strconv.Atoi(v); err == nil {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// setRetryAfter sets the Retry-After header when the error tells the caller when to retry
+func setRetryAfter(ctx echo.Context, err *OpenlaneError) {
+	if err.RetryAfter > 0 {
+		ctx.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(err.RetryAfter.Seconds())))
+	}
+}
+
+// openlaneFailure returns the status and error code for errors returned by openlane, otherwise the fallback
+// response is returned
+func (h *Handler) openlaneFailure(ctx echo.Context, err error, fallback func(echo.Context, error) error) error {
+	var oerr *OpenlaneError
+	if !errors.As(openlaneError(err), &oerr) {
+		return fallback(ctx, err)
+	}
+
+	return h.OpenlaneFailed(ctx, oerr)
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/clientv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/utils/rout"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// networkError returns the error returned by the openlane client for a non-200 response
+func networkError(status int) error {
+	return &clientv2.ErrorResponse{NetworkError: &clientv2.HTTPError{Code: status, Message: http.StatusText(status)}}
+}
+
+// graphQLError returns the error returned by the openlane client for a GraphQL error
+func graphQLError(message string, extensions map[string]any) error {
+	return &clientv2.ErrorResponse{GqlErrors: &gqlerror.List{{Message: message, Extensions: extensions}}}
+}
+
+func TestOpenlaneError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantCode       rout.ErrorCode
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:       "unauthorized",
+			err:        networkError(http.StatusUnauthorized),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   UnauthorizedErrCode,
+		},
+		{
+			name:       "forbidden",
+			err:        networkError(http.StatusForbidden),
+			wantStatus: http.StatusForbidden,
+			wantCode:   ForbiddenErrCode,
+		},
+		{
+			name:           "rate limited",
+			err:            networkError(http.StatusTooManyRequests),
+			wantStatus:     http.StatusTooManyRequests,
+			wantCode:       RateLimitedErrCode,
+			wantRetryAfter: defaultRetryAfter,
+		},
+		{
+			name:       "internal server error",
+			err:        networkError(http.StatusInternalServerError),
+			wantStatus: http.StatusBadGateway,
+			wantCode:   UpstreamErrCode,
+		},
+		{
+			name:           "unavailable",
+			err:            networkError(http.StatusServiceUnavailable),
+			wantStatus:     http.StatusServiceUnavailable,
+			wantCode:       UpstreamUnavailableErrCode,
+			wantRetryAfter: defaultRetryAfter,
+		},
+		{
+			name:           "unreachable",
+			err:            &net.OpError{Op: "dial", Net: "tcp", Err: errUpstream},
+			wantStatus:     http.StatusServiceUnavailable,
+			wantCode:       UpstreamUnavailableErrCode,
+			wantRetryAfter: defaultRetryAfter,
+		},
+		{
+			name:       "already exists message",
+			err:        graphQLError("organization already exists", nil),
+			wantStatus: http.StatusConflict,
+			wantCode:   AlreadyExistsErrCode,
+		},
+		{
+			name:           "rate limited extension",
+			err:            graphQLError("slow down", map[string]any{"code": "RATE_LIMITED", "retryAfter": float64(5)}),
+			wantStatus:     http.StatusTooManyRequests,
+			wantCode:       RateLimitedErrCode,
+			wantRetryAfter: 5 * time.Second,
+		},
+		{
+			name:       "unauthenticated extension",
+			err:        graphQLError("could not identify caller", map[string]any{"code": "UNAUTHENTICATED"}),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   UnauthorizedErrCode,
+		},
+		{
+			name: "validation error",
+			err:  graphQLError("validator failed for field \"name\"", nil),
+		},
+		{
+			name: "bad request",
+			err:  networkError(http.StatusBadRequest),
+		},
+		{
+			name: "not an openlane error",
+			err:  errUpstream,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := openlaneError(tc.err)
+			require.ErrorIs(t, err, tc.err)
+
+			oerr, ok := err.(*OpenlaneError)
+			if tc.wantStatus == 0 {
+				assert.False(t, ok)
+
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, tc.wantStatus, oerr.StatusCode)
+			assert.Equal(t, tc.wantCode, oerr.Code)
+			assert.Equal(t, tc.wantRetryAfter, oerr.RetryAfter)
+		})
+	}
+}
+
+func TestOrganizationHandlerOpenlaneErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantCode       rout.ErrorCode
+		wantRetryAfter string
+	}{
+		{
+			name:       "conflict",
+			err:        graphQLError("organization already exists", nil),
+			wantStatus: http.StatusConflict,
+			wantCode:   AlreadyExistsErrCode,
+		},
+		{
+			name:           "rate limited",
+			err:            networkError(http.StatusTooManyRequests),
+			wantStatus:     http.StatusTooManyRequests,
+			wantCode:       RateLimitedErrCode,
+			wantRetryAfter: "30",
+		},
+		{
+			name:       "bad gateway",
+			err:        networkError(http.StatusInternalServerError),
+			wantStatus: http.StatusBadGateway,
+			wantCode:   UpstreamErrCode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFakeOpenlane()
+			f.failCreate["meow.testing"] = tc.err
+
+			h := newTestHandler(f)
+
+			ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"meow","description":"meow"}`)
+
+			require.ErrorIs(t, h.OrganizationHandler(ctx), tc.err)
+			requireStatus(t, rec, tc.wantStatus)
+			assert.Equal(t, tc.wantRetryAfter, rec.Header().Get("Retry-After"))
+
+			var out models.ProvisioningFailureReply
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+			assert.Equal(t, tc.wantCode, out.ErrorCode)
+
+			// the organizations created before the failure are rolled back
+			assert.Len(t, out.RolledBack, len(f.created))
+			assert.Empty(t, f.orgs)
+		})
+	}
+}
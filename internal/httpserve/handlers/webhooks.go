@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// notify sends a webhook for the organization lifecycle event when webhooks are enabled; the webhook is delivered in
+// the background so a failure to queue it is logged and does not change the response
+func (h *Handler) notify(eventType string, event models.OrganizationEvent) {
+	if h.Webhooks == nil {
+		return
+	}
+
+	if err := h.Webhooks.Send(eventType, event); err != nil {
+		log.Error().Err(err).Str("type", eventType).Msg("failed to send webhook")
+	}
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
+)
+
+func TestOrganizationWebhooks(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []webhook.Event
+		data   []models.OrganizationEvent
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if err := webhook.Verify("meow", r.Header, body, 0); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var event struct {
+			webhook.Event
+			Data models.OrganizationEvent `json:"data"`
+		}
+
+		_ = json.Unmarshal(body, &event)
+
+		mu.Lock()
+		events = append(events, event.Event)
+		data = append(data, event.Data)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+
+	dispatcher, err := webhook.New(webhook.Config{
+		URLs:        []string{srv.URL},
+		Secret:      "meow",
+		Timeout:     time.Second,
+		MaxAttempts: 1,
+		Workers:     1,
+		QueueSize:   10,
+	})
+	require.NoError(t, err)
+
+	f := newFakeOpenlane()
+	h := newTestHandler(f)
+	h.Webhooks = dispatcher
+
+	ctx, rec := newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"MITB Inc.","environments":["production"],"buckets":["assets"]}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	var out models.OrganizationReply
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+	// a dry run does not send a webhook
+	ctx, rec = newTestContext(t, http.MethodPost, "/v1/organization", `{"name":"Meow Corp","dryRun":true}`)
+
+	require.NoError(t, h.OrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	ctx, rec = newTestContext(t, http.MethodPatch, "/v1/organization/"+out.ID, `{"environments":["staging"]}`)
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: out.ID}})
+
+	require.NoError(t, h.UpdateOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	ctx, rec = newTestContext(t, http.MethodDelete, "/v1/organization/"+out.ID, "")
+	ctx.SetPathParams(echo.PathParams{{Name: "id", Value: out.ID}})
+
+	require.NoError(t, h.DeleteOrganizationHandler(ctx))
+	requireStatus(t, rec, http.StatusOK)
+
+	require.NoError(t, dispatcher.Close(context.Background()))
+
+	require.Len(t, events, 3)
+
+	assert.Equal(t, webhook.EventOrganizationCreated, events[0].Type)
+	require.NotNil(t, data[0].Organization)
+	assert.Equal(t, out.ID, data[0].Organization.ID)
+	assert.Equal(t, "MITB Inc.", data[0].Organization.Name)
+	require.Len(t, data[0].Organization.Environments, 1)
+
+	assert.Equal(t, webhook.EventOrganizationUpdated, events[1].Type)
+	assert.Len(t, data[1].Organization.Environments, 2)
+	assert.Len(t, data[1].Created, 2)
+
+	assert.Equal(t, webhook.EventOrganizationDeleted, events[2].Type)
+	assert.Equal(t, out.ID, data[2].Organization.ID)
+	assert.Len(t, data[2].Deleted, 5)
+}
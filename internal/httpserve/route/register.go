@@ -13,9 +13,10 @@ func registerOrganizationHandler(router *Router) (err error) {
 	name := "Organization"
 
 	route := echo.Route{
-		Name:   name,
-		Method: method,
-		Path:   path,
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
 		Handler: func(c echo.Context) error {
 			return router.Handler.OrganizationHandler(c)
 		},
@@ -29,3 +30,203 @@ func registerOrganizationHandler(router *Router) (err error) {
 
 	return nil
 }
+
+// registerBulkOrganizationHandler registers the bulk organization handler and route
+func registerBulkOrganizationHandler(router *Router) (err error) {
+	path := "/organizations/bulk"
+	method := http.MethodPost
+	name := "BulkOrganization"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.BulkOrganizationHandler(c)
+		},
+	}
+
+	bulkOperation := router.Handler.BindBulkOrganizationHandler()
+
+	if err := router.Addv1Route(path, method, bulkOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerListOrganizationsHandler registers the list organizations handler and route
+func registerListOrganizationsHandler(router *Router) (err error) {
+	path := "/organizations"
+	method := http.MethodGet
+	name := "ListOrganizations"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.ListOrganizationsHandler(c)
+		},
+	}
+
+	listOperation := router.Handler.BindListOrganizationsHandler()
+
+	if err := router.Addv1Route(path, method, listOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerGetOrganizationHandler registers the get organization handler and route
+func registerGetOrganizationHandler(router *Router) (err error) {
+	path := "/organization/:id"
+	method := http.MethodGet
+	name := "GetOrganization"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.GetOrganizationHandler(c)
+		},
+	}
+
+	getOperation := router.Handler.BindGetOrganizationHandler()
+
+	if err := router.Addv1Route("/organization/{id}", method, getOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerUpdateOrganizationHandler registers the update organization handler and route
+func registerUpdateOrganizationHandler(router *Router) (err error) {
+	path := "/organization/:id"
+	method := http.MethodPatch
+	name := "UpdateOrganization"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.UpdateOrganizationHandler(c)
+		},
+	}
+
+	updateOperation := router.Handler.BindUpdateOrganizationHandler()
+
+	if err := router.Addv1Route("/organization/{id}", method, updateOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerReconcileOrganizationHandler registers the reconcile organization handler and route
+func registerReconcileOrganizationHandler(router *Router) (err error) {
+	path := "/organization/:id/reconcile"
+	method := http.MethodPost
+	name := "ReconcileOrganization"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.ReconcileOrganizationHandler(c)
+		},
+	}
+
+	reconcileOperation := router.Handler.BindReconcileOrganizationHandler()
+
+	if err := router.Addv1Route("/organization/{id}/reconcile", method, reconcileOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerDeleteOrganizationHandler registers the delete organization handler and route
+func registerDeleteOrganizationHandler(router *Router) (err error) {
+	path := "/organization/:id"
+	method := http.MethodDelete
+	name := "DeleteOrganization"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.DeleteOrganizationHandler(c)
+		},
+	}
+
+	deleteOperation := router.Handler.BindDeleteOrganizationHandler()
+
+	if err := router.Addv1Route("/organization/{id}", method, deleteOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerOrganizationJobHandler registers the organization provisioning job status handler and route
+func registerOrganizationJobHandler(router *Router) (err error) {
+	path := "/organization/jobs/:id"
+	method := http.MethodGet
+	name := "OrganizationJob"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.OrganizationJobHandler(c)
+		},
+	}
+
+	jobOperation := router.Handler.BindOrganizationJobHandler()
+
+	if err := router.Addv1Route("/organization/jobs/{id}", method, jobOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// registerAuditHandler registers the audit log handler and route
+func registerAuditHandler(router *Router) (err error) {
+	path := "/audit"
+	method := http.MethodGet
+	name := "Audit"
+
+	route := echo.Route{
+		Name:        name,
+		Method:      method,
+		Path:        path,
+		Middlewares: authMW,
+		Handler: func(c echo.Context) error {
+			return router.Handler.AuditHandler(c)
+		},
+	}
+
+	auditOperation := router.Handler.BindAuditHandler()
+
+	if err := router.Addv1Route(path, method, auditOperation, route); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package route
 
 import (
+	"slices"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -10,6 +11,7 @@ import (
 	"github.com/theopenlane/core/pkg/middleware/ratelimit"
 
 	"github.com/theopenlane/openlane-cloud/internal/httpserve/handlers"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/validation"
 )
 
 var (
@@ -25,11 +27,17 @@ type Router struct {
 	Echo    *echo.Echo
 	OAS     *openapi3.T
 	Handler *handlers.Handler
+	// AuthMiddleware authenticates requests to the versioned API
+	AuthMiddleware []echo.MiddlewareFunc
+	// Validation controls validating the requests of every route added with an operation against the OpenAPI schema
+	Validation validation.Config
+	// validator validates the requests against the OpenAPI schema, it is created when the first route is added
+	validator *validation.Validator
 }
 
 // AddRoute is used to add a route to the echo router and OpenAPI schema at the same time ensuring consistency between the spec and the server
 func (r *Router) AddRoute(pattern, method string, op *openapi3.Operation, route echo.Routable) error {
-	_, err := r.Echo.AddRoute(route)
+	_, err := r.Echo.AddRoute(r.validated(pattern, method, op, route))
 	if err != nil {
 		return err
 	}
@@ -43,7 +51,7 @@ func (r *Router) AddRoute(pattern, method string, op *openapi3.Operation, route
 func (r *Router) Addv1Route(pattern, method string, op *openapi3.Operation, route echo.Routable) error {
 	grp := r.VersionOne()
 
-	_, err := grp.AddRoute(route)
+	_, err := grp.AddRoute(r.validated(pattern, method, op, route))
 	if err != nil {
 		return err
 	}
@@ -57,7 +65,7 @@ func (r *Router) Addv1Route(pattern, method string, op *openapi3.Operation, rout
 func (r *Router) AddUnversionedRoute(pattern, method string, op *openapi3.Operation, route echo.Routable) error {
 	grp := r.Base()
 
-	_, err := grp.AddRoute(route)
+	_, err := grp.AddRoute(r.validated(pattern, method, op, route))
 	if err != nil {
 		return err
 	}
@@ -67,6 +75,24 @@ func (r *Router) AddUnversionedRoute(pattern, method string, op *openapi3.Operat
 	return nil
 }
 
+// validated returns the route with the middleware that validates its requests against the operation added as its last
+// middleware, so requests are authenticated first; the route is returned unchanged when the operation is not validated
+func (r *Router) validated(pattern, method string, op *openapi3.Operation, route echo.Routable) echo.Routable {
+	if r.validator == nil {
+		r.validator = validation.New(r.Validation, r.OAS)
+	}
+
+	validate := r.validator.Middleware(pattern, method, op)
+	if validate == nil {
+		return route
+	}
+
+	rt := route.ToRoute()
+	rt.Middlewares = append(slices.Clone(rt.Middlewares), validate)
+
+	return rt
+}
+
 // AddEchoOnlyRoute is used to add a route to the echo router without adding it to the OpenAPI schema
 func (r *Router) AddEchoOnlyRoute(route echo.Routable) error {
 	grp := r.Base()
@@ -96,12 +122,14 @@ func (r *Router) Base() *echo.Group {
 
 // RegisterRoutes with the echo routers - Router is defined within openapi.go
 func RegisterRoutes(router *Router) error {
-	// Middleware for restricted endpoints
-	restrictedEndpointsMW = append(restrictedEndpointsMW, mw...)
+	// Middleware for restricted endpoints, rebuilt on every call so routers registered after the first, e.g. in tests,
+	// do not inherit the middleware of earlier routers
+	restrictedEndpointsMW = append([]echo.MiddlewareFunc{}, mw...)
 	restrictedEndpointsMW = append(restrictedEndpointsMW, ratelimit.RateLimiterWithConfig(restrictedRateLimit)) // add restricted ratelimit middleware
 
 	// Middleware for authenticated endpoints
-	authMW = append(authMW, mw...)
+	authMW = append([]echo.MiddlewareFunc{}, mw...)
+	authMW = append(authMW, router.AuthMiddleware...)
 
 	// routeHandlers that take the router and handler as input
 	routeHandlers := []interface{}{
@@ -110,6 +138,14 @@ func RegisterRoutes(router *Router) error {
 		registerMetricsHandler,
 		registerOpenAPIHandler,
 		registerOrganizationHandler,
+		registerBulkOrganizationHandler,
+		registerListOrganizationsHandler,
+		registerGetOrganizationHandler,
+		registerUpdateOrganizationHandler,
+		registerDeleteOrganizationHandler,
+		registerReconcileOrganizationHandler,
+		registerOrganizationJobHandler,
+		registerAuditHandler,
 	}
 
 	for _, route := range routeHandlers {
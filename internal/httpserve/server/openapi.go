@@ -1,10 +1,20 @@
 package server
 
 import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3gen"
+	echo "github.com/theopenlane/echox"
 
 	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/handlers"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
 )
 
 // NewOpenAPISpec creates a new OpenAPI 3.1.0 specification based on the configured go interfaces and the operation types appended within the individual handlers
@@ -16,16 +26,44 @@ func NewOpenAPISpec() (*openapi3.T, error) {
 	securityschemes := make(openapi3.SecuritySchemes)
 	examples := make(openapi3.Examples)
 
-	generator := openapi3gen.NewGenerator(openapi3gen.UseAllExportedFields())
+	// every struct used by a schema is exported as a component schema, so the types of the client can be generated from
+	// the specification; the structs of the schemas are named after their key and every other struct after its type
+	names := map[reflect.Type]string{}
 	for key, val := range openAPISchemas {
+		names[reflect.TypeOf(val).Elem()] = key
+	}
+
+	generator := openapi3gen.NewGenerator(openapi3gen.UseAllExportedFields(),
+		openapi3gen.CreateComponentSchemas(openapi3gen.ExportComponentSchemasOptions{
+			ExportComponentSchemas: true,
+			ExportTopLevelSchema:   true,
+		}),
+		openapi3gen.CreateTypeNameGenerator(func(t reflect.Type) string {
+			if name, ok := names[t]; ok {
+				return name
+			}
+
+			return t.Name()
+		}))
+
+	// recursive types are shared between the schemas that use them, so the schemas are generated in a fixed order to
+	// keep the specification the same every time it is built
+	for _, key := range slices.Sorted(maps.Keys(openAPISchemas)) {
+		val := openAPISchemas[key]
+
 		ref, err := generator.NewSchemaRefForValue(val, schemas)
 		if err != nil {
 			return nil, err
 		}
 
-		schemas[key] = ref
+		// structs are exported by the generator, only the schemas of other types are added here
+		if ref.Ref == "" {
+			schemas[key] = ref
+		}
 	}
 
+	nullableProperties(schemas)
+
 	errorResponse := &openapi3.SchemaRef{
 		Ref: "#/components/schemas/ErrorResponse",
 	}
@@ -50,11 +88,36 @@ func NewOpenAPISpec() (*openapi3.T, error) {
 		WithContent(openapi3.NewContentWithJSONSchemaRef(errorResponse))
 	responses["Unauthorized"] = &openapi3.ResponseRef{Value: unauthorized}
 
+	forbidden := openapi3.NewResponse().
+		WithDescription("Forbidden").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(errorResponse))
+	responses["Forbidden"] = &openapi3.ResponseRef{Value: forbidden}
+
 	conflict := openapi3.NewResponse().
 		WithDescription("Conflict").
 		WithContent(openapi3.NewContentWithJSONSchemaRef(errorResponse))
 	responses["Conflict"] = &openapi3.ResponseRef{Value: conflict}
 
+	notFound := openapi3.NewResponse().
+		WithDescription("Not Found").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(errorResponse))
+	responses["NotFound"] = &openapi3.ResponseRef{Value: notFound}
+
+	tooManyRequests := openapi3.NewResponse().
+		WithDescription("Too Many Requests").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(errorResponse))
+	responses["TooManyRequests"] = &openapi3.ResponseRef{Value: tooManyRequests}
+
+	badGateway := openapi3.NewResponse().
+		WithDescription("Bad Gateway").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(errorResponse))
+	responses["BadGateway"] = &openapi3.ResponseRef{Value: badGateway}
+
+	serviceUnavailable := openapi3.NewResponse().
+		WithDescription("Service Unavailable").
+		WithContent(openapi3.NewContentWithJSONSchemaRef(errorResponse))
+	responses["ServiceUnavailable"] = &openapi3.ResponseRef{Value: serviceUnavailable}
+
 	return &openapi3.T{
 		OpenAPI: "3.1.0",
 		Info: &openapi3.Info{
@@ -89,9 +152,99 @@ func NewOpenAPISpec() (*openapi3.T, error) {
 	}, nil
 }
 
+// AddSecuritySchemes declares the schemes used to authenticate requests to the versioned API; when an issuer is
+// configured, JWTs from its OpenID Connect provider are accepted as an alternative on every authenticated operation.
+// Nothing is declared when authentication is disabled and the operations no longer require a scheme
+func AddSecuritySchemes(oas *openapi3.T, c auth.Config) {
+	if !c.Enabled {
+		for _, path := range oas.Paths.Map() {
+			for _, op := range path.Operations() {
+				op.Security = nil
+			}
+		}
+
+		return
+	}
+
+	bearer := &APIKey{Name: echo.HeaderAuthorization}
+	oas.Components.SecuritySchemes[handlers.BearerSecurityScheme] = &openapi3.SecuritySchemeRef{Value: bearer.Scheme()}
+
+	if c.Issuer == "" {
+		return
+	}
+
+	openid := &OpenID{ConnectURL: strings.TrimSuffix(c.Issuer, "/") + "/.well-known/openid-configuration"}
+	oas.Components.SecuritySchemes[handlers.OpenIDSecurityScheme] = &openapi3.SecuritySchemeRef{Value: openid.Scheme()}
+
+	for _, path := range oas.Paths.Map() {
+		for _, op := range path.Operations() {
+			if op.Security == nil || len(*op.Security) == 0 {
+				continue
+			}
+
+			op.Security.With(openapi3.NewSecurityRequirement().Authenticate(handlers.OpenIDSecurityScheme))
+		}
+	}
+}
+
+// SkipOptionalPointerExtension is set on the properties that are only nullable because they are optional; null is
+// decoded as their zero value, so clients generated from the specification do not need a pointer to omit them
+const SkipOptionalPointerExtension = "x-go-type-skip-optional-pointer"
+
+// nullableProperties marks every optional property of the schemas nullable, clients commonly send null for a value they
+// do not set and the handlers decode it the same as a missing value. Schemas of the same type are shared by the
+// generator, so a copy of the schema of a property is marked rather than the schema itself; a property referencing a
+// component schema marks the component nullable
+func nullableProperties(schemas openapi3.Schemas) {
+	for _, schema := range schemas {
+		if schema.Value == nil {
+			continue
+		}
+
+		for name, prop := range schema.Value.Properties {
+			if slices.Contains(schema.Value.Required, name) {
+				continue
+			}
+
+			if prop.Ref != "" {
+				if ref := schemas[strings.TrimPrefix(prop.Ref, "#/components/schemas/")]; ref != nil && ref.Value != nil {
+					ref.Value.Nullable = true
+				}
+
+				continue
+			}
+
+			if prop.Value == nil || prop.Value.Nullable {
+				continue
+			}
+
+			nullable := *prop.Value
+			nullable.Nullable = true
+			nullable.Extensions = map[string]any{SkipOptionalPointerExtension: true}
+
+			schema.Value.Properties[name] = openapi3.NewSchemaRef("", &nullable)
+		}
+	}
+}
+
 // openAPISchemas is a mapping of types to auto generate schemas for - these specifically live under the OAS "schema" type so that we can simply make schemaRef's to them and not have to define them all individually in the OAS paths
 var openAPISchemas = map[string]any{
-	"ErrorResponse": &rout.StatusError{},
+	"ErrorResponse":                &rout.StatusError{},
+	"OrganizationRequest":          &models.OrganizationRequest{},
+	"OrganizationReply":            &models.OrganizationReply{},
+	"OrganizationPlanReply":        &models.OrganizationPlanReply{},
+	"ProvisioningJobReply":         &models.ProvisioningJobReply{},
+	"ProvisioningFailureReply":     &models.ProvisioningFailureReply{},
+	"ValidationFailureReply":       &models.ValidationFailureReply{},
+	"BulkOrganizationRequest":      &models.BulkOrganizationRequest{},
+	"BulkOrganizationReply":        &models.BulkOrganizationReply{},
+	"OrganizationListReply":        &models.OrganizationListReply{},
+	"OrganizationUpdateRequest":    &models.OrganizationUpdateRequest{},
+	"OrganizationUpdateReply":      &models.OrganizationUpdateReply{},
+	"OrganizationReconcileRequest": &models.OrganizationReconcileRequest{},
+	"OrganizationReconcileReply":   &models.OrganizationReconcileReply{},
+	"OrganizationDeleteReply":      &models.OrganizationDeleteReply{},
+	"AuditReply":                   &models.AuditReply{},
 }
 
 // OAuth2 is a struct that represents an OAuth2 security scheme
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
 	"github.com/rs/zerolog/log"
 	echodebug "github.com/theopenlane/core/pkg/middleware/debug"
@@ -56,12 +58,23 @@ func (s *Server) StartEchoServer(ctx context.Context) error {
 		return err
 	}
 
+	// stopped is closed once the server has shut down and the shutdown functions have finished
+	stopped := make(chan struct{})
+
 	sc := echo.StartConfig{
-		HideBanner:      true,
-		HidePort:        true,
-		Address:         s.config.Settings.Server.Listen,
-		GracefulTimeout: s.config.Settings.Server.ShutdownGracePeriod,
-		GracefulContext: ctx,
+		HideBanner: true,
+		HidePort:   true,
+		Address:    s.config.Settings.Server.Listen,
+		BeforeServeFunc: func(hs *http.Server) error {
+			go func() {
+				defer close(stopped)
+
+				<-ctx.Done()
+				s.shutdown(hs)
+			}()
+
+			return nil
+		},
 	}
 
 	srv.Echo.Debug = s.config.Settings.Server.Debug
@@ -75,12 +88,19 @@ func (s *Server) StartEchoServer(ctx context.Context) error {
 	}
 
 	srv.Handler = &s.config.Handler
+	srv.AuthMiddleware = s.config.AuthMiddleware
+
+	// responses are only validated in dev mode, a response that does not match the specification is an error
+	srv.Validation = s.config.Settings.Server.Validation
+	srv.Validation.Responses = srv.Validation.Responses && s.config.Settings.Server.Dev
 
 	// Add base routes to the server
 	if err := route.RegisterRoutes(srv); err != nil {
 		return err
 	}
 
+	AddSecuritySchemes(srv.OAS, s.config.Settings.Auth)
+
 	// Registers additional routes for the graph endpoints with middleware defined
 	for _, handler := range s.handlers {
 		handler.Routes(srv.Echo.Group("", s.config.GraphMiddleware...))
@@ -96,13 +116,43 @@ func (s *Server) StartEchoServer(ctx context.Context) error {
 	if s.config.Settings.Server.TLS.Enabled {
 		log.Info().Msg("starting in https mode")
 
-		return sc.StartTLS(srv.Echo, s.config.Settings.Server.TLS.CertFile, s.config.Settings.Server.TLS.CertKey)
+		return wait(sc.StartTLS(srv.Echo, s.config.Settings.Server.TLS.CertFile, s.config.Settings.Server.TLS.CertKey), stopped)
 	}
 
 	log.Info().Msg(startBlock)
 
 	// otherwise, start without TLS
-	return sc.Start(srv.Echo)
+	return wait(sc.Start(srv.Echo), stopped)
+}
+
+// shutdown stops the server from accepting new requests, waits for the requests in flight, and then runs the shutdown
+// functions; all of it must finish within the shutdown grace period
+func (s *Server) shutdown(hs *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Settings.Server.ShutdownGracePeriod)
+	defer cancel()
+
+	log.Info().Msg("shutting down server")
+
+	if err := hs.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("failed to shut down server within the grace period")
+	}
+
+	for _, fn := range s.config.Shutdown {
+		if err := fn(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to release server resources within the grace period")
+		}
+	}
+}
+
+// wait returns the error from serving requests; when the server was shut down it waits for the shutdown to finish
+func wait(err error, stopped <-chan struct{}) error {
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	<-stopped
+
+	return nil
 }
 
 var startBlock = `
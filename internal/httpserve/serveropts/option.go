@@ -6,7 +6,14 @@ import (
 	echo "github.com/theopenlane/echox"
 	"github.com/theopenlane/echox/middleware"
 
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/blueprint"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/audit"
 	"github.com/theopenlane/openlane-cloud/internal/httpserve/config"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/handlers"
+	"github.com/theopenlane/openlane-cloud/internal/idempotency"
+	"github.com/theopenlane/openlane-cloud/internal/openlane"
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
 
 	"github.com/theopenlane/core/pkg/middleware/cachecontrol"
 	"github.com/theopenlane/core/pkg/middleware/cors"
@@ -42,11 +49,16 @@ func WithConfigProvider(cfgProvider config.Provider) ServerOption {
 	})
 }
 
-// WithOpenlaneClient supplies the openlane client for the server
+// WithOpenlaneClient supplies the openlane client for the server, and the pool of caller clients when caller
+// credentials are forwarded to openlane
 func WithOpenlaneClient() ServerOption {
 	return newApplyFunc(func(s *ServerOptions) {
 		var err error
 
+		if s.Config.Settings.Server.Openlane.ForwardCredentials {
+			s.Config.Handler.OpenlaneClients = openlane.NewClientPool(s.Config.Settings.Server.Openlane.ClientCacheSize, nil)
+		}
+
 		creds := openlaneclient.Authorization{
 			BearerToken: s.Config.Settings.Server.Openlane.Token,
 		}
@@ -59,6 +71,96 @@ func WithOpenlaneClient() ServerOption {
 	})
 }
 
+// WithProvisioning sets up the concurrency, background workers, and blueprints used for organization provisioning
+func WithProvisioning() ServerOption {
+	return newApplyFunc(func(s *ServerOptions) {
+		p := s.Config.Settings.Server.Provisioning
+
+		blueprints, err := blueprint.NewRegistry(p.Blueprints)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load blueprints")
+		}
+
+		s.Config.Handler.Concurrency = p.Concurrency
+		s.Config.Handler.BulkConcurrency = p.BulkConcurrency
+		s.Config.Handler.MaxBulkSize = p.MaxBulkSize
+		s.Config.Handler.Jobs = handlers.NewJobs(p.Workers, p.QueueSize, p.JobRetention)
+		s.Config.Handler.Blueprints = blueprints
+
+		// running jobs finish, or are canceled and rolled back, before the server exits within the shutdown grace period
+		s.Config.Shutdown = append(s.Config.Shutdown, s.Config.Handler.Jobs.Close)
+	})
+}
+
+// WithIdempotency sets up the store used to replay requests made with an Idempotency-Key header
+func WithIdempotency() ServerOption {
+	return newApplyFunc(func(s *ServerOptions) {
+		if !s.Config.Settings.Server.Idempotency.Enabled {
+			return
+		}
+
+		store, err := idempotency.NewStore(s.Config.Settings.Server.Idempotency)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create idempotency store")
+		}
+
+		s.Config.Handler.IdempotencyStore = store
+	})
+}
+
+// WithWebhooks sets up the dispatcher used to notify other systems of organization lifecycle events
+func WithWebhooks() ServerOption {
+	return newApplyFunc(func(s *ServerOptions) {
+		if !s.Config.Settings.Server.Webhooks.Enabled {
+			return
+		}
+
+		dispatcher, err := webhook.New(s.Config.Settings.Server.Webhooks)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create webhook dispatcher")
+		}
+
+		s.Config.Handler.Webhooks = dispatcher
+
+		// queued events are delivered before the server exits, within the shutdown grace period
+		s.Config.Shutdown = append(s.Config.Shutdown, dispatcher.Close)
+	})
+}
+
+// WithAudit sets up the audit log used to record every provisioning action
+func WithAudit() ServerOption {
+	return newApplyFunc(func(s *ServerOptions) {
+		if !s.Config.Settings.Server.Audit.Enabled {
+			return
+		}
+
+		auditLog, err := audit.New(s.Config.Settings.Server.Audit)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create audit log")
+		}
+
+		s.Config.Handler.Audit = auditLog
+	})
+}
+
+// WithAuth sets up the middleware used to authenticate requests to the versioned API
+func WithAuth() ServerOption {
+	return newApplyFunc(func(s *ServerOptions) {
+		if !s.Config.Settings.Auth.Enabled {
+			log.Warn().Msg("authentication is disabled, the versioned api can be accessed without credentials")
+
+			return
+		}
+
+		authenticator, err := auth.New(s.Config.Settings.Auth)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create authenticator")
+		}
+
+		s.Config.AuthMiddleware = append(s.Config.AuthMiddleware, auth.Middleware(authenticator))
+	})
+}
+
 // WithHTTPS sets up TLS config settings for the server
 func WithHTTPS() ServerOption {
 	return newApplyFunc(func(s *ServerOptions) {
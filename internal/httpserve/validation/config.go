@@ -0,0 +1,12 @@
+package validation
+
+// Config is the configuration for validating requests against the OpenAPI specification
+type Config struct {
+	// Enabled turns on validating the parameters and body of every request against the OpenAPI specification
+	Enabled bool `json:"enabled" koanf:"enabled" default:"true"`
+	// Responses turns on validating every response against the OpenAPI specification when the server is in dev mode,
+	// a response that does not match is replaced with an internal server error
+	Responses bool `json:"responses" koanf:"responses" default:"false"`
+	// Skip is the list of operation ids, e.g. BulkOrganizationHandler, that are not validated
+	Skip []string `json:"skip" koanf:"skip"`
+}
@@ -0,0 +1,2 @@
+// Package validation checks requests, and optionally responses, against the OpenAPI specification served by the router
+package validation
@@ -0,0 +1,10 @@
+package validation
+
+import (
+	"errors"
+)
+
+var (
+	// ErrInvalidResponse is returned when a response does not match the OpenAPI specification
+	ErrInvalidResponse = errors.New("the response does not match the OpenAPI specification")
+)
@@ -0,0 +1,244 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/rs/zerolog/log"
+	echo "github.com/theopenlane/echox"
+	"github.com/theopenlane/utils/rout"
+
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/handlers"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// bodyPointer is the JSON pointer of the request body, parameters are under the pointer of their location, e.g.
+// /query/limit or /header/Idempotency-Key
+const bodyPointer = "/body"
+
+// options validates every parameter and the body of a request; defaults are applied by the handlers, so the request
+// is not modified
+var options = &openapi3filter.Options{
+	MultiError:          true,
+	SkipSettingDefaults: true,
+}
+
+// Validator validates requests, and optionally responses, against the operations of an OpenAPI specification
+type Validator struct {
+	config Config
+	oas    *openapi3.T
+
+	// the references of the specification are resolved once every operation has been added to it
+	resolve  sync.Once
+	resolved error
+}
+
+// New returns a Validator for the operations of the specification
+func New(c Config, oas *openapi3.T) *Validator {
+	return &Validator{config: c, oas: oas}
+}
+
+// Middleware returns the echo middleware that validates the requests to the operation at the path of the specification,
+// nil is returned when validation is disabled or the operation is skipped. Requests that do not match the specification
+// are rejected with a 400 Bad Request listing every invalid field as a JSON pointer into the request
+func (v *Validator) Middleware(path, method string, op *openapi3.Operation) echo.MiddlewareFunc {
+	if !v.config.Enabled || op == nil || slices.Contains(v.config.Skip, op.OperationID) {
+		return nil
+	}
+
+	// requests are authenticated by the auth middleware, so the security requirements of the operation are not checked
+	unsecured := *op
+	unsecured.Security = &openapi3.SecurityRequirements{}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if err := v.resolveRefs(); err != nil {
+				return err
+			}
+
+			route := &routers.Route{
+				Spec:      v.oas,
+				Path:      path,
+				PathItem:  v.oas.Paths.Value(path),
+				Method:    method,
+				Operation: &unsecured,
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    ctx.Request(),
+				PathParams: pathParams(ctx, route),
+				Route:      route,
+				Options:    options,
+			}
+
+			if err := openapi3filter.ValidateRequest(ctx.Request().Context(), input); err != nil {
+				return invalidInput(ctx, err)
+			}
+
+			if !v.config.Responses {
+				return next(ctx)
+			}
+
+			return validateResponse(ctx, input, next)
+		}
+	}
+}
+
+// resolveRefs resolves the references to the components of the specification, which is built without them resolved;
+// it is done when the first request is validated because operations are added to the specification after their routes
+func (v *Validator) resolveRefs() error {
+	v.resolve.Do(func() {
+		v.resolved = openapi3.NewLoader().ResolveRefsIn(v.oas, nil)
+	})
+
+	return v.resolved
+}
+
+// pathParams returns the values of the path parameters of the route matched by echo
+func pathParams(ctx echo.Context, route *routers.Route) map[string]string {
+	params := map[string]string{}
+
+	for _, ref := range append(slices.Clone(route.PathItem.Parameters), route.Operation.Parameters...) {
+		if ref.Value != nil && ref.Value.In == openapi3.ParameterInPath {
+			params[ref.Value.Name] = ctx.PathParam(ref.Value.Name)
+		}
+	}
+
+	return params
+}
+
+// invalidInput writes the 400 Bad Request response for a request that does not match the specification, the same
+// response the handlers return for a request with invalid fields
+func invalidInput(ctx echo.Context, err error) error {
+	verr := &models.ValidationError{Fields: fieldErrors(err, "")}
+
+	if err := ctx.JSON(http.StatusBadRequest, verr.Reply(handlers.InvalidInputErrCode)); err != nil {
+		return err
+	}
+
+	return verr
+}
+
+// fieldErrors returns an error for every invalid field in the result of validating a request; the field of each is
+// the JSON pointer of the value in the request, e.g. /body/environments/1
+func fieldErrors(err error, pointer string) []models.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		fields := []models.FieldError{}
+
+		for _, err := range e {
+			fields = append(fields, fieldErrors(err, pointer)...)
+		}
+
+		return fields
+	case *openapi3filter.RequestError:
+		pointer = bodyPointer
+		if e.Parameter != nil {
+			pointer = "/" + e.Parameter.In + "/" + escape(e.Parameter.Name)
+		}
+
+		switch e.Err.(type) {
+		case openapi3.MultiError, *openapi3.SchemaError:
+			return fieldErrors(e.Err, pointer)
+		}
+
+		return []models.FieldError{{Field: pointer, Message: reason(e.Reason, e.Err)}}
+	case *openapi3.SchemaError:
+		for _, token := range e.JSONPointer() {
+			pointer += "/" + escape(token)
+		}
+
+		return []models.FieldError{{Field: pointer, Message: e.Reason}}
+	default:
+		return []models.FieldError{{Field: pointer, Message: err.Error()}}
+	}
+}
+
+// reason returns the reason a value is invalid followed by the error that caused it, if any
+func reason(reason string, err error) string {
+	switch {
+	case err == nil:
+		return reason
+	case reason == "" || reason == err.Error():
+		return err.Error()
+	default:
+		return reason + ": " + err.Error()
+	}
+}
+
+// escape escapes a reference token of a JSON pointer
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// recorder buffers the body of a response so it can be validated before it is sent, the headers are written to the
+// underlying writer and the status is recorded by the echo response
+type recorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+// WriteHeader does not write the status, it is written once the response has been validated
+func (r *recorder) WriteHeader(int) {}
+
+// Write records the body of the response
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// validateResponse runs next with the response buffered and sends it when it matches the specification; a response
+// that does not match is logged and replaced with a 500 Internal Server Error describing the mismatch
+func validateResponse(ctx echo.Context, input *openapi3filter.RequestValidationInput, next echo.HandlerFunc) error {
+	resp := ctx.Response()
+	writer := resp.Writer
+	rec := &recorder{ResponseWriter: writer}
+
+	resp.Writer = rec
+	err := next(ctx)
+	resp.Writer = writer
+
+	// nothing was written, the error is sent by the echo error handler
+	if !resp.Committed {
+		return err
+	}
+
+	verr := openapi3filter.ValidateResponse(ctx.Request().Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 resp.Status,
+		Header:                 writer.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+		Options:                options,
+	})
+	if verr == nil {
+		writer.WriteHeader(resp.Status)
+
+		if _, werr := writer.Write(rec.body.Bytes()); werr != nil {
+			return werr
+		}
+
+		return err
+	}
+
+	verr = fmt.Errorf("%w: %w", ErrInvalidResponse, verr)
+
+	log.Error().Err(verr).Str("operation", input.Route.Operation.OperationID).Int("status", resp.Status).Msg("invalid response")
+
+	writer.Header().Del(echo.HeaderContentLength)
+	writer.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	writer.WriteHeader(http.StatusInternalServerError)
+
+	if werr := json.NewEncoder(writer).Encode(rout.ErrorResponse(verr)); werr != nil {
+		return werr
+	}
+
+	return verr
+}
@@ -0,0 +1,188 @@
+package validation
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/handlers"
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+// newTestSpec returns a specification with a single operation that takes a path, query, and header parameter and a
+// JSON body referencing a component schema, and replies with the same schema
+func newTestSpec() (*openapi3.T, *openapi3.Operation) {
+	thing := openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema().WithMinLength(3)).
+		WithProperty("environments", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema().WithMinLength(1)))
+	thing.Required = []string{"name"}
+
+	ref := &openapi3.SchemaRef{Ref: "#/components/schemas/Thing"}
+
+	op := openapi3.NewOperation()
+	op.OperationID = "ThingHandler"
+	op.AddParameter(openapi3.NewPathParameter("id").WithSchema(openapi3.NewStringSchema()))
+	op.AddParameter(openapi3.NewQueryParameter("limit").WithSchema(openapi3.NewIntegerSchema().WithMin(1).WithMax(10)))
+	op.AddParameter(openapi3.NewHeaderParameter("X-Thing-Key").WithSchema(openapi3.NewStringSchema().WithMaxLength(5)))
+	op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(ref)}
+	op.AddResponse(http.StatusOK, openapi3.NewResponse().WithDescription("ok").WithJSONSchemaRef(ref))
+
+	oas := &openapi3.T{
+		OpenAPI: "3.1.0",
+		Info:    &openapi3.Info{Title: "test", Version: "v1"},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{"Thing": openapi3.NewSchemaRef("", thing)},
+		},
+	}
+
+	oas.AddOperation("/things/{id}", http.MethodPost, op)
+
+	return oas, op
+}
+
+// newTestServer returns an echo server with the operation of the test specification validated by the config; the
+// handler replies with the reply, or echoes the request body when it is not set
+func newTestServer(t *testing.T, c Config, reply string) *echo.Echo {
+	t.Helper()
+
+	oas, op := newTestSpec()
+
+	e := echo.New()
+
+	var mw []echo.MiddlewareFunc
+	if validate := New(c, oas).Middleware("/things/{id}", http.MethodPost, op); validate != nil {
+		mw = append(mw, validate)
+	}
+
+	e.POST("/things/:id", func(ctx echo.Context) error {
+		body, err := io.ReadAll(ctx.Request().Body)
+		if err != nil {
+			return err
+		}
+
+		if reply != "" {
+			body = []byte(reply)
+		}
+
+		return ctx.JSONBlob(http.StatusOK, body)
+	}, mw...)
+
+	return e
+}
+
+// send sends a request to the test server and returns the response
+func send(e *echo.Echo, target, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestRequestValidation(t *testing.T) {
+	e := newTestServer(t, Config{Enabled: true}, "")
+
+	t.Run("valid request", func(t *testing.T) {
+		body := `{"name":"MITB Inc.","environments":["production"]}`
+
+		rec := send(e, "/things/abc?limit=5", body, http.Header{"X-Thing-Key": {"meow"}})
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		// the body can still be read by the handler
+		assert.JSONEq(t, body, rec.Body.String())
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		rec := send(e, "/things/abc?limit=0", `{"name":"MI","environments":["production",""]}`, http.Header{"X-Thing-Key": {"meow meow"}})
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var out models.ValidationFailureReply
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+
+		assert.False(t, out.Success)
+		assert.Equal(t, handlers.InvalidInputErrCode, out.ErrorCode)
+
+		fields := map[string]string{}
+		for _, f := range out.Fields {
+			fields[f.Field] = f.Message
+		}
+
+		assert.Len(t, fields, 4)
+		assert.Contains(t, fields, "/query/limit")
+		assert.Contains(t, fields, "/header/X-Thing-Key")
+		assert.Contains(t, fields, "/body/name")
+		assert.Contains(t, fields, "/body/environments/1")
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		rec := send(e, "/things/abc", "", nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var out models.ValidationFailureReply
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+		require.Len(t, out.Fields, 1)
+		assert.Equal(t, "/body", out.Fields[0].Field)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		rec := send(e, "/things/abc", `{"name":`, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var out models.ValidationFailureReply
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+		require.Len(t, out.Fields, 1)
+		assert.Equal(t, "/body", out.Fields[0].Field)
+	})
+}
+
+func TestRequestValidationDisabled(t *testing.T) {
+	oas, op := newTestSpec()
+
+	assert.Nil(t, New(Config{}, oas).Middleware("/things/{id}", http.MethodPost, op))
+	assert.Nil(t, New(Config{Enabled: true, Skip: []string{"ThingHandler"}}, oas).Middleware("/things/{id}", http.MethodPost, op))
+	assert.Nil(t, New(Config{Enabled: true}, oas).Middleware("/livez", http.MethodGet, nil))
+
+	rec := send(newTestServer(t, Config{Enabled: true, Skip: []string{"ThingHandler"}}, ""), "/things/abc?limit=0", `{}`, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestResponseValidation(t *testing.T) {
+	body := `{"name":"MITB Inc."}`
+
+	t.Run("valid response", func(t *testing.T) {
+		rec := send(newTestServer(t, Config{Enabled: true, Responses: true}, ""), "/things/abc", body, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, body, rec.Body.String())
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		rec := send(newTestServer(t, Config{Enabled: true, Responses: true}, `{"name":1}`), "/things/abc", body, nil)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), ErrInvalidResponse.Error())
+	})
+
+	t.Run("responses not validated", func(t *testing.T) {
+		rec := send(newTestServer(t, Config{Enabled: true}, `{"name":1}`), "/things/abc", body, nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"name":1}`, rec.Body.String())
+	})
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, "a~1b~0c", escape("a/b~c"))
+}
@@ -0,0 +1,25 @@
+package idempotency
+
+import "time"
+
+const (
+	// StoreMemory keeps idempotency records in memory, they are lost when the server restarts
+	StoreMemory = "memory"
+	// StoreFile persists idempotency records to a file on disk
+	StoreFile = "file"
+)
+
+// Config is the configuration for idempotent requests
+type Config struct {
+	// Enabled turns on support for the Idempotency-Key header
+	Enabled bool `json:"enabled" koanf:"enabled" default:"true"`
+	// Store is the backend used to persist idempotency records, either memory or file
+	Store string `json:"store" koanf:"store" default:"memory"`
+	// Path is the location of the file used when the store is file
+	Path string `json:"path" koanf:"path" default:"idempotency.json"`
+	// TTL is how long the response for an idempotency key is kept and replayed
+	TTL time.Duration `json:"ttl" koanf:"ttl" default:"24h"`
+	// PendingTTL is how long a key is reserved for a request that has not completed, so a key held by a request that
+	// never finished, e.g. when the server crashed, can be retried
+	PendingTTL time.Duration `json:"pending_ttl" koanf:"pending_ttl" default:"5m"`
+}
@@ -0,0 +1,2 @@
+// Package idempotency stores the results of requests made with an Idempotency-Key so retries can be replayed
+package idempotency
@@ -0,0 +1,16 @@
+package idempotency
+
+import (
+	"errors"
+)
+
+var (
+	// ErrFingerprintMismatch is returned when an idempotency key is reused with a different request
+	ErrFingerprintMismatch = errors.New("idempotency key has already been used with a different request")
+
+	// ErrRequestInProgress is returned when a request with the same idempotency key is still being processed
+	ErrRequestInProgress = errors.New("a request with this idempotency key is already in progress")
+
+	// ErrUnknownStore is returned when the configured store type is not supported
+	ErrUnknownStore = errors.New("unknown idempotency store")
+)
@@ -0,0 +1,141 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	ownerReadWrite = 0600
+)
+
+// FileStore persists idempotency records to a JSON file so they survive server restarts
+type FileStore struct {
+	mu         sync.Mutex
+	path       string
+	ttl        time.Duration
+	pendingTTL time.Duration
+	records    map[string]*Record
+}
+
+// Ensure FileStore implements the Store interface
+var _ Store = &FileStore{}
+
+// NewFileStore returns a new store backed by the file at path, loading any existing records; records are kept for the
+// ttl, and records of requests that have not completed for the pending ttl
+func NewFileStore(path string, ttl, pendingTTL time.Duration) (*FileStore, error) {
+	s := &FileStore{
+		path:       path,
+		ttl:        ttl,
+		pendingTTL: pendingTTL,
+		records:    map[string]*Record{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return s, nil
+	}
+
+	if err := json.Unmarshal(data, &s.records); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Begin reserves the key for a new request or returns the completed record to replay
+func (s *FileStore) Begin(_ context.Context, key, fingerprint string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.records[key]
+
+	reserved, replay, err := begin(existing, ok, key, fingerprint, s.ttl, s.pendingTTL)
+	if err != nil || replay != nil {
+		return replay, err
+	}
+
+	s.records[key] = reserved
+
+	return nil, s.flush()
+}
+
+// Complete stores the response for the key
+func (s *FileStore) Complete(_ context.Context, key string, statusCode int, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+
+	r.Completed = true
+	r.StatusCode = statusCode
+	r.Body = append([]byte(nil), body...)
+
+	return s.flush()
+}
+
+// Release removes the reservation for the key
+func (s *FileStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+
+	return s.flush()
+}
+
+// flush removes expired records and atomically rewrites the file, the lock must be held by the caller
+func (s *FileStore) flush() error {
+	for key, r := range s.records {
+		if r.expired(s.ttl, s.pendingTTL) {
+			delete(s.records, key)
+		}
+	}
+
+	data, err := json.Marshal(s.records)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), ownerReadWrite); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
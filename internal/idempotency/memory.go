@@ -0,0 +1,80 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps idempotency records in memory
+type MemoryStore struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	pendingTTL time.Duration
+	records    map[string]*Record
+}
+
+// Ensure MemoryStore implements the Store interface
+var _ Store = &MemoryStore{}
+
+// NewMemoryStore returns a new in-memory store that keeps records for the ttl, and records of requests that have not
+// completed for the pending ttl
+func NewMemoryStore(ttl, pendingTTL time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:        ttl,
+		pendingTTL: pendingTTL,
+		records:    map[string]*Record{},
+	}
+}
+
+// Begin reserves the key for a new request or returns the completed record to replay
+func (s *MemoryStore) Begin(_ context.Context, key, fingerprint string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune()
+
+	existing, ok := s.records[key]
+
+	reserved, replay, err := begin(existing, ok, key, fingerprint, s.ttl, s.pendingTTL)
+	if err != nil || replay != nil {
+		return replay, err
+	}
+
+	s.records[key] = reserved
+
+	return nil, nil
+}
+
+// Complete stores the response for the key
+func (s *MemoryStore) Complete(_ context.Context, key string, statusCode int, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.records[key]; ok {
+		r.Completed = true
+		r.StatusCode = statusCode
+		r.Body = append([]byte(nil), body...)
+	}
+
+	return nil
+}
+
+// Release removes the reservation for the key
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+
+	return nil
+}
+
+// prune removes expired records, the lock must be held by the caller
+func (s *MemoryStore) prune() {
+	for key, r := range s.records {
+		if r.expired(s.ttl, s.pendingTTL) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Record is the stored state of a request made with an idempotency key
+type Record struct {
+	// Key is the idempotency key provided by the caller
+	Key string `json:"key"`
+	// Fingerprint identifies the request the key was first used with
+	Fingerprint string `json:"fingerprint"`
+	// Completed is true once the response has been stored
+	Completed bool `json:"completed"`
+	// StatusCode is the http status code of the stored response
+	StatusCode int `json:"statusCode,omitempty"`
+	// Body is the stored response body
+	Body json.RawMessage `json:"body,omitempty"`
+	// CreatedAt is the time the key was first used
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// expired returns true when the record is older than the ttl, or older than the pending ttl when it has not completed
+func (r *Record) expired(ttl, pendingTTL time.Duration) bool {
+	if !r.Completed && pendingTTL > 0 {
+		ttl = pendingTTL
+	}
+
+	return ttl > 0 && time.Since(r.CreatedAt) > ttl
+}
+
+// Store persists idempotency records
+type Store interface {
+	// Begin reserves the key for a new request with the fingerprint; if the key was already used for the same request
+	// and has completed the stored record is returned so the response can be replayed
+	Begin(ctx context.Context, key, fingerprint string) (*Record, error)
+	// Complete stores the response for the key
+	Complete(ctx context.Context, key string, statusCode int, body []byte) error
+	// Release removes the reservation for a key whose request did not complete so it can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// NewStore returns the store configured by the config
+func NewStore(c Config) (Store, error) {
+	switch c.Store {
+	case StoreMemory, "":
+		return NewMemoryStore(c.TTL, c.PendingTTL), nil
+	case StoreFile:
+		return NewFileStore(c.Path, c.TTL, c.PendingTTL)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStore, c.Store)
+	}
+}
+
+// Fingerprint returns a hash identifying the request made to the route with the body
+func Fingerprint(route string, body any) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(route))
+	h.Write([]byte{'\n'})
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// begin applies the Begin rules to the existing record, returning the record to store when the key can be reserved
+func begin(existing *Record, ok bool, key, fingerprint string, ttl, pendingTTL time.Duration) (reserved *Record, replay *Record, err error) {
+	if ok && !existing.expired(ttl, pendingTTL) {
+		if existing.Fingerprint != fingerprint {
+			return nil, nil, ErrFingerprintMismatch
+		}
+
+		if !existing.Completed {
+			return nil, nil, ErrRequestInProgress
+		}
+
+		out := *existing
+
+		return nil, &out, nil
+	}
+
+	return &Record{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now(),
+	}, nil, nil
+}
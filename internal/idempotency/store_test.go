@@ -0,0 +1,151 @@
+package idempotency_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/theopenlane/openlane-cloud/internal/idempotency"
+)
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) idempotency.Store{
+		"memory": func(_ *testing.T) idempotency.Store {
+			return idempotency.NewMemoryStore(time.Hour, time.Minute)
+		},
+		"file": func(t *testing.T) idempotency.Store {
+			s, err := idempotency.NewFileStore(filepath.Join(t.TempDir(), "idempotency.json"), time.Hour, time.Minute)
+			require.NoError(t, err)
+
+			return s
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newStore(t)
+
+			// first use reserves the key
+			rec, err := s.Begin(ctx, "key", "fingerprint")
+			require.NoError(t, err)
+			assert.Nil(t, rec)
+
+			// retries while the first request is running are rejected
+			_, err = s.Begin(ctx, "key", "fingerprint")
+			require.ErrorIs(t, err, idempotency.ErrRequestInProgress)
+
+			// reusing the key for a different request is rejected
+			_, err = s.Begin(ctx, "key", "other")
+			require.ErrorIs(t, err, idempotency.ErrFingerprintMismatch)
+
+			require.NoError(t, s.Complete(ctx, "key", 200, []byte(`{"success":true}`)))
+
+			// retries replay the stored response
+			rec, err = s.Begin(ctx, "key", "fingerprint")
+			require.NoError(t, err)
+			require.NotNil(t, rec)
+			assert.Equal(t, 200, rec.StatusCode)
+			assert.JSONEq(t, `{"success":true}`, string(rec.Body))
+
+			_, err = s.Begin(ctx, "key", "other")
+			require.ErrorIs(t, err, idempotency.ErrFingerprintMismatch)
+
+			// released keys can be used again
+			rec, err = s.Begin(ctx, "released", "fingerprint")
+			require.NoError(t, err)
+			assert.Nil(t, rec)
+
+			require.NoError(t, s.Release(ctx, "released"))
+
+			rec, err = s.Begin(ctx, "released", "other")
+			require.NoError(t, err)
+			assert.Nil(t, rec)
+		})
+	}
+}
+
+func TestStoresPendingTTL(t *testing.T) {
+	const pendingTTL = 50 * time.Millisecond
+
+	stores := map[string]func(t *testing.T) idempotency.Store{
+		"memory": func(_ *testing.T) idempotency.Store {
+			return idempotency.NewMemoryStore(time.Hour, pendingTTL)
+		},
+		"file": func(t *testing.T) idempotency.Store {
+			s, err := idempotency.NewFileStore(filepath.Join(t.TempDir(), "idempotency.json"), time.Hour, pendingTTL)
+			require.NoError(t, err)
+
+			return s
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newStore(t)
+
+			_, err := s.Begin(ctx, "pending", "fingerprint")
+			require.NoError(t, err)
+
+			_, err = s.Begin(ctx, "completed", "fingerprint")
+			require.NoError(t, err)
+			require.NoError(t, s.Complete(ctx, "completed", 200, []byte(`{"success":true}`)))
+
+			_, err = s.Begin(ctx, "pending", "fingerprint")
+			require.ErrorIs(t, err, idempotency.ErrRequestInProgress)
+
+			time.Sleep(2 * pendingTTL)
+
+			// a request that never completed no longer holds the key once the pending ttl has passed
+			rec, err := s.Begin(ctx, "pending", "other")
+			require.NoError(t, err)
+			assert.Nil(t, rec)
+
+			// completed responses are still replayed until the ttl
+			rec, err = s.Begin(ctx, "completed", "fingerprint")
+			require.NoError(t, err)
+			require.NotNil(t, rec)
+			assert.Equal(t, 200, rec.StatusCode)
+		})
+	}
+}
+
+func TestFileStorePersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "idempotency.json")
+
+	s, err := idempotency.NewFileStore(path, time.Hour, time.Minute)
+	require.NoError(t, err)
+
+	_, err = s.Begin(ctx, "key", "fingerprint")
+	require.NoError(t, err)
+	require.NoError(t, s.Complete(ctx, "key", 202, []byte(`{"success":true}`)))
+
+	// a new store loaded from the same file replays the response
+	s, err = idempotency.NewFileStore(path, time.Hour, time.Minute)
+	require.NoError(t, err)
+
+	rec, err := s.Begin(ctx, "key", "fingerprint")
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, 202, rec.StatusCode)
+}
+
+func TestFingerprint(t *testing.T) {
+	a, err := idempotency.Fingerprint("POST /v1/organization", map[string]string{"name": "meow"})
+	require.NoError(t, err)
+
+	b, err := idempotency.Fingerprint("POST /v1/organization", map[string]string{"name": "meow"})
+	require.NoError(t, err)
+
+	c, err := idempotency.Fingerprint("POST /v1/organization?async=true", map[string]string{"name": "meow"})
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
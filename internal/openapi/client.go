@@ -0,0 +1,451 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// schemaPrefix is the prefix of the references to the component schemas, which the generated types are named after
+	schemaPrefix = "#/components/schemas/"
+	// operationSuffix is removed from the operation ids, which are named after the handlers, to name the functions
+	operationSuffix = "Handler"
+	// jsonContentType is the content type of the request and response bodies used by the client
+	jsonContentType = "application/json"
+)
+
+var (
+	// ErrMissingOperationID is returned when an operation of the specification does not have an operation id
+	ErrMissingOperationID = errors.New("operation does not have an operation id")
+	// ErrUnsupportedSchema is returned when a parameter, request body, or response cannot be mapped to a go type
+	ErrUnsupportedSchema = errors.New("schema is not supported by the client generator")
+)
+
+// operation is an operation of the specification as it is rendered in the generated client
+type operation struct {
+	// ID is the operation id of the operation
+	ID string
+	// Name is the operation id without the handler suffix, used to name the generated function
+	Name string
+	// Method is the request method of the operation
+	Method string
+	// Option is the name of the httpsling option of the request method
+	Option string
+	// Pattern is the path of the operation in the specification
+	Pattern string
+	// Path is the go expression of the path the request is sent to
+	Path string
+	// Params are the path, query, and header parameters of the operation
+	Params []param
+	// Body is the model type of the JSON request body, if any
+	Body string
+	// Replies are the model types of the successful JSON responses, sorted by status code
+	Replies []string
+}
+
+// param is a parameter of an operation as it is rendered in the generated client
+type param struct {
+	// Name is the name of the parameter in the request
+	Name string
+	// In is where the parameter is sent: path, query, or header
+	In string
+	// Field is the name of the field of the parameter in the generated params struct
+	Field string
+	// Type is the go type of the field
+	Type string
+	// Description is the description of the parameter in the specification
+	Description string
+}
+
+// ParamsType returns the name of the generated struct of the parameters of the operation
+func (o operation) ParamsType() string {
+	return lowerFirst(o.Name) + "Params"
+}
+
+// RepliesType returns the name of the generated type constraint of an operation with more than one reply
+func (o operation) RepliesType() string {
+	return lowerFirst(o.Name) + "Replies"
+}
+
+// Query returns the query and header parameters of the operation, which are only sent when they are set
+func (o operation) Query() []param {
+	return slices.DeleteFunc(slices.Clone(o.Params), func(p param) bool { return p.In == openapi3.ParameterInPath })
+}
+
+// Option returns the httpsling option of the parameter
+func (p param) Option() string {
+	if p.In == openapi3.ParameterInHeader {
+		return "Header"
+	}
+
+	return "QueryParam"
+}
+
+// IsSet returns the go expression that is true when the parameter is set in params
+func (p param) IsSet() string {
+	v := "params." + p.Field
+
+	switch p.Type {
+	case "int":
+		return v + " != 0"
+	case "bool":
+		return v
+	case "time.Time":
+		return "!" + v + ".IsZero()"
+	default:
+		return v + ` != ""`
+	}
+}
+
+// Value returns the go expression of the string value of the parameter in params
+func (p param) Value() string {
+	v := "params." + p.Field
+
+	switch p.Type {
+	case "int":
+		return "strconv.Itoa(" + v + ")"
+	case "bool":
+		return "strconv.FormatBool(" + v + ")"
+	case "time.Time":
+		return v + ".Format(time.RFC3339)"
+	default:
+		return v
+	}
+}
+
+// GenerateClient returns the formatted source of the client functions of every operation in the specification; each
+// operation gets a params struct with its path, query, and header parameters and a send function that takes the
+// params and the request body and returns the reply, the request and response bodies use the types generated from the
+// component schemas of the specification by GenerateModels
+func GenerateClient(oas *openapi3.T) ([]byte, error) {
+	prefix, err := serverPath(oas)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []operation{}
+
+	for pattern, item := range oas.Paths.Map() {
+		for method, op := range item.Operations() {
+			o, err := newOperation(prefix, pattern, method, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, pattern, err)
+			}
+
+			ops = append(ops, o)
+		}
+	}
+
+	slices.SortFunc(ops, func(a, b operation) int { return strings.Compare(a.ID, b.ID) })
+
+	var buf bytes.Buffer
+	if err := clientTemplate.Execute(&buf, clientData{Operations: ops}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// serverPath returns the path of the URL of the first server, which the paths of the operations are relative to
+func serverPath(oas *openapi3.T) (string, error) {
+	if len(oas.Servers) == 0 {
+		return "", nil
+	}
+
+	u, err := url.Parse(oas.Servers[0].URL)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(u.Path, "/"), nil
+}
+
+// newOperation maps an operation of the specification to the operation rendered in the generated client
+func newOperation(prefix, pattern, method string, op *openapi3.Operation) (operation, error) {
+	if op.OperationID == "" {
+		return operation{}, ErrMissingOperationID
+	}
+
+	o := operation{
+		ID:      op.OperationID,
+		Name:    strings.TrimSuffix(op.OperationID, operationSuffix),
+		Method:  method,
+		Option:  upperFirst(strings.ToLower(method)),
+		Pattern: pattern,
+		Path:    pathExpr(prefix + pattern),
+	}
+
+	for _, ref := range op.Parameters {
+		p, err := newParam(ref.Value)
+		if err != nil {
+			return operation{}, err
+		}
+
+		o.Params = append(o.Params, p)
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if media := op.RequestBody.Value.Content.Get(jsonContentType); media != nil {
+			body, err := modelType(media.Schema)
+			if err != nil {
+				return operation{}, fmt.Errorf("request body: %w", err)
+			}
+
+			o.Body = body
+		}
+	}
+
+	replies, err := replyTypes(op)
+	if err != nil {
+		return operation{}, err
+	}
+
+	o.Replies = replies
+
+	return o, nil
+}
+
+// newParam maps a parameter of the specification to the field of the params struct
+func newParam(p *openapi3.Parameter) (param, error) {
+	out := param{
+		Name:        p.Name,
+		In:          p.In,
+		Field:       fieldName(p.Name),
+		Description: p.Description,
+	}
+
+	if p.Schema == nil || p.Schema.Value == nil {
+		return param{}, fmt.Errorf("parameter %s: %w", p.Name, ErrUnsupportedSchema)
+	}
+
+	s := p.Schema.Value
+
+	switch {
+	case s.Type.Is(openapi3.TypeInteger):
+		out.Type = "int"
+	case s.Type.Is(openapi3.TypeBoolean):
+		out.Type = "bool"
+	case s.Type.Is(openapi3.TypeString) && s.Format == "date-time":
+		out.Type = "time.Time"
+	case s.Type.Is(openapi3.TypeString):
+		out.Type = "string"
+	default:
+		return param{}, fmt.Errorf("parameter %s: %w", p.Name, ErrUnsupportedSchema)
+	}
+
+	return out, nil
+}
+
+// replyTypes returns the model types of the successful JSON responses of the operation sorted by status code; the
+// alternatives of a response that can be one of several schemas are all included
+func replyTypes(op *openapi3.Operation) ([]string, error) {
+	codes := []int{}
+
+	for code := range op.Responses.Map() {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < http.StatusOK || status >= http.StatusMultipleChoices {
+			continue
+		}
+
+		codes = append(codes, status)
+	}
+
+	slices.Sort(codes)
+
+	replies := []string{}
+
+	for _, code := range codes {
+		resp := op.Responses.Status(code)
+		if resp == nil || resp.Value == nil {
+			continue
+		}
+
+		media := resp.Value.Content.Get(jsonContentType)
+		if media == nil {
+			continue
+		}
+
+		schemas := []*openapi3.SchemaRef{media.Schema}
+		if media.Schema != nil && media.Schema.Ref == "" && media.Schema.Value != nil {
+			if alternatives := append(media.Schema.Value.AnyOf, media.Schema.Value.OneOf...); len(alternatives) > 0 {
+				schemas = alternatives
+			}
+		}
+
+		for _, s := range schemas {
+			reply, err := modelType(s)
+			if err != nil {
+				return nil, fmt.Errorf("response %d: %w", code, err)
+			}
+
+			if !slices.Contains(replies, reply) {
+				replies = append(replies, reply)
+			}
+		}
+	}
+
+	if len(replies) == 0 {
+		return nil, fmt.Errorf("no successful JSON response: %w", ErrUnsupportedSchema)
+	}
+
+	return replies, nil
+}
+
+// modelType returns the generated type of a referenced component schema, see GenerateModels
+func modelType(s *openapi3.SchemaRef) (string, error) {
+	if s == nil || !strings.HasPrefix(s.Ref, schemaPrefix) {
+		return "", ErrUnsupportedSchema
+	}
+
+	return strings.TrimPrefix(s.Ref, schemaPrefix), nil
+}
+
+// pathExpr returns the go expression of the path with every path parameter replaced by its escaped field of params
+func pathExpr(path string) string {
+	parts := []string{}
+
+	for path != "" {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+
+		if start < 0 || end < start {
+			parts = append(parts, strconv.Quote(path))
+
+			break
+		}
+
+		if start > 0 {
+			parts = append(parts, strconv.Quote(path[:start]))
+		}
+
+		parts = append(parts, "url.PathEscape(params."+fieldName(path[start+1:end])+")")
+		path = path[end+1:]
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// fieldName returns the exported go name of a parameter name, e.g. X-Openlane-Token becomes XOpenlaneToken
+func fieldName(name string) string {
+	if strings.EqualFold(name, "id") {
+		return "ID"
+	}
+
+	var b strings.Builder
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		b.WriteString(upperFirst(part))
+	}
+
+	return b.String()
+}
+
+// upperFirst returns the string with the first letter in upper case
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// lowerFirst returns the string with the first letter in lower case
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// clientData is the data the client template is executed with
+type clientData struct {
+	Operations []operation
+}
+
+// Imports returns true for every package that is used by the generated client
+func (d clientData) Imports() map[string]bool {
+	imports := map[string]bool{}
+
+	for _, o := range d.Operations {
+		imports["url"] = imports["url"] || strings.Contains(o.Path, "url.")
+
+		for _, p := range o.Params {
+			imports["strconv"] = imports["strconv"] || p.Type == "int" || p.Type == "bool"
+			imports["time"] = imports["time"] || p.Type == "time.Time"
+		}
+	}
+
+	return imports
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by openapi/openapi_generator.go; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+{{- with .Imports }}
+{{- if .url }}
+	"net/url"
+{{- end }}
+{{- if .strconv }}
+	"strconv"
+{{- end }}
+{{- if .time }}
+	"time"
+{{- end }}
+{{- end }}
+
+	"github.com/theopenlane/httpsling"
+)
+{{ range .Operations }}
+// {{ .ParamsType }} are the parameters of the {{ .ID }} operation, parameters with a zero value are not sent
+type {{ .ParamsType }} struct {
+{{- range .Params }}
+	// {{ .Field }} is the {{ .Name }} {{ .In }} parameter: {{ .Description }}
+	{{ .Field }} {{ .Type }}
+{{- end }}
+}
+{{ if gt (len .Replies) 1 }}
+// {{ .RepliesType }} are the replies of the {{ .ID }} operation
+type {{ .RepliesType }} interface {
+	{{ range $i, $r := .Replies }}{{ if $i }} | {{ end }}{{ $r }}{{ end }}
+}
+
+// send{{ .Name }} sends the request of the {{ .ID }} operation, {{ .Method }} {{ .Pattern }}, and decodes the reply into T
+func send{{ .Name }}[T {{ .RepliesType }}](ctx context.Context, c *APIv1, params {{ .ParamsType }}{{ if .Body }}, body *{{ .Body }}{{ end }}) (*T, error) {
+{{- else }}
+// send{{ .Name }} sends the request of the {{ .ID }} operation, {{ .Method }} {{ .Pattern }}
+func send{{ .Name }}(ctx context.Context, c *APIv1, params {{ .ParamsType }}{{ if .Body }}, body *{{ .Body }}{{ end }}) (*{{ index .Replies 0 }}, error) {
+{{- end }}
+	opts := []httpsling.Option{httpsling.{{ .Option }}({{ .Path }})}
+{{ range .Query }}
+	if {{ .IsSet }} {
+		opts = append(opts, httpsling.{{ .Option }}({{ printf "%q" .Name }}, {{ .Value }}))
+	}
+{{ end }}
+{{- if .Body }}
+	if body != nil {
+		opts = append(opts, httpsling.Body(body))
+	}
+{{ end }}
+	out := new({{ if gt (len .Replies) 1 }}T{{ else }}{{ index .Replies 0 }}{{ end }})
+	if err := c.send(ctx, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+{{ end }}`))
@@ -0,0 +1,2 @@
+// Package openapi exports the OpenAPI specification served by the router and generates the client from it
+package openapi
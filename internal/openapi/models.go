@@ -0,0 +1,255 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/server"
+)
+
+// initialisms are the suffixes of the generated field names that are written in upper case, e.g. parentId becomes
+// ParentID
+var initialisms = map[string]string{
+	"Id":  "ID",
+	"Ids": "IDs",
+	"Url": "URL",
+}
+
+// model is a component schema of the specification as it is rendered in the generated client
+type model struct {
+	// Name is the name of the component schema, used to name the generated type
+	Name string
+	// Type is the go type of a component schema that is not an object, e.g. an array of another component
+	Type string
+	// Fields are the properties of an object component schema sorted by name
+	Fields []field
+}
+
+// field is a property of an object component schema as it is rendered in the generated client
+type field struct {
+	// Name is the name of the field
+	Name string
+	// Type is the go type of the field
+	Type string
+	// JSON is the name of the property in the request and response bodies
+	JSON string
+}
+
+// GenerateModels returns the formatted source of a type for every component schema in the specification, which are
+// the request and reply types of the generated client functions; object schemas become structs with a field for every
+// property, references to other objects are pointers so they are omitted from requests when they are not set
+func GenerateModels(oas *openapi3.T) ([]byte, error) {
+	models := []model{}
+
+	if oas.Components != nil {
+		for _, name := range slices.Sorted(maps.Keys(oas.Components.Schemas)) {
+			m, err := newModel(name, oas.Components.Schemas[name])
+			if err != nil {
+				return nil, fmt.Errorf("schema %s: %w", name, err)
+			}
+
+			models = append(models, m)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := modelsTemplate.Execute(&buf, modelsData{Models: models}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// newModel maps a component schema of the specification to the type rendered in the generated client
+func newModel(name string, s *openapi3.SchemaRef) (model, error) {
+	if s == nil || s.Value == nil {
+		return model{}, ErrUnsupportedSchema
+	}
+
+	m := model{Name: name}
+
+	if !s.Value.Type.Is(openapi3.TypeObject) || len(s.Value.Properties) == 0 {
+		t, err := goType(s)
+		if err != nil {
+			return model{}, err
+		}
+
+		m.Type = t
+
+		return m, nil
+	}
+
+	for _, prop := range slices.Sorted(maps.Keys(s.Value.Properties)) {
+		t, err := goType(s.Value.Properties[prop])
+		if err != nil {
+			return model{}, fmt.Errorf("property %s: %w", prop, err)
+		}
+
+		m.Fields = append(m.Fields, field{
+			Name: exportedName(prop),
+			Type: t,
+			JSON: prop,
+		})
+	}
+
+	return m, nil
+}
+
+// goType returns the go type of a property or component schema; references to object schemas and nullable values are
+// pointers, references in arrays and values that are only nullable because they are optional are not
+func goType(s *openapi3.SchemaRef) (string, error) {
+	if s == nil {
+		return "", ErrUnsupportedSchema
+	}
+
+	if strings.HasPrefix(s.Ref, schemaPrefix) {
+		name := strings.TrimPrefix(s.Ref, schemaPrefix)
+
+		if s.Value != nil && s.Value.Type.Is(openapi3.TypeObject) {
+			return "*" + name, nil
+		}
+
+		return name, nil
+	}
+
+	t, err := valueType(s.Value)
+	if err != nil {
+		return "", err
+	}
+
+	if skip, _ := s.Value.Extensions[server.SkipOptionalPointerExtension].(bool); skip {
+		return t, nil
+	}
+
+	if s.Value.Nullable && !strings.HasPrefix(t, "[]") && !strings.HasPrefix(t, "map[") {
+		return "*" + t, nil
+	}
+
+	return t, nil
+}
+
+// valueType returns the go type of a schema that is not a reference
+func valueType(s *openapi3.Schema) (string, error) {
+	if s == nil {
+		return "", ErrUnsupportedSchema
+	}
+
+	switch {
+	case s.Type == nil || len(s.Type.Slice()) == 0:
+		return "json.RawMessage", nil
+	case s.Type.Is(openapi3.TypeString) && s.Format == "date-time":
+		return "time.Time", nil
+	case s.Type.Is(openapi3.TypeString):
+		return "string", nil
+	case s.Type.Is(openapi3.TypeInteger):
+		return "int", nil
+	case s.Type.Is(openapi3.TypeNumber):
+		return "float64", nil
+	case s.Type.Is(openapi3.TypeBoolean):
+		return "bool", nil
+	case s.Type.Is(openapi3.TypeArray):
+		items, err := itemType(s.Items)
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + items, nil
+	case s.Type.Is(openapi3.TypeObject) && len(s.Properties) == 0 && s.AdditionalProperties.Schema != nil:
+		values, err := itemType(s.AdditionalProperties.Schema)
+		if err != nil {
+			return "", err
+		}
+
+		return "map[string]" + values, nil
+	default:
+		return "", ErrUnsupportedSchema
+	}
+}
+
+// itemType returns the go type of the items of an array or the values of a map, which are never pointers
+func itemType(s *openapi3.SchemaRef) (string, error) {
+	t, err := goType(s)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(t, "*"), nil
+}
+
+// exportedName returns the exported go name of a property, e.g. organizationId becomes OrganizationID
+func exportedName(name string) string {
+	out := fieldName(name)
+
+	for suffix, initialism := range initialisms {
+		if strings.HasSuffix(out, suffix) {
+			return strings.TrimSuffix(out, suffix) + initialism
+		}
+	}
+
+	return out
+}
+
+// modelsData is the data the models template is executed with
+type modelsData struct {
+	Models []model
+}
+
+// Imports returns true for every package that is used by the generated types
+func (d modelsData) Imports() map[string]bool {
+	imports := map[string]bool{}
+
+	for _, m := range d.Models {
+		for _, t := range append([]string{m.Type}, fieldTypes(m.Fields)...) {
+			imports["json"] = imports["json"] || strings.Contains(t, "json.")
+			imports["time"] = imports["time"] || strings.Contains(t, "time.")
+		}
+	}
+
+	return imports
+}
+
+// fieldTypes returns the go types of the fields
+func fieldTypes(fields []field) []string {
+	out := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		out = append(out, f.Type)
+	}
+
+	return out
+}
+
+var modelsTemplate = template.Must(template.New("models").Parse(`// Code generated by openapi/openapi_generator.go; DO NOT EDIT.
+
+package client
+{{ with .Imports }}
+{{- if or .json .time }}
+import (
+{{- if .json }}
+	"encoding/json"
+{{- end }}
+{{- if .time }}
+	"time"
+{{- end }}
+)
+{{ end }}
+{{- end }}
+{{- range .Models }}
+// {{ .Name }} is the {{ .Name }} schema of the specification
+{{- if .Type }}
+type {{ .Name }} {{ .Type }}
+{{- else }}
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }} ` + "`" + `json:"{{ .JSON }},omitempty"` + "`" + `
+{{- end }}
+}
+{{- end }}
+{{ end }}`))
@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	echo "github.com/theopenlane/echox"
+
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/validation"
+)
+
+const (
+	// checkedInSpec is the specification exported by the generator
+	checkedInSpec = "../../openapi/openapi.json"
+	// clientDir is the package of the client the generator writes the client functions to
+	clientDir = "../client"
+	// generatedClient is the client generated from the checked-in specification
+	generatedClient = clientDir + "/operations_gen.go"
+	// generatedModels are the request and reply types generated from the checked-in specification
+	generatedModels = clientDir + "/models_gen.go"
+	// regenerate is the message of every assertion that fails when the generator was not run after a change
+	regenerate = "run `task openapi:generate` and commit the result"
+)
+
+func TestSpecIsDeterministic(t *testing.T) {
+	first, err := Spec()
+	require.NoError(t, err)
+
+	second, err := Spec()
+	require.NoError(t, err)
+
+	a, err := Marshal(first)
+	require.NoError(t, err)
+
+	b, err := Marshal(second)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(a), string(b))
+}
+
+func TestCheckedInSpecMatchesRouter(t *testing.T) {
+	oas, err := Spec()
+	require.NoError(t, err)
+
+	served, err := Marshal(oas)
+	require.NoError(t, err)
+
+	exported, err := os.ReadFile(checkedInSpec)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(exported), string(served), regenerate)
+
+	loaded, err := Load(exported)
+	require.NoError(t, err)
+	require.NoError(t, loaded.Validate(context.Background()))
+}
+
+func TestValidationAcceptsNullOptionalFields(t *testing.T) {
+	oas, err := Spec()
+	require.NoError(t, err)
+
+	op := oas.Paths.Value("/organization").Post
+	require.NotNil(t, op)
+
+	validate := validation.New(validation.Config{Enabled: true}, oas).Middleware("/organization", http.MethodPost, op)
+	require.NotNil(t, validate)
+
+	handler := validate(func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusNoContent)
+	})
+
+	tests := map[string]struct {
+		body   string
+		status int
+	}{
+		"null string":   {body: `{"name":"Delta Inc","description":null}`, status: http.StatusNoContent},
+		"null array":    {body: `{"name":"Delta Inc","domains":null,"environments":null}`, status: http.StatusNoContent},
+		"null boolean":  {body: `{"name":"Delta Inc","dryRun":null}`, status: http.StatusNoContent},
+		"null settings": {body: `{"name":"Delta Inc","settings":null}`, status: http.StatusNoContent},
+		"null in settings": {
+			body:   `{"name":"Delta Inc","settings":{"billingEmail":null,"billingAddress":null,"billingNotificationsEnabled":null}}`,
+			status: http.StatusNoContent,
+		},
+		"wrong type": {body: `{"name":"Delta Inc","description":1}`, status: http.StatusBadRequest},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/organization", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			rec := httptest.NewRecorder()
+
+			_ = handler(echo.New().NewContext(req, rec))
+
+			assert.Equal(t, tc.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestGeneratedClientMatchesSpec(t *testing.T) {
+	exported, err := os.ReadFile(checkedInSpec)
+	require.NoError(t, err)
+
+	oas, err := Load(exported)
+	require.NoError(t, err)
+
+	generated, err := GenerateClient(oas)
+	require.NoError(t, err)
+
+	checkedIn, err := os.ReadFile(generatedClient)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(checkedIn), string(generated), regenerate)
+}
+
+func TestGeneratedModelsMatchSpec(t *testing.T) {
+	exported, err := os.ReadFile(checkedInSpec)
+	require.NoError(t, err)
+
+	oas, err := Load(exported)
+	require.NoError(t, err)
+
+	generated, err := GenerateModels(oas)
+	require.NoError(t, err)
+
+	checkedIn, err := os.ReadFile(generatedModels)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(checkedIn), string(generated), regenerate)
+}
+
+func TestClientUsesEveryOperation(t *testing.T) {
+	fset := token.NewFileSet()
+
+	files, err := filepath.Glob(filepath.Join(clientDir, "*.go"))
+	require.NoError(t, err)
+
+	generated := map[string]bool{}
+	called := map[string]bool{}
+
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		require.NoError(t, err)
+
+		isGenerated := name == filepath.Clean(generatedClient)
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				if isGenerated && strings.HasPrefix(n.Name.Name, "send") {
+					generated[n.Name.Name] = true
+				}
+			case *ast.Ident:
+				if !isGenerated && strings.HasPrefix(n.Name, "send") {
+					called[n.Name] = true
+				}
+			}
+
+			return true
+		})
+	}
+
+	require.NotEmpty(t, generated)
+
+	for name := range generated {
+		assert.True(t, called[name], "the operation of %s is not used by any method of the client", name)
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	tests := map[string]string{
+		"id":               "ID",
+		"dryRun":           "DryRun",
+		"X-Openlane-Token": "XOpenlaneToken",
+		"Idempotency-Key":  "IdempotencyKey",
+	}
+
+	for name, field := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, field, fieldName(name))
+		})
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"id":             "ID",
+		"organizationId": "OrganizationID",
+		"error_code":     "ErrorCode",
+		"avatarURL":      "AvatarURL",
+		"identifier":     "Identifier",
+	}
+
+	for name, field := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, field, exportedName(name))
+		})
+	}
+}
+
+func TestPathExpr(t *testing.T) {
+	assert.Equal(t, `"/v1/audit"`, pathExpr("/v1/audit"))
+	assert.Equal(t, `"/v1/organization/" + url.PathEscape(params.ID) + "/reconcile"`, pathExpr("/v1/organization/{id}/reconcile"))
+}
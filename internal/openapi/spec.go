@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"encoding/json"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/theopenlane/openlane-cloud/internal/auth"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/handlers"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/route"
+	"github.com/theopenlane/openlane-cloud/internal/httpserve/server"
+)
+
+// Spec registers every route with a new router and returns the OpenAPI specification it serves; the specification is
+// exported with authentication disabled, so it does not depend on the credentials configured for the server
+func Spec() (*openapi3.T, error) {
+	router, err := server.NewRouter()
+	if err != nil {
+		return nil, err
+	}
+
+	router.Handler = &handlers.Handler{}
+
+	if err := route.RegisterRoutes(router); err != nil {
+		return nil, err
+	}
+
+	server.AddSecuritySchemes(router.OAS, auth.Config{})
+
+	return router.OAS, nil
+}
+
+// Marshal returns the indented JSON of the specification; map keys are sorted by encoding/json so the output is the
+// same every time the specification is exported
+func Marshal(oas *openapi3.T) ([]byte, error) {
+	out, err := json.MarshalIndent(oas, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
+
+// Load parses an exported specification and resolves its references, so it can be validated or used to generate the
+// client the same way as the specification served by the router
+func Load(spec []byte) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+
+	oas, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return oas, nil
+}
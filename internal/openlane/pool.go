@@ -0,0 +1,89 @@
+package openlane
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/theopenlane/core/pkg/openlaneclient"
+)
+
+// ClientFunc creates an openlane client that authenticates with the token
+type ClientFunc func(token string) (*openlaneclient.OpenlaneClient, error)
+
+// ClientPool caches an openlane client for each credential so requests made with the same token reuse the
+// same client; the least recently used client is evicted once the pool is full
+type ClientPool struct {
+	mu        sync.Mutex
+	size      int
+	clients   map[string]*list.Element
+	order     *list.List
+	newClient ClientFunc
+}
+
+// pooledClient is a cached client and the key of the credential it was created with
+type pooledClient struct {
+	key    string
+	client *openlaneclient.OpenlaneClient
+}
+
+// NewClientPool returns a pool holding up to size clients; when newClient is nil clients are created with the
+// default openlane configuration
+func NewClientPool(size int, newClient ClientFunc) *ClientPool {
+	if newClient == nil {
+		newClient = func(token string) (*openlaneclient.OpenlaneClient, error) {
+			return (&Config{Token: token}).NewClient()
+		}
+	}
+
+	return &ClientPool{
+		size:      max(size, 1),
+		clients:   map[string]*list.Element{},
+		order:     list.New(),
+		newClient: newClient,
+	}
+}
+
+// Get returns the client for the token, creating it if it is not already in the pool
+func (p *ClientPool) Get(token string) (*openlaneclient.OpenlaneClient, error) {
+	if token == "" {
+		return nil, ErrAPITokenMissing
+	}
+
+	// the pool is keyed by a hash so tokens are not kept around as map keys
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if el, ok := p.clients[key]; ok {
+		p.order.MoveToFront(el)
+
+		return el.Value.(*pooledClient).client, nil
+	}
+
+	client, err := p.newClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	p.clients[key] = p.order.PushFront(&pooledClient{key: key, client: client})
+
+	for p.order.Len() > p.size {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.clients, oldest.Value.(*pooledClient).key)
+	}
+
+	return client, nil
+}
+
+// Len returns the number of clients in the pool
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.order.Len()
+}
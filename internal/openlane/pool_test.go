@@ -0,0 +1,48 @@
+package openlane_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/theopenlane/core/pkg/openlaneclient"
+
+	"github.com/theopenlane/openlane-cloud/internal/openlane"
+)
+
+func TestClientPool(t *testing.T) {
+	created := []string{}
+
+	pool := openlane.NewClientPool(2, func(token string) (*openlaneclient.OpenlaneClient, error) {
+		created = append(created, token)
+
+		return &openlaneclient.OpenlaneClient{}, nil
+	})
+
+	_, err := pool.Get("")
+	require.ErrorIs(t, err, openlane.ErrAPITokenMissing)
+
+	first, err := pool.Get("token-1")
+	require.NoError(t, err)
+
+	// the same token reuses the client
+	again, err := pool.Get("token-1")
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+
+	_, err = pool.Get("token-2")
+	require.NoError(t, err)
+
+	// token-1 was used most recently so token-2 is evicted when the pool is full
+	_, err = pool.Get("token-1")
+	require.NoError(t, err)
+
+	_, err = pool.Get("token-3")
+	require.NoError(t, err)
+	assert.Equal(t, 2, pool.Len())
+
+	_, err = pool.Get("token-2")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"token-1", "token-2", "token-3", "token-2"}, created)
+}
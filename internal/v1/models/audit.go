@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/theopenlane/utils/rout"
+)
+
+const (
+	// AuditActionCreate is recorded when an organization hierarchy is provisioned
+	AuditActionCreate = "organization.create"
+	// AuditActionUpdate is recorded when organizations are added to an existing hierarchy
+	AuditActionUpdate = "organization.update"
+	// AuditActionDelete is recorded when an organization hierarchy is deleted
+	AuditActionDelete = "organization.delete"
+	// AuditActionReconcile is recorded when a hierarchy is repaired to match its template
+	AuditActionReconcile = "organization.reconcile"
+)
+
+const (
+	// AuditOutcomeSuccess is the outcome of an action that completed
+	AuditOutcomeSuccess = "success"
+	// AuditOutcomeFailure is the outcome of an action that failed, any organizations it created have been rolled back
+	AuditOutcomeFailure = "failure"
+)
+
+const (
+	// DefaultAuditLimit is the number of audit entries returned when no limit is requested
+	DefaultAuditLimit = 100
+	// MaxAuditLimit is the maximum number of audit entries returned by a single request
+	MaxAuditLimit = 1000
+)
+
+// AuditEntry is a record of a single provisioning action in the audit log
+type AuditEntry struct {
+	// ID is the unique id of the entry
+	ID string `json:"id"`
+	// Time is the time the action completed
+	Time time.Time `json:"time"`
+	// RequestID is the id of the request that started the action, from the X-Request-ID header
+	RequestID string `json:"requestId,omitempty"`
+	// Caller is the name of the API token or the subject of the JWT that made the request
+	Caller string `json:"caller,omitempty"`
+	// AuthMethod is the way the caller was authenticated
+	AuthMethod string `json:"authMethod,omitempty"`
+	// Action is the provisioning action, e.g. organization.create
+	Action string `json:"action"`
+	// OrganizationID is the id of the root organization of the hierarchy
+	OrganizationID string `json:"organizationId,omitempty"`
+	// Input is the request body of the action
+	Input json.RawMessage `json:"input,omitempty"`
+	// Created contains the ids of the organizations created by the action, in creation order
+	Created []string `json:"created,omitempty"`
+	// Deleted contains the ids of the organizations deleted by the action, or rolled back after a failure
+	Deleted []string `json:"deleted,omitempty"`
+	// Outcome is success or failure
+	Outcome string `json:"outcome"`
+	// Error is the reason the action failed
+	Error string `json:"error,omitempty"`
+}
+
+// AuditFilter selects the entries returned from the audit log
+type AuditFilter struct {
+	// Since excludes entries before the time when set
+	Since time.Time
+	// Until excludes entries after the time when set
+	Until time.Time
+	// OrganizationID only includes entries for the hierarchy with the root organization or that created or deleted the
+	// organization when set
+	OrganizationID string
+	// Limit is the maximum number of entries returned, the most recent entries are kept
+	Limit int
+	// Caller only includes entries recorded for the caller; it is always applied, so entries recorded without a caller
+	// are only returned when it is not set. It is set by the server from the authenticated caller of the request
+	Caller string
+	// AuthMethod only includes entries recorded for callers authenticated with the method; it is always applied with
+	// Caller, so an API token and a JWT with the same subject do not see each other's entries
+	AuthMethod string
+}
+
+// Match reports whether the entry is selected by the filter, ignoring the limit
+func (f AuditFilter) Match(e AuditEntry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && e.Time.After(f.Until) {
+		return false
+	}
+
+	if e.Caller != f.Caller || e.AuthMethod != f.AuthMethod {
+		return false
+	}
+
+	if f.OrganizationID == "" || e.OrganizationID == f.OrganizationID {
+		return true
+	}
+
+	for _, ids := range [][]string{e.Created, e.Deleted} {
+		for _, id := range ids {
+			if id == f.OrganizationID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// AuditReply is the response object for the audit log
+type AuditReply struct {
+	rout.Reply
+	// Entries contains the matching entries, oldest first
+	Entries []AuditEntry `json:"entries"`
+}
+
+// ExampleAuditResponse is an example of a successful audit log response for OpenAPI documentation
+var ExampleAuditResponse = AuditReply{
+	Reply: rout.Reply{Success: true},
+	Entries: []AuditEntry{
+		{
+			ID:             "01J4EXD5MM60CX4YNYN0DWE0D1",
+			Time:           time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC),
+			RequestID:      "gLGtOnmEyZOsMZsxChXZMvPyBGVhqYeN",
+			Caller:         "ci",
+			AuthMethod:     "api_token",
+			Action:         AuditActionCreate,
+			OrganizationID: "1234",
+			Input:          json.RawMessage(`{"name":"MITB Inc.","environments":["production"]}`),
+			Created:        []string{"1234", "5678"},
+			Outcome:        AuditOutcomeSuccess,
+		},
+	},
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// csvListSeparator separates the values of list columns, such as domains, within a single csv field
+const csvListSeparator = ";"
+
+// CSVColumns are the columns that can be used in a csv file of organizations, the name column is required
+var CSVColumns = []string{"name", "description", "domains", "template", "environments", "buckets", "relationships"}
+
+var (
+	// ErrCSVEmpty is returned when a csv file of organizations has no header row
+	ErrCSVEmpty = errors.New("csv file is empty")
+
+	// ErrCSVUnknownColumn is returned when the header row of a csv file contains a column that is not supported
+	ErrCSVUnknownColumn = errors.New("unknown csv column")
+
+	// ErrCSVMissingName is returned when the header row of a csv file does not contain the name column
+	ErrCSVMissingName = errors.New("csv file must have a name column")
+)
+
+// ParseOrganizationsCSV reads organization requests from a csv file; the first row is a header naming the columns,
+// in any order, and list columns contain values separated by ';'. Empty list columns are left unset so the default
+// hierarchy is used
+func ParseOrganizationsCSV(r io.Reader) ([]OrganizationRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrCSVEmpty
+		}
+
+		return nil, err
+	}
+
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+
+		if !slices.Contains(CSVColumns, header[i]) {
+			return nil, fmt.Errorf("%w: %q", ErrCSVUnknownColumn, column)
+		}
+	}
+
+	if !slices.Contains(header, "name") {
+		return nil, ErrCSVMissingName
+	}
+
+	out := []OrganizationRequest{}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		in := OrganizationRequest{}
+
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+
+			switch header[i] {
+			case "name":
+				in.Name = value
+			case "description":
+				in.Description = value
+			case "domains":
+				in.Domains = csvList(value)
+			case "template":
+				in.Template = value
+			case "environments":
+				in.Environments = csvList(value)
+			case "buckets":
+				in.Buckets = csvList(value)
+			case "relationships":
+				in.Relationships = csvList(value)
+			}
+		}
+
+		out = append(out, in)
+	}
+
+	return out, nil
+}
+
+// csvList splits a csv field into a list, an empty field returns nil
+func csvList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, csvListSeparator)
+}
@@ -0,0 +1,88 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+func TestParseOrganizationsCSV(t *testing.T) {
+	testCases := []struct {
+		name    string
+		csv     string
+		want    []models.OrganizationRequest
+		wantErr error
+	}{
+		{
+			name: "all columns",
+			csv: `name,description,domains,template,environments,buckets,relationships
+MITB Inc.,meow,mitb.com;meow.com,,production;staging,assets,vendors
+Meow Corp, ,,retail,,,
+`,
+			want: []models.OrganizationRequest{
+				{
+					Name:          "MITB Inc.",
+					Description:   "meow",
+					Domains:       []string{"mitb.com", "meow.com"},
+					Environments:  []string{"production", "staging"},
+					Buckets:       []string{"assets"},
+					Relationships: []string{"vendors"},
+				},
+				{
+					Name:     "Meow Corp",
+					Template: "retail",
+				},
+			},
+		},
+		{
+			name: "columns in any order and case",
+			csv:  "Template,NAME\nretail,\"Meow, Corp\"\n",
+			want: []models.OrganizationRequest{
+				{Name: "Meow, Corp", Template: "retail"},
+			},
+		},
+		{
+			name: "header only",
+			csv:  "name\n",
+			want: []models.OrganizationRequest{},
+		},
+		{
+			name:    "empty",
+			csv:     "",
+			wantErr: models.ErrCSVEmpty,
+		},
+		{
+			name:    "unknown column",
+			csv:     "name,owner\nmeow,me\n",
+			wantErr: models.ErrCSVUnknownColumn,
+		},
+		{
+			name:    "missing name column",
+			csv:     "description\nmeow\n",
+			wantErr: models.ErrCSVMissingName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := models.ParseOrganizationsCSV(strings.NewReader(tc.csv))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, out)
+		})
+	}
+}
+
+func TestParseOrganizationsCSVWrongFieldCount(t *testing.T) {
+	_, err := models.ParseOrganizationsCSV(strings.NewReader("name,template\nmeow\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/theopenlane/core/pkg/enums"
+)
+
+const (
+	// MaxInvites is the maximum number of users that can be invited by a single request
+	MaxInvites = 100
+	// MaxGroups is the maximum number of groups that can be created in each environment by a single request
+	MaxGroups = 20
+)
+
+// OrganizationInvite is a user invited to join the root organization once the hierarchy has been created
+type OrganizationInvite struct {
+	// Email is the email address the invitation is sent to
+	Email string `json:"email"`
+	// Role is the role of the user in the organization: ADMIN or MEMBER, MEMBER is used when it is not set
+	Role string `json:"role,omitempty"`
+}
+
+// OrganizationGroup is a group created in the environments of the hierarchy once it has been created
+type OrganizationGroup struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Environments are the names of the environments, or the first level of a template, the group is created in;
+	// the group is created in every environment when none are set
+	Environments []string `json:"environments,omitempty"`
+	// Members are the existing openlane users added to every copy of the group
+	Members []GroupMember `json:"members,omitempty"`
+}
+
+// GroupMember is an existing openlane user added to a group
+type GroupMember struct {
+	UserID string `json:"userId"`
+	// Role is the role of the user in the group: ADMIN or MEMBER, MEMBER is used when it is not set
+	Role string `json:"role,omitempty"`
+}
+
+// InviteDetails is an invitation sent to join the root organization
+type InviteDetails struct {
+	ID     string `json:"id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	Status string `json:"status,omitempty"`
+}
+
+// GroupDetails is a group created in an organization of the hierarchy
+type GroupDetails struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// OrganizationID is the id of the organization that owns the group
+	OrganizationID string `json:"organizationId"`
+	// Members contains the users added to the group
+	Members []GroupMemberDetails `json:"members,omitempty"`
+}
+
+// GroupMemberDetails is a user added to a group
+type GroupMemberDetails struct {
+	// ID is the id of the group membership
+	ID     string `json:"id"`
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+}
+
+// normalizeMembers trims the invites and groups, lowercases the invited email addresses, and uppercases the roles
+func normalizeMembers(invites []OrganizationInvite, groups []OrganizationGroup) {
+	for i := range invites {
+		invites[i].Email = strings.ToLower(strings.TrimSpace(invites[i].Email))
+		invites[i].Role = strings.ToUpper(strings.TrimSpace(invites[i].Role))
+	}
+
+	for i := range groups {
+		groups[i].Name = strings.TrimSpace(groups[i].Name)
+		normalize(groups[i].Environments)
+
+		for j := range groups[i].Members {
+			groups[i].Members[j].UserID = strings.TrimSpace(groups[i].Members[j].UserID)
+			groups[i].Members[j].Role = strings.ToUpper(strings.TrimSpace(groups[i].Members[j].Role))
+		}
+	}
+}
+
+// invites validates the invited users and ensures each email address is only invited once
+func (v *fieldValidator) invites(invites []OrganizationInvite) {
+	if len(invites) > MaxInvites {
+		v.add("invites", "the request contains %d invites, the maximum is %d", len(invites), MaxInvites)
+
+		return
+	}
+
+	seen := make(map[string]struct{}, len(invites))
+
+	for i, in := range invites {
+		field := fmt.Sprintf("invites[%d]", i)
+
+		v.role(field+".role", in.Role)
+
+		if addr, err := mail.ParseAddress(in.Email); err != nil || addr.Address != in.Email {
+			v.add(field+".email", "%q is not a valid email address", in.Email)
+
+			continue
+		}
+
+		if _, ok := seen[in.Email]; ok {
+			v.add(field+".email", "%q is invited more than once", in.Email)
+		}
+
+		seen[in.Email] = struct{}{}
+	}
+}
+
+// groups validates the groups and their members and ensures each group name is only used once
+func (v *fieldValidator) groups(groups []OrganizationGroup) {
+	if len(groups) > MaxGroups {
+		v.add("groups", "the request contains %d groups, the maximum is %d", len(groups), MaxGroups)
+
+		return
+	}
+
+	seen := make(map[string]struct{}, len(groups))
+
+	for i, g := range groups {
+		field := fmt.Sprintf("groups[%d]", i)
+
+		v.children(field+".environments", g.Environments, nil)
+
+		switch {
+		case g.Name == "":
+			v.add(field+".name", "is required")
+		case len(g.Name) > MaxNameLength:
+			v.add(field+".name", "must be at most %d characters", MaxNameLength)
+		default:
+			key := strings.ToLower(g.Name)
+			if _, ok := seen[key]; ok {
+				v.add(field+".name", "%q is listed more than once", g.Name)
+			}
+
+			seen[key] = struct{}{}
+		}
+
+		users := make(map[string]struct{}, len(g.Members))
+
+		for j, m := range g.Members {
+			f := fmt.Sprintf("%s.members[%d]", field, j)
+
+			v.role(f+".role", m.Role)
+
+			if m.UserID == "" {
+				v.add(f+".userId", "is required")
+
+				continue
+			}
+
+			if _, ok := users[m.UserID]; ok {
+				v.add(f+".userId", "%q is listed more than once", m.UserID)
+			}
+
+			users[m.UserID] = struct{}{}
+		}
+	}
+}
+
+// role validates an optional role of an invited user or group member
+func (v *fieldValidator) role(field, role string) {
+	if role != "" && !slices.Contains(enums.Role("").Values(), role) {
+		v.add(field, "%q must be one of %s", role, strings.Join(enums.Role("").Values(), ", "))
+	}
+}
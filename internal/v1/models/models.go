@@ -1,6 +1,10 @@
 package models
 
 import (
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/mcuadros/go-defaults"
 	"github.com/theopenlane/utils/rout"
 )
@@ -11,22 +15,304 @@ import (
 
 // OrganizationRequest is the request object for creating a organization
 type OrganizationRequest struct {
-	Name          string   `json:"name"`
-	Description   string   `json:"description,omitempty"`
-	Domains       []string `json:"domains,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Domains     []string `json:"domains,omitempty"`
+	// Template is the name of the blueprint used to create the hierarchy, a specific version can be selected with
+	// name@version; the default blueprint is used when no template is provided
+	Template      string   `json:"template,omitempty"`
 	Environments  []string `json:"environments,omitempty" default:"[production,testing]"`
 	Buckets       []string `json:"buckets,omitempty" default:"[assets,customers,orders,relationships,sales]"`
 	Relationships []string `json:"relationships,omitempty" default:"[internal_users,marketing_subscribers,marketplaces,partners,vendors]"`
+	// Settings of the root organization, the settings named in settings.inherit are also applied to the organizations
+	// underneath it
+	Settings *OrganizationSettings `json:"settings,omitempty"`
+	// Invites contains the users invited to the root organization once the hierarchy has been created
+	Invites []OrganizationInvite `json:"invites,omitempty"`
+	// Groups contains the groups created in the environments once the hierarchy has been created
+	Groups []OrganizationGroup `json:"groups,omitempty"`
+	// DryRun validates the request and returns the organizations that would be created without creating them
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // OrganizationReply is the response object for creating a organization
 type OrganizationReply struct {
 	rout.Reply
-	ID           string        `json:"id"`
-	Name         string        `json:"name"`
-	Description  string        `json:"description,omitempty"`
-	Domains      []string      `json:"domains,omitempty"`
+	// OrgDetails contains the id, names, tags, settings, and creation time of the root organization
+	OrgDetails
+	Description string   `json:"description,omitempty"`
+	Domains     []string `json:"domains,omitempty"`
+	// Template is the name and version of the blueprint used to create the hierarchy
+	Template string `json:"template,omitempty"`
+	// Environments contains the hierarchy created by the default blueprint
 	Environments []Environment `json:"environments,omitempty"`
+	// Children contains the hierarchy created by any other blueprint
+	Children []OrgNode `json:"children,omitempty"`
+	// Invites contains the invitations sent to join the root organization
+	Invites []InviteDetails `json:"invites,omitempty"`
+	// Groups contains the groups created in the environments
+	Groups []GroupDetails `json:"groups,omitempty"`
+}
+
+// OrganizationEvent is the payload of the webhooks sent when an organization hierarchy is created, updated, or deleted
+type OrganizationEvent struct {
+	// Organization is the hierarchy after it was created or updated, or before it was deleted
+	Organization *OrganizationReply `json:"organization"`
+	// Created contains the organizations added to an existing hierarchy
+	Created []OrgDetails `json:"created,omitempty"`
+	// Deleted contains the organizations that were deleted, children before their parents
+	Deleted []OrgDetails `json:"deleted,omitempty"`
+}
+
+// OrganizationPlanReply is the response object for a dry run of creating a organization
+type OrganizationPlanReply struct {
+	rout.Reply
+	// DryRun is always true, no organizations were created
+	DryRun bool `json:"dryRun"`
+	// Template is the name and version of the blueprint the hierarchy would be created from
+	Template string `json:"template"`
+	// Total is the number of organizations that would be created, including the root organization
+	Total int `json:"total"`
+	// Organization is the root organization that would be created along with the hierarchy underneath it
+	Organization PlannedOrg `json:"organization"`
+	// Collisions contains the existing organizations that use the name of a planned organization; creating the
+	// hierarchy fails while there are any collisions
+	Collisions []OrgDetails `json:"collisions,omitempty"`
+	// Invites contains the users that would be invited to the root organization
+	Invites []OrganizationInvite `json:"invites,omitempty"`
+}
+
+// PlannedOrg is an organization that would be created by a request along with the organizations underneath it
+type PlannedOrg struct {
+	// Name is the unique openlane name of the organization, the path from the root joined by '.'
+	Name string `json:"name"`
+	// DisplayName is the name of the organization within its parent
+	DisplayName string `json:"displayName"`
+	// Description of the organization
+	Description string `json:"description,omitempty"`
+	// Parent is the unique openlane name of the parent organization, empty for the root organization
+	Parent string `json:"parent,omitempty"`
+	// Tags that would be set on the organization
+	Tags []string `json:"tags,omitempty"`
+	// Domains that would be set on the organization settings
+	Domains []string `json:"domains,omitempty"`
+	// Groups contains the names of the groups that would be created in the organization
+	Groups []string `json:"groups,omitempty"`
+	// ExistingID is the id of the organization that already uses the name, if any
+	ExistingID string `json:"existingId,omitempty"`
+	// Children are the organizations that would be created underneath the organization
+	Children []PlannedOrg `json:"children,omitempty"`
+}
+
+// BulkOrganizationRequest is the request object for creating many organizations in a single request
+type BulkOrganizationRequest []OrganizationRequest
+
+// BulkOrganizationReply is the response object for creating many organizations in a single request
+type BulkOrganizationReply struct {
+	rout.Reply
+	// Total is the number of organizations in the request
+	Total int `json:"total"`
+	// Succeeded is the number of organizations that were created
+	Succeeded int `json:"succeeded"`
+	// Failed is the number of organizations that could not be created
+	Failed int `json:"failed"`
+	// Results contains the outcome for every organization, in the same order as the request
+	Results []BulkOrganizationResult `json:"results"`
+}
+
+// BulkOrganizationResult is the outcome of creating a single organization in a bulk request
+type BulkOrganizationResult struct {
+	rout.Reply
+	// Index is the position of the organization in the request, starting at 0
+	Index int `json:"index"`
+	// Name is the name of the organization in the request
+	Name string `json:"name"`
+	// Status is the http status code the organization would have received if it was created on its own
+	Status int `json:"status"`
+	// Fields contains the invalid fields when the organization failed validation
+	Fields []FieldError `json:"fields,omitempty"`
+	// Organization is the hierarchy that was created
+	Organization *OrganizationReply `json:"organization,omitempty"`
+	// Plan is the hierarchy that would be created when the organization is a dry run
+	Plan *OrganizationPlanReply `json:"plan,omitempty"`
+	// Orphaned contains the organizations that could not be rolled back after a failure and must be cleaned up manually
+	Orphaned []OrgDetails `json:"orphaned,omitempty"`
+}
+
+// OrganizationUpdateRequest is the request object for adding environments, buckets, or relationships to an existing
+// organization hierarchy
+type OrganizationUpdateRequest struct {
+	Environments  []string `json:"environments,omitempty"`
+	Buckets       []string `json:"buckets,omitempty"`
+	Relationships []string `json:"relationships,omitempty"`
+}
+
+// OrganizationUpdateReply is the response object for updating an organization hierarchy
+type OrganizationUpdateReply struct {
+	OrganizationReply
+	// Created contains the organizations that were added to the hierarchy
+	Created []OrgDetails `json:"created"`
+}
+
+// OrganizationReconcileRequest is the request object for comparing an existing organization hierarchy with the expected
+// hierarchy, which is described by a template or the environments, buckets, and relationships in the same way as a new
+// organization
+type OrganizationReconcileRequest struct {
+	// Template is the name of the blueprint the hierarchy is expected to match, a specific version can be selected
+	// with name@version
+	Template      string   `json:"template,omitempty"`
+	Environments  []string `json:"environments,omitempty"`
+	Buckets       []string `json:"buckets,omitempty"`
+	Relationships []string `json:"relationships,omitempty"`
+	// Repair creates missing organizations and restores the names of renamed organizations
+	Repair bool `json:"repair,omitempty"`
+	// Prune deletes organizations that are not in the expected hierarchy, only used when repair is set; the expected
+	// hierarchy must be set with the template, or environments, buckets, and relationships, since the template a
+	// hierarchy was created with is not known
+	Prune bool `json:"prune,omitempty"`
+}
+
+// OrganizationReconcileReply is the response object for reconciling an organization hierarchy
+type OrganizationReconcileReply struct {
+	rout.Reply
+	// Template is the name and version of the blueprint the hierarchy was compared with
+	Template string `json:"template"`
+	// InSync is true when the existing hierarchy matched the expected hierarchy before any repair
+	InSync bool `json:"inSync"`
+	// Missing contains the expected organizations that do not exist
+	Missing []DriftNode `json:"missing,omitempty"`
+	// Extra contains the organizations that exist but are not in the expected hierarchy
+	Extra []DriftNode `json:"extra,omitempty"`
+	// Renamed contains the organizations whose display name no longer matches the expected hierarchy
+	Renamed []RenamedNode `json:"renamed,omitempty"`
+	// Repaired is true when the differences were repaired
+	Repaired bool `json:"repaired,omitempty"`
+	// Created contains the organizations created by the repair
+	Created []OrgDetails `json:"created,omitempty"`
+	// Deleted contains the organizations deleted by the repair when pruning
+	Deleted []OrgDetails `json:"deleted,omitempty"`
+}
+
+// DriftNode is an organization that is missing from, or not expected in, an organization hierarchy
+type DriftNode struct {
+	// ID of the organization, empty for missing organizations
+	ID string `json:"id,omitempty"`
+	// Path of the organization from the root organization, joined by '.'
+	Path string `json:"path"`
+	// Descendants is the number of organizations underneath the organization that are also missing or extra
+	Descendants int `json:"descendants,omitempty"`
+}
+
+// RenamedNode is an organization whose display name no longer matches the expected hierarchy
+type RenamedNode struct {
+	// ID of the organization
+	ID string `json:"id"`
+	// Path of the organization in the expected hierarchy, joined by '.'
+	Path string `json:"path"`
+	// Name is the current display name of the organization
+	Name string `json:"name"`
+	// ExpectedName is the display name of the organization in the expected hierarchy
+	ExpectedName string `json:"expectedName"`
+}
+
+// OrganizationDeleteReply is the response object for deleting an organization hierarchy
+type OrganizationDeleteReply struct {
+	rout.Reply
+	// DryRun is true when no organizations were deleted
+	DryRun bool `json:"dryRun,omitempty"`
+	// Organizations contains the organizations that were deleted, or would be deleted for a dry run, leaf first
+	Organizations []OrgDetails `json:"organizations"`
+	// Failed contains the organizations that could not be deleted
+	Failed []OrgDetails `json:"failed,omitempty"`
+}
+
+const (
+	// DefaultPageSize is the number of organizations returned by a list request when no limit is requested
+	DefaultPageSize = 50
+	// MaxPageSize is the maximum number of organizations returned by a single list request
+	MaxPageSize = 500
+)
+
+// OrganizationListReply is a page of the root organizations visible to the caller
+type OrganizationListReply struct {
+	rout.Reply
+	// Organizations contains the root organizations on the page, ordered by id
+	Organizations []OrgDetails `json:"organizations"`
+	// NextCursor is passed as the cursor to return the next page, it is empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// ProvisioningFailureReply is the response object returned when an organization hierarchy could not be fully provisioned
+type ProvisioningFailureReply struct {
+	rout.Reply
+	// Created contains all organizations created before the failure, in creation order
+	Created []OrgDetails `json:"created,omitempty"`
+	// RolledBack contains the organizations that were deleted after the failure
+	RolledBack []OrgDetails `json:"rolledBack,omitempty"`
+	// Orphaned contains the organizations that could not be deleted and must be cleaned up manually
+	Orphaned []OrgDetails `json:"orphaned,omitempty"`
+}
+
+// JobStatus is the state of an asynchronous provisioning job
+type JobStatus string
+
+var (
+	// JobStatusPending is the status of a job waiting for a worker
+	JobStatusPending JobStatus = "pending"
+	// JobStatusRunning is the status of a job currently being provisioned
+	JobStatusRunning JobStatus = "running"
+	// JobStatusSucceeded is the status of a job that provisioned the full hierarchy
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusFailed is the status of a job that failed and was rolled back
+	JobStatusFailed JobStatus = "failed"
+)
+
+// NodeStatus is the state of a single organization within an asynchronous provisioning job
+type NodeStatus string
+
+var (
+	// NodeStatusCreated is the status of an organization that was created
+	NodeStatusCreated NodeStatus = "created"
+	// NodeStatusRolledBack is the status of an organization that was deleted after its hierarchy failed to be created
+	NodeStatusRolledBack NodeStatus = "rolled_back"
+	// NodeStatusOrphaned is the status of an organization that could not be deleted after the job failed
+	NodeStatusOrphaned NodeStatus = "orphaned"
+)
+
+// ProvisioningJob is an asynchronous organization provisioning job
+type ProvisioningJob struct {
+	// ID is the identifier of the job
+	ID string `json:"id"`
+	// Status is the current state of the job
+	Status JobStatus `json:"status"`
+	// Total is the number of organizations expected to be created by the job
+	Total int `json:"total"`
+	// Completed is the number of organizations created so far
+	Completed int `json:"completed"`
+	// Nodes contains the organizations created by the job and their current state
+	Nodes []JobNode `json:"nodes,omitempty"`
+	// Result is the final organization hierarchy once the job has succeeded
+	Result *OrganizationReply `json:"result,omitempty"`
+	// BulkResult is the result for every organization in the request once a bulk job has finished
+	BulkResult *BulkOrganizationReply `json:"bulkResult,omitempty"`
+	// Failure contains the error and rollback details once the job has failed
+	Failure *ProvisioningFailureReply `json:"failure,omitempty"`
+	// CreatedAt is the time the job was submitted
+	CreatedAt time.Time `json:"createdAt"`
+	// UpdatedAt is the time the job was last updated
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// JobNode is a single organization created by an asynchronous provisioning job
+type JobNode struct {
+	OrgDetails
+	Status NodeStatus `json:"status"`
+}
+
+// ProvisioningJobReply is the response object for an asynchronous provisioning job
+type ProvisioningJobReply struct {
+	rout.Reply
+	Job ProvisioningJob `json:"job"`
 }
 
 type Environment struct {
@@ -43,37 +329,143 @@ type Relationship struct {
 	OrgDetails
 }
 
+// OrgDetails is a single organization in openlane
 type OrgDetails struct {
-	ID   string `json:"id"`
+	ID string `json:"id"`
+	// Name is the display name of the organization
 	Name string `json:"name"`
+	// Slug is the unique lower case openlane name of the organization, the path from the root joined by '.'
+	Slug string `json:"slug,omitempty"`
+	// Tags on the organization
+	Tags []string `json:"tags,omitempty"`
+	// ParentID is the id of the parent organization, empty for the root organization
+	ParentID string `json:"parentId,omitempty"`
+	// Settings of the organization
+	Settings *OrgSettings `json:"settings,omitempty"`
+	// CreatedAt is the time the organization was created
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// OrgSettings are the settings of an organization
+type OrgSettings struct {
+	Domains             []string `json:"domains,omitempty"`
+	AllowedEmailDomains []string `json:"allowedEmailDomains,omitempty"`
+	BillingContact      string   `json:"billingContact,omitempty"`
+	BillingEmail        string   `json:"billingEmail,omitempty"`
+	BillingPhone        string   `json:"billingPhone,omitempty"`
 }
 
-// Validate ensures the required fields are set on the OrganizationRequest request
+// OrgNode is an organization created from a blueprint along with the organizations created underneath it
+type OrgNode struct {
+	OrgDetails
+	Children []OrgNode `json:"children,omitempty"`
+}
+
+// Validate normalizes the OrganizationRequest request and ensures the name, domains, and hierarchy are valid; every
+// invalid field is returned in a single ValidationError
 func (r *OrganizationRequest) Validate() error {
-	// Required for all requests
-	if r.Name == "" {
-		return rout.MissingField("name")
+	r.Name = strings.TrimSpace(r.Name)
+	normalizeDomains(r.Domains)
+	normalize(r.Environments)
+	normalize(r.Buckets)
+	normalize(r.Relationships)
+	r.Settings.normalize()
+	normalizeMembers(r.Invites, r.Groups)
+
+	if err := validateHierarchy(r.Template, &r.Environments, &r.Buckets, &r.Relationships); err != nil {
+		return err
+	}
+
+	v := &fieldValidator{}
+	v.name(r.Name)
+	v.domains("domains", r.Domains)
+	v.settings(r.Settings)
+	v.invites(r.Invites)
+	v.groups(r.Groups)
+
+	if r.Template == "" {
+		v.hierarchy(strings.ToLower(r.Name), r.Environments, r.Buckets, r.Relationships)
+	}
+
+	return v.err()
+}
+
+// validateHierarchy ensures the environments, buckets, and relationships, which describe the default hierarchy, are not
+// combined with a template and sets the default values for any that are not provided when one of them is set
+func validateHierarchy(template string, environments, buckets, relationships *[]string) error {
+	if *environments == nil && *buckets == nil && *relationships == nil {
+		return nil
+	}
+
+	if template != "" {
+		return rout.ConflictingFields("template", "environments", "buckets", "relationships")
 	}
 
 	// Set default values if not provided in the request
 	defaultRequest := &OrganizationRequest{}
 	defaults.SetDefaults(defaultRequest)
 
-	if r.Environments == nil {
-		r.Environments = defaultRequest.Environments
+	if *environments == nil {
+		*environments = defaultRequest.Environments
 	}
 
-	if r.Buckets == nil {
-		r.Buckets = defaultRequest.Buckets
+	if *buckets == nil {
+		*buckets = defaultRequest.Buckets
 	}
 
-	if r.Relationships == nil {
-		r.Relationships = defaultRequest.Relationships
+	if *relationships == nil {
+		*relationships = defaultRequest.Relationships
 	}
 
 	return nil
 }
 
+// Validate ensures the template is not combined with environments, buckets, or relationships on the
+// OrganizationReconcileRequest request, that the expected hierarchy is set when pruning, and that the names in the
+// expected hierarchy are valid
+func (r *OrganizationReconcileRequest) Validate() error {
+	normalize(r.Environments)
+	normalize(r.Buckets)
+	normalize(r.Relationships)
+
+	// pruning against the default hierarchy would delete the organizations of a hierarchy created from another template
+	if r.Prune && r.Template == "" && r.Environments == nil && r.Buckets == nil && r.Relationships == nil {
+		return rout.MissingField("template, environments, buckets, or relationships")
+	}
+
+	if err := validateHierarchy(r.Template, &r.Environments, &r.Buckets, &r.Relationships); err != nil {
+		return err
+	}
+
+	if r.Template != "" {
+		return nil
+	}
+
+	v := &fieldValidator{}
+	v.hierarchy("", r.Environments, r.Buckets, r.Relationships)
+
+	return v.err()
+}
+
+// Validate ensures at least one environment, bucket, or relationship is set on the OrganizationUpdateRequest request and
+// that their names are valid
+func (r *OrganizationUpdateRequest) Validate() error {
+	if len(r.Environments) == 0 && len(r.Buckets) == 0 && len(r.Relationships) == 0 {
+		return rout.MissingField("environments, buckets, or relationships")
+	}
+
+	normalize(r.Environments)
+	normalize(r.Buckets)
+	normalize(r.Relationships)
+
+	v := &fieldValidator{}
+	v.children("environments", r.Environments, nil)
+	v.children("buckets", r.Buckets, nil)
+	v.children("relationships", r.Relationships, ReservedRelationshipNames)
+
+	return v.err()
+}
+
 // ExampleOrganizationSuccessRequest is an example of a successful organization request for OpenAPI documentation
 var ExampleOrganizationSuccessRequest = OrganizationRequest{
 	Name: "MITB Inc.",
@@ -82,6 +474,173 @@ var ExampleOrganizationSuccessRequest = OrganizationRequest{
 // ExampleOrganizationSuccessResponse is an example of a successful organization response for OpenAPI documentation
 var ExampleOrganizationSuccessResponse = OrganizationReply{
 	Reply: rout.Reply{Success: true},
-	ID:    "1234",
-	Name:  "MITB Inc.",
+	OrgDetails: OrgDetails{
+		ID:   "1234",
+		Name: "MITB Inc.",
+		Slug: "mitb inc.",
+	},
+	Template: "default@1",
+	Environments: []Environment{
+		{
+			OrgDetails: OrgDetails{ID: "5678", Name: "production", Slug: "mitb inc..production", Tags: []string{"production"}, ParentID: "1234"},
+		},
+	},
+}
+
+// ExampleOrganizationPlanResponse is an example of a dry run organization response for OpenAPI documentation
+var ExampleOrganizationPlanResponse = OrganizationPlanReply{
+	Reply:    rout.Reply{Success: true},
+	DryRun:   true,
+	Template: "retail@1",
+	Total:    3,
+	Organization: PlannedOrg{
+		Name:        "mitb inc.",
+		DisplayName: "MITB Inc.",
+		Children: []PlannedOrg{
+			{
+				Name:        "mitb inc..stores",
+				DisplayName: "stores",
+				Parent:      "mitb inc.",
+				Tags:        []string{"stores"},
+				Children: []PlannedOrg{
+					{Name: "mitb inc..stores.east", DisplayName: "east", Parent: "mitb inc..stores", Tags: []string{"stores", "east"}},
+				},
+			},
+		},
+	},
+}
+
+// ExampleBulkOrganizationRequest is an example of a bulk organization request for OpenAPI documentation
+var ExampleBulkOrganizationRequest = BulkOrganizationRequest{
+	{Name: "MITB Inc."},
+	{Name: "Meow Corp", Template: "retail"},
+}
+
+// ExampleBulkOrganizationResponse is an example of a bulk organization response for OpenAPI documentation
+var ExampleBulkOrganizationResponse = BulkOrganizationReply{
+	Reply:     rout.Reply{Success: false},
+	Total:     2,
+	Succeeded: 1,
+	Failed:    1,
+	Results: []BulkOrganizationResult{
+		{
+			Reply:        rout.Reply{Success: true},
+			Index:        0,
+			Name:         "MITB Inc.",
+			Status:       http.StatusOK,
+			Organization: &ExampleOrganizationSuccessResponse,
+		},
+		{
+			Reply:  rout.Reply{Success: false, Error: "organization already exists", ErrorCode: "ALREADY_EXISTS"},
+			Index:  1,
+			Name:   "Meow Corp",
+			Status: http.StatusConflict,
+		},
+	},
+}
+
+// ExampleOrganizationUpdateRequest is an example of a successful organization update request for OpenAPI documentation
+var ExampleOrganizationUpdateRequest = OrganizationUpdateRequest{
+	Environments: []string{"staging"},
+}
+
+// ExampleOrganizationUpdateResponse is an example of a successful organization update response for OpenAPI documentation
+var ExampleOrganizationUpdateResponse = OrganizationUpdateReply{
+	OrganizationReply: ExampleOrganizationSuccessResponse,
+	Created: []OrgDetails{
+		{ID: "5678", Name: "staging"},
+	},
+}
+
+// ExampleOrganizationReconcileRequest is an example of a successful organization reconcile request for OpenAPI documentation
+var ExampleOrganizationReconcileRequest = OrganizationReconcileRequest{
+	Template: "default",
+	Repair:   true,
+}
+
+// ExampleOrganizationReconcileResponse is an example of a successful organization reconcile response for OpenAPI documentation
+var ExampleOrganizationReconcileResponse = OrganizationReconcileReply{
+	Reply:    rout.Reply{Success: true},
+	Template: "default@1",
+	Missing: []DriftNode{
+		{Path: "testing.sales"},
+	},
+	Renamed: []RenamedNode{
+		{ID: "5678", Path: "production", Name: "prod", ExpectedName: "production"},
+	},
+	Repaired: true,
+	Created: []OrgDetails{
+		{ID: "9012", Name: "sales"},
+	},
+}
+
+// ExampleOrganizationDeleteResponse is an example of a successful organization delete response for OpenAPI documentation
+var ExampleOrganizationDeleteResponse = OrganizationDeleteReply{
+	Reply: rout.Reply{Success: true},
+	Organizations: []OrgDetails{
+		{ID: "5678", Name: "production"},
+		{ID: "1234", Name: "MITB Inc."},
+	},
+}
+
+// ExampleOrganizationDeleteFailureResponse is an example of a partially failed organization delete response for OpenAPI documentation
+var ExampleOrganizationDeleteFailureResponse = OrganizationDeleteReply{
+	Reply: rout.Reply{Success: false, Error: "failed to delete organization: 1 organization(s) could not be deleted"},
+	Organizations: []OrgDetails{
+		{ID: "5678", Name: "production"},
+	},
+	Failed: []OrgDetails{
+		{ID: "9012", Name: "testing"},
+	},
+}
+
+// ExampleOrganizationListResponse is an example of a successful organization list response for OpenAPI documentation
+var ExampleOrganizationListResponse = OrganizationListReply{
+	Reply: rout.Reply{Success: true},
+	Organizations: []OrgDetails{
+		{ID: "1234", Name: "MITB Inc.", Slug: "mitb inc."},
+		{ID: "5678", Name: "Meow Corp", Slug: "meow corp"},
+	},
+	NextCursor: "5678",
+}
+
+// ExampleProvisioningFailureResponse is an example of a failed organization provisioning response for OpenAPI documentation
+var ExampleProvisioningFailureResponse = ProvisioningFailureReply{
+	Reply: rout.Reply{Success: false, Error: "organization already exists"},
+	Created: []OrgDetails{
+		{ID: "1234", Name: "MITB Inc."},
+		{ID: "5678", Name: "production"},
+	},
+	RolledBack: []OrgDetails{
+		{ID: "5678", Name: "production"},
+		{ID: "1234", Name: "MITB Inc."},
+	},
+}
+
+// ExampleValidationFailureResponse is an example of a response to a request with invalid fields for OpenAPI documentation
+var ExampleValidationFailureResponse = ValidationFailureReply{
+	Reply: rout.Reply{
+		Success:   false,
+		Error:     `invalid input: domains[0]: "meow" is not a valid domain; environments[1]: "production" is listed more than once`,
+		ErrorCode: "INVALID_INPUT",
+	},
+	Fields: []FieldError{
+		{Field: "domains[0]", Message: `"meow" is not a valid domain`},
+		{Field: "environments[1]", Message: `"production" is listed more than once`},
+	},
+}
+
+// ExampleProvisioningJobResponse is an example of an asynchronous provisioning job response for OpenAPI documentation
+var ExampleProvisioningJobResponse = ProvisioningJobReply{
+	Reply: rout.Reply{Success: true},
+	Job: ProvisioningJob{
+		ID:        "01J4EXD5MM60CX4YNFN0DF9VHQ",
+		Status:    JobStatusRunning,
+		Total:     23,
+		Completed: 2,
+		Nodes: []JobNode{
+			{OrgDetails: OrgDetails{ID: "1234", Name: "MITB Inc."}, Status: NodeStatusCreated},
+			{OrgDetails: OrgDetails{ID: "5678", Name: "production"}, Status: NodeStatusCreated},
+		},
+	},
 }
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/theopenlane/core/pkg/enums"
+	openlanemodels "github.com/theopenlane/core/pkg/models"
+)
+
+// InheritableSettings are the names of the settings that can be inherited by the organizations underneath the root
+var InheritableSettings = []string{
+	"allowedEmailDomains",
+	"avatarURL",
+	"billingAddress",
+	"billingContact",
+	"billingEmail",
+	"billingNotificationsEnabled",
+	"billingPhone",
+	"geoLocation",
+	"taxIdentifier",
+	"tags",
+}
+
+// OrganizationSettings are the settings of the root organization created by a request; the settings named in Inherit
+// are also applied to the environments and buckets underneath it
+type OrganizationSettings struct {
+	// AllowedEmailDomains restricts the email domains of users that can be added to the organization
+	AllowedEmailDomains []string `json:"allowedEmailDomains,omitempty"`
+	// AvatarURL is the url of the avatar shown for the organization
+	AvatarURL string `json:"avatarURL,omitempty"`
+	// BillingAddress is the address billing information is sent to
+	BillingAddress *openlanemodels.Address `json:"billingAddress,omitempty"`
+	// BillingContact is the name of the billing contact for the organization
+	BillingContact string `json:"billingContact,omitempty"`
+	// BillingEmail is the email address of the billing contact for the organization
+	BillingEmail string `json:"billingEmail,omitempty"`
+	// BillingNotificationsEnabled turns email notifications related to billing on or off
+	BillingNotificationsEnabled *bool `json:"billingNotificationsEnabled,omitempty"`
+	// BillingPhone is the phone number of the billing contact for the organization
+	BillingPhone string `json:"billingPhone,omitempty"`
+	// GeoLocation is the region of the organization: AMER, EMEA, or APAC
+	GeoLocation string `json:"geoLocation,omitempty"`
+	// TaxIdentifier is the government-issued tax or business id of the organization
+	TaxIdentifier string `json:"taxIdentifier,omitempty"`
+	// Tags added to the organization settings
+	Tags []string `json:"tags,omitempty"`
+	// Inherit contains the names of the settings that are also applied to the environments and buckets, or the first
+	// two levels of a template, unless the template sets its own value
+	Inherit []string `json:"inherit,omitempty"`
+}
+
+// Inherited returns a copy of the settings containing only the settings named in Inherit
+func (s *OrganizationSettings) Inherited() OrganizationSettings {
+	out := OrganizationSettings{}
+	if s == nil {
+		return out
+	}
+
+	for _, name := range s.Inherit {
+		switch name {
+		case "allowedEmailDomains":
+			out.AllowedEmailDomains = s.AllowedEmailDomains
+		case "avatarURL":
+			out.AvatarURL = s.AvatarURL
+		case "billingAddress":
+			out.BillingAddress = s.BillingAddress
+		case "billingContact":
+			out.BillingContact = s.BillingContact
+		case "billingEmail":
+			out.BillingEmail = s.BillingEmail
+		case "billingNotificationsEnabled":
+			out.BillingNotificationsEnabled = s.BillingNotificationsEnabled
+		case "billingPhone":
+			out.BillingPhone = s.BillingPhone
+		case "geoLocation":
+			out.GeoLocation = s.GeoLocation
+		case "taxIdentifier":
+			out.TaxIdentifier = s.TaxIdentifier
+		case "tags":
+			out.Tags = s.Tags
+		}
+	}
+
+	return out
+}
+
+// normalize trims the settings and lowercases the allowed email domains, the region is uppercased
+func (s *OrganizationSettings) normalize() {
+	if s == nil {
+		return
+	}
+
+	normalizeDomains(s.AllowedEmailDomains)
+	normalize(s.Tags)
+	normalize(s.Inherit)
+
+	s.AvatarURL = strings.TrimSpace(s.AvatarURL)
+	s.BillingContact = strings.TrimSpace(s.BillingContact)
+	s.BillingEmail = strings.TrimSpace(s.BillingEmail)
+	s.BillingPhone = strings.TrimSpace(s.BillingPhone)
+	s.GeoLocation = strings.ToUpper(strings.TrimSpace(s.GeoLocation))
+	s.TaxIdentifier = strings.TrimSpace(s.TaxIdentifier)
+}
+
+// settings validates the organization settings
+func (v *fieldValidator) settings(s *OrganizationSettings) {
+	if s == nil {
+		return
+	}
+
+	v.domains("settings.allowedEmailDomains", s.AllowedEmailDomains)
+
+	if s.BillingEmail != "" {
+		if _, err := mail.ParseAddress(s.BillingEmail); err != nil {
+			v.add("settings.billingEmail", "%q is not a valid email address", s.BillingEmail)
+		}
+	}
+
+	if s.AvatarURL != "" {
+		if u, err := url.Parse(s.AvatarURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.add("settings.avatarURL", "%q is not a valid http or https url", s.AvatarURL)
+		}
+	}
+
+	if s.GeoLocation != "" && !slices.Contains(enums.Region("").Values(), s.GeoLocation) {
+		v.add("settings.geoLocation", "%q must be one of %s", s.GeoLocation, strings.Join(enums.Region("").Values(), ", "))
+	}
+
+	for i, name := range s.Inherit {
+		if !slices.Contains(InheritableSettings, name) {
+			v.add(fmt.Sprintf("settings.inherit[%d]", i), "%q is not a setting that can be inherited", name)
+		}
+	}
+}
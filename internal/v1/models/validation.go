@@ -0,0 +1,242 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/theopenlane/utils/rout"
+)
+
+const (
+	// MinNameLength is the minimum length of the name of a new organization
+	MinNameLength = 3
+	// MaxNameLength is the maximum length of the name of an organization or of an environment, bucket, or relationship
+	MaxNameLength = 64
+	// MaxPathLength is the maximum length of the unique openlane name of an organization, which is made up of the
+	// names of the organization and all of its ancestors joined by '.'
+	MaxPathLength = 160
+	// MaxDomainLength is the maximum length of a domain
+	MaxDomainLength = 255
+	// MaxOrganizations is the maximum number of organizations that can be created underneath the root organization
+	// by a single request
+	MaxOrganizations = 500
+
+	// relationshipsBucket is the bucket that contains the relationships in the default hierarchy
+	relationshipsBucket = "relationships"
+)
+
+var (
+	// ReservedRelationshipNames cannot be used for relationships because the organization would have the same
+	// tags as the bucket containing it and could not be told apart when the hierarchy is loaded
+	ReservedRelationshipNames = []string{relationshipsBucket}
+
+	// childNameRegexp matches the allowed names of environments, buckets, and relationships; dots are not allowed
+	// because they separate the names of the ancestors in the unique name of each organization
+	childNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+	// domainRegexp matches a domain made up of at least two labels
+	domainRegexp = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+)
+
+// rootNameInvalidChars are the characters openlane does not allow in organization names
+const rootNameInvalidChars = "!@#$%^*()+{}|:\"<>?`=[]\\;'/~"
+
+// FieldError describes why a single field of a request is invalid
+type FieldError struct {
+	// Field is the name of the field, list entries include their index e.g. domains[1]; requests that do not match the
+	// OpenAPI specification use the JSON pointer of the value in the request instead, e.g. /body/domains/1
+	Field string `json:"field"`
+	// Message describes why the field is invalid
+	Message string `json:"message"`
+}
+
+// ValidationFailureReply is the response object returned when a request has invalid fields
+type ValidationFailureReply struct {
+	rout.Reply
+	// Fields contains every invalid field in the request
+	Fields []FieldError `json:"fields"`
+}
+
+// ValidationError is returned when one or more fields of a request are invalid
+type ValidationError struct {
+	// Fields contains every invalid field in the request
+	Fields []FieldError
+}
+
+// Error returns the ValidationError in string format
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+
+	return "invalid input: " + strings.Join(msgs, "; ")
+}
+
+// Reply returns the response object for the invalid request
+func (e *ValidationError) Reply(code rout.ErrorCode) ValidationFailureReply {
+	return ValidationFailureReply{
+		Reply:  rout.ErrorResponseWithCode(e, code),
+		Fields: e.Fields,
+	}
+}
+
+// fieldValidator collects the errors for every invalid field in a request
+type fieldValidator struct {
+	fields []FieldError
+}
+
+// add records an invalid field
+func (v *fieldValidator) add(field, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns a ValidationError when any field is invalid
+func (v *fieldValidator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: v.fields}
+}
+
+// name validates the name of a new root organization; a dot followed by more of the name is not allowed because dots
+// separate the names in the unique name of each organization, so "acme.production" would have the same unique name as
+// the production environment of "acme". A trailing dot, e.g. "MITB Inc.", cannot be mistaken for a child
+func (v *fieldValidator) name(name string) {
+	switch {
+	case name == "":
+		v.add("name", "is required")
+	case len(name) < MinNameLength || len(name) > MaxNameLength:
+		v.add("name", "must be between %d and %d characters", MinNameLength, MaxNameLength)
+	case strings.ContainsAny(name, rootNameInvalidChars):
+		v.add("name", "cannot contain special characters")
+	case strings.Contains(strings.TrimSuffix(name, "."), "."):
+		v.add("name", "can only contain '.' at the end")
+	}
+}
+
+// domains validates the domains in the list and ensures each is only listed once
+func (v *fieldValidator) domains(field string, domains []string) {
+	seen := make(map[string]struct{}, len(domains))
+
+	for i, d := range domains {
+		field := fmt.Sprintf("%s[%d]", field, i)
+
+		if len(d) > MaxDomainLength || !domainRegexp.MatchString(d) {
+			v.add(field, "%q is not a valid domain", d)
+
+			continue
+		}
+
+		if _, ok := seen[d]; ok {
+			v.add(field, "%q is listed more than once", d)
+		}
+
+		seen[d] = struct{}{}
+	}
+}
+
+// children validates the names of the environments, buckets, or relationships in the list and ensures each is only
+// listed once; names are compared without case because they are lowercased in the unique organization name
+func (v *fieldValidator) children(field string, names []string, reserved []string) {
+	seen := make(map[string]struct{}, len(names))
+
+	for i, n := range names {
+		f := fmt.Sprintf("%s[%d]", field, i)
+
+		switch {
+		case n == "":
+			v.add(f, "is required")
+
+			continue
+		case len(n) > MaxNameLength:
+			v.add(f, "must be at most %d characters", MaxNameLength)
+
+			continue
+		case !childNameRegexp.MatchString(n):
+			v.add(f, "%q can only contain letters, numbers, '-' and '_' and must start with a letter or number", n)
+
+			continue
+		case slices.Contains(reserved, strings.ToLower(n)):
+			v.add(f, "%q is a reserved name", n)
+
+			continue
+		}
+
+		key := strings.ToLower(n)
+		if _, ok := seen[key]; ok {
+			v.add(f, "%q is listed more than once", n)
+		}
+
+		seen[key] = struct{}{}
+	}
+}
+
+// hierarchy validates the environments, buckets, and relationships of the default hierarchy, the length of the longest
+// unique organization name underneath the root, and the number of organizations that would be created
+func (v *fieldValidator) hierarchy(root string, environments, buckets, relationships []string) {
+	v.children("environments", environments, nil)
+	v.children("buckets", buckets, nil)
+	v.children("relationships", relationships, ReservedRelationshipNames)
+
+	if root != "" {
+		path := []string{root, longest(environments), longest(buckets)}
+		if slices.ContainsFunc(buckets, isRelationshipsBucket) {
+			path = append(path, longest(relationships))
+		}
+
+		if l := len(strings.Join(path, ".")); l > MaxPathLength {
+			v.add("name", "the organization names in the hierarchy would be %d characters, the maximum is %d", l, MaxPathLength)
+		}
+	}
+
+	if count := StandardCount(environments, buckets, relationships); count > MaxOrganizations {
+		v.add("environments", "the hierarchy would contain %d organizations, the maximum is %d", count, MaxOrganizations)
+	}
+}
+
+// StandardCount returns the number of organizations in the default hierarchy underneath the root organization
+func StandardCount(environments, buckets, relationships []string) int {
+	perEnvironment := 1 + len(buckets)
+	if slices.ContainsFunc(buckets, isRelationshipsBucket) {
+		perEnvironment += len(relationships)
+	}
+
+	return len(environments) * perEnvironment
+}
+
+// isRelationshipsBucket reports whether the bucket contains the relationships, the name is matched case insensitively
+// like the buckets of the standard blueprint
+func isRelationshipsBucket(bucket string) bool {
+	return strings.EqualFold(bucket, relationshipsBucket)
+}
+
+// longest returns the longest name in the list
+func longest(names []string) string {
+	out := ""
+
+	for _, n := range names {
+		if len(n) > len(out) {
+			out = n
+		}
+	}
+
+	return out
+}
+
+// normalize trims surrounding whitespace from each entry in the list
+func normalize(values []string) {
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+}
+
+// normalizeDomains trims surrounding whitespace and lowercases each domain
+func normalizeDomains(domains []string) {
+	for i := range domains {
+		domains[i] = strings.ToLower(strings.TrimSpace(domains[i]))
+	}
+}
@@ -0,0 +1,240 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/theopenlane/openlane-cloud/internal/v1/models"
+)
+
+func TestOrganizationRequestValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		request    models.OrganizationRequest
+		wantFields []string
+	}{
+		{
+			name:    "valid",
+			request: models.OrganizationRequest{Name: "MITB Inc.", Domains: []string{"meow.com"}},
+		},
+		{
+			name:    "valid hierarchy",
+			request: models.OrganizationRequest{Name: "meow", Environments: []string{"production", "staging-2"}, Relationships: []string{"vendors"}},
+		},
+		{
+			name:       "missing name",
+			request:    models.OrganizationRequest{},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "name too short",
+			request:    models.OrganizationRequest{Name: "me"},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "name with special characters",
+			request:    models.OrganizationRequest{Name: "meow/cats"},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "name with a dot separating a child name",
+			request:    models.OrganizationRequest{Name: "acme.production"},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "name with a dot separating a child name and a trailing dot",
+			request:    models.OrganizationRequest{Name: "MITB Inc..production."},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "invalid and duplicate domains",
+			request:    models.OrganizationRequest{Name: "meow", Domains: []string{"meow", "meow.com", "MEOW.com", "https://meow.com"}},
+			wantFields: []string{"domains[0]", "domains[2]", "domains[3]"},
+		},
+		{
+			name:       "invalid child names",
+			request:    models.OrganizationRequest{Name: "meow", Environments: []string{"prod.east", "", "Production", "production"}, Buckets: []string{"assets", "with space"}},
+			wantFields: []string{"environments[0]", "environments[1]", "environments[3]", "buckets[1]"},
+		},
+		{
+			name:       "reserved relationship",
+			request:    models.OrganizationRequest{Name: "meow", Relationships: []string{"vendors", "relationships"}},
+			wantFields: []string{"relationships[1]"},
+		},
+		{
+			name: "names too long",
+			request: models.OrganizationRequest{
+				Name:          strings.Repeat("m", models.MaxNameLength),
+				Environments:  []string{strings.Repeat("e", models.MaxNameLength)},
+				Buckets:       []string{"relationships"},
+				Relationships: []string{strings.Repeat("r", models.MaxNameLength+1)},
+			},
+			wantFields: []string{"relationships[0]", "name"},
+		},
+		{
+			name: "too many organizations",
+			request: models.OrganizationRequest{
+				Name:          "meow",
+				Environments:  names("env", 10),
+				Buckets:       append(names("bucket", 9), "relationships"),
+				Relationships: names("rel", 50),
+			},
+			wantFields: []string{"environments"},
+		},
+		{
+			name: "too many organizations with a mixed case relationships bucket",
+			request: models.OrganizationRequest{
+				Name:          "meow",
+				Environments:  names("env", 10),
+				Buckets:       append(names("bucket", 9), "Relationships"),
+				Relationships: names("rel", 50),
+			},
+			wantFields: []string{"environments"},
+		},
+		{
+			name: "names too long with a mixed case relationships bucket",
+			request: models.OrganizationRequest{
+				Name:          strings.Repeat("m", models.MaxNameLength),
+				Environments:  []string{strings.Repeat("e", models.MaxNameLength)},
+				Buckets:       []string{"RELATIONSHIPS"},
+				Relationships: []string{strings.Repeat("r", models.MaxNameLength)},
+			},
+			wantFields: []string{"name"},
+		},
+		{
+			name: "valid settings",
+			request: models.OrganizationRequest{Name: "meow", Settings: &models.OrganizationSettings{
+				AllowedEmailDomains: []string{"meow.com"},
+				AvatarURL:           "https://meow.com/avatar.png",
+				BillingEmail:        "Meow <billing@meow.com>",
+				GeoLocation:         "amer",
+				Inherit:             []string{"billingEmail", "geoLocation"},
+			}},
+		},
+		{
+			name: "invalid settings",
+			request: models.OrganizationRequest{Name: "meow", Settings: &models.OrganizationSettings{
+				AllowedEmailDomains: []string{"meow.com", "meow"},
+				AvatarURL:           "meow.com/avatar.png",
+				BillingEmail:        "billing",
+				GeoLocation:         "mars",
+				Inherit:             []string{"tags", "domains"},
+			}},
+			wantFields: []string{"settings.allowedEmailDomains[1]", "settings.billingEmail", "settings.avatarURL", "settings.geoLocation", "settings.inherit[1]"},
+		},
+		{
+			name: "invalid invites",
+			request: models.OrganizationRequest{Name: "meow", Invites: []models.OrganizationInvite{
+				{Email: "Cat@meow.com"}, {Email: "cat@meow.com", Role: "admin"}, {Email: "Cat <dog@meow.com>"}, {Email: "dog@meow.com", Role: "owner"},
+			}},
+			wantFields: []string{"invites[1].email", "invites[2].email", "invites[3].role"},
+		},
+		{
+			name: "invalid groups",
+			request: models.OrganizationRequest{Name: "meow", Groups: []models.OrganizationGroup{
+				{Name: "engineering", Environments: []string{"prod.east"}, Members: []models.GroupMember{{UserID: "1"}, {UserID: "1", Role: "user"}}},
+				{Name: "Engineering"},
+				{Members: []models.GroupMember{{}}},
+			}},
+			wantFields: []string{
+				"groups[0].environments[0]", "groups[0].members[1].role", "groups[0].members[1].userId",
+				"groups[1].name", "groups[2].name", "groups[2].members[0].userId",
+			},
+		},
+		{
+			name:    "template skips the default hierarchy",
+			request: models.OrganizationRequest{Name: "meow", Template: "retail"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.Validate()
+			if len(tc.wantFields) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var verr *models.ValidationError
+			require.ErrorAs(t, err, &verr)
+
+			fields := []string{}
+			for _, f := range verr.Fields {
+				fields = append(fields, f.Field)
+			}
+
+			assert.Equal(t, tc.wantFields, fields)
+		})
+	}
+}
+
+func TestOrganizationRequestValidateNormalizes(t *testing.T) {
+	in := models.OrganizationRequest{
+		Name:         "  meow  ",
+		Domains:      []string{" Meow.COM "},
+		Environments: []string{" production "},
+	}
+
+	require.NoError(t, in.Validate())
+
+	assert.Equal(t, "meow", in.Name)
+	assert.Equal(t, []string{"meow.com"}, in.Domains)
+	assert.Equal(t, []string{"production"}, in.Environments)
+}
+
+func TestOrganizationUpdateRequestValidate(t *testing.T) {
+	in := models.OrganizationUpdateRequest{Environments: []string{"staging", "Staging"}, Relationships: []string{"relationships"}}
+
+	var verr *models.ValidationError
+	require.ErrorAs(t, in.Validate(), &verr)
+	assert.Len(t, verr.Fields, 2)
+}
+
+func TestOrganizationReconcileRequestValidate(t *testing.T) {
+	// the expected hierarchy is required to prune
+	in := models.OrganizationReconcileRequest{Repair: true, Prune: true}
+	require.Error(t, in.Validate())
+
+	in = models.OrganizationReconcileRequest{Repair: true, Prune: true, Template: "retail"}
+	require.NoError(t, in.Validate())
+
+	in = models.OrganizationReconcileRequest{Repair: true, Prune: true, Environments: []string{"production"}}
+	require.NoError(t, in.Validate())
+
+	in = models.OrganizationReconcileRequest{Repair: true}
+	require.NoError(t, in.Validate())
+}
+
+// names returns n names with the prefix
+func names(prefix string, n int) []string {
+	out := make([]string, 0, n)
+	for i := range n {
+		out = append(out, prefix+strings.Repeat("x", i))
+	}
+
+	return out
+}
+
+func TestOrganizationSettingsInherited(t *testing.T) {
+	enabled := true
+
+	s := &models.OrganizationSettings{
+		BillingContact:              "Meow",
+		BillingEmail:                "billing@meow.com",
+		BillingNotificationsEnabled: &enabled,
+		Tags:                        []string{"customer"},
+		Inherit:                     []string{"billingEmail", "billingNotificationsEnabled"},
+	}
+
+	assert.Equal(t, models.OrganizationSettings{
+		BillingEmail:                "billing@meow.com",
+		BillingNotificationsEnabled: &enabled,
+	}, s.Inherited())
+
+	var none *models.OrganizationSettings
+	assert.Equal(t, models.OrganizationSettings{}, none.Inherited())
+}
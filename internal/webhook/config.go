@@ -0,0 +1,27 @@
+package webhook
+
+import "time"
+
+// Config is the configuration for outbound webhooks
+type Config struct {
+	// Enabled turns on webhook notifications for organization lifecycle events
+	Enabled bool `json:"enabled" koanf:"enabled" default:"false"`
+	// URLs are the endpoints every event is delivered to
+	URLs []string `json:"urls" koanf:"urls"`
+	// Secret is the key used to sign the payload of every webhook with HMAC-SHA256
+	Secret string `json:"secret" koanf:"secret"`
+	// Timeout is the maximum duration of a single delivery attempt
+	Timeout time.Duration `json:"timeout" koanf:"timeout" default:"10s"`
+	// MaxAttempts is the number of times delivery is attempted before the event is written to the dead letter log
+	MaxAttempts int `json:"max_attempts" koanf:"max_attempts" default:"5"`
+	// Backoff is the delay before the first retry, it is doubled after every failed attempt
+	Backoff time.Duration `json:"backoff" koanf:"backoff" default:"1s"`
+	// MaxBackoff is the maximum delay between retries
+	MaxBackoff time.Duration `json:"max_backoff" koanf:"max_backoff" default:"1m"`
+	// Workers is the number of background workers delivering webhooks
+	Workers int `json:"workers" koanf:"workers" default:"2"`
+	// QueueSize is the maximum number of deliveries waiting for a worker
+	QueueSize int `json:"queue_size" koanf:"queue_size" default:"100"`
+	// DeadLetterFile is the file events that could not be delivered are appended to
+	DeadLetterFile string `json:"dead_letter_file" koanf:"dead_letter_file" default:"webhooks-dead-letter.jsonl"`
+}
@@ -0,0 +1,298 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// EventOrganizationCreated is sent when an organization hierarchy has been created
+	EventOrganizationCreated = "organization.created"
+	// EventOrganizationUpdated is sent when organizations have been added to an existing hierarchy
+	EventOrganizationUpdated = "organization.updated"
+	// EventOrganizationDeleted is sent when an organization hierarchy has been deleted
+	EventOrganizationDeleted = "organization.deleted"
+)
+
+// deadLetterPerm is the file mode of the dead letter log, which contains the payload of every undelivered event
+const deadLetterPerm = 0600
+
+// Event is the body of every webhook request
+type Event struct {
+	// ID is the unique id of the event
+	ID string `json:"id"`
+	// Type is the type of the event, e.g. organization.created
+	Type string `json:"type"`
+	// CreatedAt is the time the event occurred
+	CreatedAt time.Time `json:"createdAt"`
+	// Data is the payload of the event
+	Data any `json:"data"`
+}
+
+// DeadLetter is an entry in the dead letter log for an event that could not be delivered to a url
+type DeadLetter struct {
+	// URL is the webhook url the event could not be delivered to
+	URL string `json:"url"`
+	// Attempts is the number of delivery attempts made
+	Attempts int `json:"attempts"`
+	// Error is the error returned by the last attempt
+	Error string `json:"error"`
+	// FailedAt is the time the event was given up on
+	FailedAt time.Time `json:"failedAt"`
+	// Event is the event that could not be delivered
+	Event json.RawMessage `json:"event"`
+}
+
+// delivery is a single event to be delivered to a single url
+type delivery struct {
+	id        string
+	eventType string
+	url       string
+	body      []byte
+}
+
+// Dispatcher delivers events to the configured urls on a bounded pool of background workers, retrying failed deliveries
+// with exponential backoff and writing events that could not be delivered to the dead letter log
+type Dispatcher struct {
+	config Config
+	client *http.Client
+	queue  chan delivery
+	wg     sync.WaitGroup
+	// mu serializes writes to the dead letter log
+	mu sync.Mutex
+	// closeMu guards closed so no event is queued once the queue has been closed
+	closeMu sync.RWMutex
+	closed  bool
+}
+
+// New validates the config, creates the dispatcher, and starts the workers
+func New(c Config) (*Dispatcher, error) {
+	if len(c.URLs) == 0 {
+		return nil, ErrMissingURLs
+	}
+
+	if c.Secret == "" {
+		return nil, ErrMissingSecret
+	}
+
+	for _, u := range c.URLs {
+		parsed, err := url.Parse(u)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidURL, u)
+		}
+	}
+
+	d := &Dispatcher{
+		config: c,
+		client: &http.Client{Timeout: c.Timeout},
+		queue:  make(chan delivery, max(c.QueueSize, 1)),
+	}
+
+	for range max(c.Workers, 1) {
+		d.wg.Add(1)
+
+		go d.work()
+	}
+
+	return d, nil
+}
+
+// Send queues the event for delivery to every url; a delivery that cannot be queued is written to the dead letter log
+// and the deliveries to the other urls are still queued. The errors of every url that could not be queued are joined
+// and returned, matching ErrQueueFull, or ErrClosed once the dispatcher has been closed
+func (d *Dispatcher) Send(eventType string, data any) error {
+	event := Event{
+		ID:        ulid.Make().String(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	d.closeMu.RLock()
+	defer d.closeMu.RUnlock()
+
+	var errs []error
+
+	for _, u := range d.config.URLs {
+		del := delivery{id: event.ID, eventType: eventType, url: u, body: body}
+
+		if d.closed {
+			d.deadLetter(del, 0, ErrClosed)
+			errs = append(errs, fmt.Errorf("%w: %s", ErrClosed, u))
+
+			continue
+		}
+
+		select {
+		case d.queue <- del:
+		default:
+			d.deadLetter(del, 0, ErrQueueFull)
+			errs = append(errs, fmt.Errorf("%w: %s", ErrQueueFull, u))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close stops accepting events and waits for the queued deliveries, including their retries, to finish; if the context
+// is done first the context error is returned and the remaining deliveries continue in the background
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.closeMu.Lock()
+
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+
+	d.closeMu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work delivers events from the queue until the queue is closed
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	for del := range d.queue {
+		d.deliver(del)
+	}
+}
+
+// deliver attempts to deliver the event until it succeeds, the receiver rejects it, or the attempts are exhausted;
+// events that are not delivered are written to the dead letter log
+func (d *Dispatcher) deliver(del delivery) {
+	backoff := d.config.Backoff
+	attempts := max(d.config.MaxAttempts, 1)
+
+	var err error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var retry bool
+
+		retry, err = d.post(del)
+		if err == nil {
+			log.Debug().Str("event", del.id).Str("type", del.eventType).Str("url", del.url).Int("attempt", attempt).Msg("webhook delivered")
+
+			return
+		}
+
+		log.Warn().Err(err).Str("event", del.id).Str("url", del.url).Int("attempt", attempt).Msg("webhook delivery failed")
+
+		if !retry || attempt == attempts {
+			d.deadLetter(del, attempt, err)
+
+			return
+		}
+
+		time.Sleep(backoff)
+
+		backoff = min(backoff*2, max(d.config.MaxBackoff, d.config.Backoff)) //nolint:mnd
+	}
+}
+
+// post makes a single signed delivery attempt and reports whether a failure can be retried; network errors, timeouts,
+// rate limits, and server errors are retried while any other response rejects the event
+func (d *Dispatcher) post(del delivery) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, del.url, bytes.NewReader(del.body))
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, del.id)
+	req.Header.Set(EventHeader, del.eventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(d.config.Secret, del.id, now, del.body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+
+	defer resp.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= http.StatusInternalServerError ||
+		resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusRequestTimeout
+
+	return retry, fmt.Errorf("%w: %s responded with %d", ErrDeliveryFailed, del.url, resp.StatusCode)
+}
+
+// deadLetter appends the event that could not be delivered to the dead letter log
+func (d *Dispatcher) deadLetter(del delivery, attempts int, cause error) {
+	log.Error().Err(cause).Str("event", del.id).Str("type", del.eventType).Str("url", del.url).Msg("webhook could not be delivered")
+
+	if d.config.DeadLetterFile == "" {
+		return
+	}
+
+	entry, err := json.Marshal(DeadLetter{
+		URL:      del.url,
+		Attempts: attempts,
+		Error:    cause.Error(),
+		FailedAt: time.Now().UTC(),
+		Event:    del.body,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to encode webhook dead letter")
+
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	f, err := os.OpenFile(d.config.DeadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, deadLetterPerm)
+	if err != nil {
+		log.Error().Err(err).Str("file", d.config.DeadLetterFile).Msg("failed to open webhook dead letter log")
+
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(entry, '\n')); err != nil {
+		log.Error().Err(err).Str("file", d.config.DeadLetterFile).Msg("failed to write webhook dead letter")
+	}
+}
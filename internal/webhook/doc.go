@@ -0,0 +1,2 @@
+// Package webhook delivers signed notifications of provisioning lifecycle events to the configured urls
+package webhook
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"errors"
+)
+
+var (
+	// ErrMissingURLs is returned when webhooks are enabled without any urls
+	ErrMissingURLs = errors.New("at least one webhook url is required")
+
+	// ErrMissingSecret is returned when webhooks are enabled without a signing secret
+	ErrMissingSecret = errors.New("a webhook signing secret is required")
+
+	// ErrInvalidURL is returned when a configured webhook url is not an absolute http or https url
+	ErrInvalidURL = errors.New("invalid webhook url")
+
+	// ErrQueueFull is returned when an event is sent but no more deliveries can be queued
+	ErrQueueFull = errors.New("webhook delivery queue is full")
+
+	// ErrClosed is returned when an event is sent after the dispatcher has been closed
+	ErrClosed = errors.New("webhook dispatcher is closed")
+
+	// ErrDeliveryFailed is returned when the receiver responds with a status code other than 2xx
+	ErrDeliveryFailed = errors.New("webhook delivery failed")
+
+	// ErrMissingSignature is returned when a webhook request does not contain the signature headers
+	ErrMissingSignature = errors.New("webhook signature is missing")
+
+	// ErrInvalidSignature is returned when the signature of a webhook request does not match the payload
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+
+	// ErrSignatureExpired is returned when the timestamp of a webhook request is outside of the tolerance
+	ErrSignatureExpired = errors.New("webhook timestamp is outside of the tolerance")
+)
@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// IDHeader contains the unique id of the event, it is the same for every attempt so receivers can ignore duplicates
+	IDHeader = "Webhook-Id"
+	// TimestampHeader contains the unix time, in seconds, the request was signed
+	TimestampHeader = "Webhook-Timestamp"
+	// SignatureHeader contains the signature of the request prefixed with the version of the signing scheme
+	SignatureHeader = "Webhook-Signature"
+	// EventHeader contains the type of the event
+	EventHeader = "Webhook-Event"
+
+	// signatureVersion is the prefix of the signature for the current signing scheme
+	signatureVersion = "v1="
+
+	// DefaultTolerance is the maximum age of a signature accepted by Verify when no tolerance is given
+	DefaultTolerance = 5 * time.Minute
+)
+
+// Sign returns the signature of the payload for the event id and timestamp; the signature is the hex encoded
+// HMAC-SHA256 of the id, timestamp, and body joined by '.'
+func Sign(secret, id string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id + "." + strconv.FormatInt(timestamp.Unix(), 10) + "."))
+	mac.Write(body)
+
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify ensures the headers of a webhook request contain a valid signature of the body for the secret, and that it was
+// signed within the tolerance; the DefaultTolerance is used when the tolerance is zero
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	id := header.Get(IDHeader)
+	ts := header.Get(TimestampHeader)
+	signature := header.Get(SignatureHeader)
+
+	if id == "" || ts == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
+	seconds, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+
+	timestamp := time.Unix(seconds, 0)
+	if age := time.Since(timestamp); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, id, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
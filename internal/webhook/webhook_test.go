@@ -0,0 +1,287 @@
+package webhook_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/theopenlane/openlane-cloud/internal/webhook"
+)
+
+const testSecret = "meow-secret"
+
+// receiver records the webhooks it receives and responds with the next status code in the list, or 200 once the list
+// is exhausted
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	events   []webhook.Event
+	errs     []error
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errs = append(r.errs, webhook.Verify(testSecret, req.Header, body, 0))
+
+	var event webhook.Event
+	_ = json.Unmarshal(body, &event)
+	r.events = append(r.events, event)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+
+	w.WriteHeader(status)
+}
+
+func newDispatcher(t *testing.T, urls ...string) (*webhook.Dispatcher, string) {
+	t.Helper()
+
+	deadLetters := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+
+	d, err := webhook.New(webhook.Config{
+		URLs:           urls,
+		Secret:         testSecret,
+		Timeout:        time.Second,
+		MaxAttempts:    3,
+		Backoff:        time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Workers:        1,
+		QueueSize:      10,
+		DeadLetterFile: deadLetters,
+	})
+	require.NoError(t, err)
+
+	return d, deadLetters
+}
+
+func readDeadLetters(t *testing.T, path string) []webhook.DeadLetter {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	require.NoError(t, err)
+	defer f.Close()
+
+	out := []webhook.DeadLetter{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry webhook.DeadLetter
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+
+		out = append(out, entry)
+	}
+
+	return out
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	r := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	d, deadLetters := newDispatcher(t, srv.URL)
+
+	require.NoError(t, d.Send(webhook.EventOrganizationCreated, map[string]string{"name": "meow"}))
+	require.NoError(t, d.Close(context.Background()))
+
+	// the event is delivered on the third attempt with the same id and a valid signature every time
+	require.Len(t, r.events, 3)
+
+	for i, event := range r.events {
+		require.NoError(t, r.errs[i])
+		assert.Equal(t, r.events[0].ID, event.ID)
+		assert.Equal(t, webhook.EventOrganizationCreated, event.Type)
+		assert.Equal(t, map[string]any{"name": "meow"}, event.Data)
+	}
+
+	assert.Empty(t, readDeadLetters(t, deadLetters))
+}
+
+func TestDispatcherDeadLetter(t *testing.T) {
+	failing := &receiver{statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}}
+	failingSrv := httptest.NewServer(failing)
+	t.Cleanup(failingSrv.Close)
+
+	rejecting := &receiver{statuses: []int{http.StatusBadRequest}}
+	rejectingSrv := httptest.NewServer(rejecting)
+	t.Cleanup(rejectingSrv.Close)
+
+	ok := &receiver{}
+	okSrv := httptest.NewServer(ok)
+	t.Cleanup(okSrv.Close)
+
+	d, deadLetters := newDispatcher(t, failingSrv.URL, rejectingSrv.URL, okSrv.URL)
+
+	require.NoError(t, d.Send(webhook.EventOrganizationDeleted, map[string]string{"name": "meow"}))
+	require.NoError(t, d.Close(context.Background()))
+
+	// server errors are retried until the attempts are exhausted, other client errors are not retried
+	assert.Len(t, failing.events, 3)
+	assert.Len(t, rejecting.events, 1)
+	assert.Len(t, ok.events, 1)
+
+	entries := readDeadLetters(t, deadLetters)
+	require.Len(t, entries, 2)
+
+	byURL := map[string]webhook.DeadLetter{}
+	for _, e := range entries {
+		byURL[e.URL] = e
+	}
+
+	assert.Equal(t, 3, byURL[failingSrv.URL].Attempts)
+	assert.Equal(t, 1, byURL[rejectingSrv.URL].Attempts)
+	assert.Contains(t, byURL[rejectingSrv.URL].Error, "400")
+
+	var event webhook.Event
+	require.NoError(t, json.Unmarshal(byURL[failingSrv.URL].Event, &event))
+	assert.Equal(t, ok.events[0].ID, event.ID)
+	assert.Equal(t, webhook.EventOrganizationDeleted, event.Type)
+}
+
+func TestDispatcherClose(t *testing.T) {
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	d, deadLetters := newDispatcher(t, srv.URL)
+
+	require.NoError(t, d.Send(webhook.EventOrganizationCreated, map[string]string{"name": "meow"}))
+
+	// the delivery is still in progress when the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, d.Close(ctx), context.DeadlineExceeded)
+
+	// events sent once the dispatcher is closed are not queued
+	require.ErrorIs(t, d.Send(webhook.EventOrganizationDeleted, map[string]string{"name": "meow"}), webhook.ErrClosed)
+
+	close(release)
+	require.NoError(t, d.Close(context.Background()))
+
+	entries := readDeadLetters(t, deadLetters)
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].Error, webhook.ErrClosed.Error())
+}
+
+func TestDispatcherQueueFull(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	blocking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(blocking.Close)
+
+	ok := &receiver{}
+	okSrv := httptest.NewServer(ok)
+	t.Cleanup(okSrv.Close)
+
+	deadLetters := filepath.Join(t.TempDir(), "dead-letter.jsonl")
+
+	d, err := webhook.New(webhook.Config{
+		URLs:           []string{blocking.URL, okSrv.URL},
+		Secret:         testSecret,
+		Timeout:        time.Second,
+		MaxAttempts:    1,
+		Workers:        1,
+		QueueSize:      1,
+		DeadLetterFile: deadLetters,
+	})
+	require.NoError(t, err)
+
+	// the only worker is delivering the first event and the queue is full once the second event has been sent, the
+	// first event may not fit either so the errors of both are ignored
+	_ = d.Send(webhook.EventOrganizationCreated, map[string]string{"name": "first"})
+	<-started
+	_ = d.Send(webhook.EventOrganizationCreated, map[string]string{"name": "second"})
+
+	// the delivery to the first url cannot be queued, the delivery to the second url is still dead lettered
+	err = d.Send(webhook.EventOrganizationDeleted, map[string]string{"name": "third"})
+	require.ErrorIs(t, err, webhook.ErrQueueFull)
+	assert.Contains(t, err.Error(), blocking.URL)
+	assert.Contains(t, err.Error(), okSrv.URL)
+
+	close(release)
+	require.NoError(t, d.Close(context.Background()))
+
+	urls := []string{}
+
+	for _, entry := range readDeadLetters(t, deadLetters) {
+		var event webhook.Event
+		require.NoError(t, json.Unmarshal(entry.Event, &event))
+
+		if event.Data.(map[string]any)["name"] == "third" {
+			assert.Contains(t, entry.Error, webhook.ErrQueueFull.Error())
+
+			urls = append(urls, entry.URL)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{blocking.URL, okSrv.URL}, urls)
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+
+	header := func(secret string, ts time.Time) http.Header {
+		h := http.Header{}
+		h.Set(webhook.IDHeader, "1")
+		h.Set(webhook.TimestampHeader, strconv.FormatInt(ts.Unix(), 10))
+		h.Set(webhook.SignatureHeader, webhook.Sign(secret, "1", ts, body))
+
+		return h
+	}
+
+	require.NoError(t, webhook.Verify(testSecret, header(testSecret, now), body, 0))
+
+	assert.ErrorIs(t, webhook.Verify(testSecret, header("wrong", now), body, 0), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify(testSecret, header(testSecret, now), []byte(`{"id":"2"}`), 0), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify(testSecret, header(testSecret, now.Add(-time.Hour)), body, 0), webhook.ErrSignatureExpired)
+	assert.NoError(t, webhook.Verify(testSecret, header(testSecret, now.Add(-time.Hour)), body, 2*time.Hour))
+	assert.ErrorIs(t, webhook.Verify(testSecret, http.Header{}, body, 0), webhook.ErrMissingSignature)
+}
+
+func TestNew(t *testing.T) {
+	_, err := webhook.New(webhook.Config{Secret: testSecret})
+	assert.ErrorIs(t, err, webhook.ErrMissingURLs)
+
+	_, err = webhook.New(webhook.Config{URLs: []string{"https://crm.example.com/hooks"}})
+	assert.ErrorIs(t, err, webhook.ErrMissingSecret)
+
+	_, err = webhook.New(webhook.Config{URLs: []string{"crm.example.com/hooks"}, Secret: testSecret})
+	assert.ErrorIs(t, err, webhook.ErrInvalidURL)
+}
@@ -34,6 +34,9 @@ const (
 var includedPackages = []string{
 	"./config",
 	"./internal/httpserve/handlers",
+	"./internal/auth",
+	"./internal/idempotency",
+	"./internal/httpserve/validation",
 }
 
 // schemaConfig represents the configuration for the schema generator
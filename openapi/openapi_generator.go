@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+
+	"github.com/theopenlane/openlane-cloud/internal/openapi"
+)
+
+// const values used for the openapi generator
+const (
+	specPath       = "./openapi/openapi.json"
+	clientPath     = "./internal/client/operations_gen.go"
+	modelsPath     = "./internal/client/models_gen.go"
+	ownerReadWrite = 0600
+)
+
+func main() {
+	if err := generate(); err != nil {
+		panic(err)
+	}
+}
+
+// generate exports the OpenAPI specification served by the router and generates the client functions and their
+// request and reply types from the exported specification, so the client only depends on what was written to the file
+func generate() error {
+	oas, err := openapi.Spec()
+	if err != nil {
+		return err
+	}
+
+	spec, err := openapi.Marshal(oas)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(specPath, spec, ownerReadWrite); err != nil {
+		return err
+	}
+
+	exported, err := openapi.Load(spec)
+	if err != nil {
+		return err
+	}
+
+	client, err := openapi.GenerateClient(exported)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(clientPath, client, ownerReadWrite); err != nil {
+		return err
+	}
+
+	models, err := openapi.GenerateModels(exported)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(modelsPath, models, ownerReadWrite)
+}